@@ -7,15 +7,22 @@ import (
 	"net/http"
 	"path/filepath"
 	"restaurant-management/configs"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/media"
+	"restaurant-management/internal/domain/notification"
+	"restaurant-management/internal/domain/reservation"
 	"restaurant-management/internal/handler"
 	"restaurant-management/internal/infrastructure/email"
+	"restaurant-management/internal/infrastructure/storage/local"
 	"restaurant-management/internal/infrastructure/storage/postgres"
+	"restaurant-management/internal/infrastructure/storage/s3"
+	"restaurant-management/internal/infrastructure/webhook"
 	"restaurant-management/internal/middleware"
 	"restaurant-management/internal/service"
 	"strings"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
 )
 
@@ -88,7 +95,7 @@ func startNotificationWorker(services *service.Services) {
 					// Send notifications for each low stock item
 					for _, item := range lowStockItems {
 						err := services.Notification.SendLowInventoryAlert(
-							ctx, business.ID, item.Name, item.Quantity, item.MinQuantity, item.Unit,
+							ctx, business.ID, item.Name, item.Quantity, item.MinStock, item.Unit,
 						)
 						if err != nil {
 							log.Printf("Error sending low inventory alert for %s in business %s: %v", item.Name, business.Name, err)
@@ -110,6 +117,163 @@ func startNotificationWorker(services *service.Services) {
 	}()
 }
 
+// startReorderWorker drafts reorder requests for every business once an hour
+func startReorderWorker(services *service.Services) {
+	ticker := time.NewTicker(time.Hour)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx := context.Background()
+			businesses, _, err := services.Business.GetAllBusinesses(ctx)
+			if err != nil {
+				log.Printf("Error getting businesses: %v", err)
+				continue
+			}
+			for _, business := range businesses {
+				draftReorderRequests(ctx, services, business.ID)
+			}
+		}
+	}()
+}
+
+// draftReorderRequests turns the reorder suggestions of a business into draft requests
+// for its managers to approve. Stock already on open requests is not ordered again.
+func draftReorderRequests(ctx context.Context, services *service.Services, businessID int) {
+	plan, err := services.Inventory.CreateReorderRequests(ctx, inventory.ReorderQuery{}, businessID)
+	if err != nil {
+		log.Printf("Error drafting reorder requests for business %d: %v", businessID, err)
+		return
+	}
+	for _, group := range plan.Groups {
+		if group.RequestID != nil {
+			log.Printf("Drafted reorder request %d for %d items from %s", *group.RequestID, len(group.Lines), group.SupplierName)
+		} else {
+			log.Printf("No supplier found for %d low stock items of business %d", len(group.Lines), businessID)
+		}
+	}
+}
+
+// startExpiryWorker checks every business for lots expiring within days every ten minutes,
+// and once at startup
+func startExpiryWorker(services *service.Services, days int) {
+	check := func() {
+		ctx := context.Background()
+		businesses, _, err := services.Business.GetAllBusinesses(ctx)
+		if err != nil {
+			log.Printf("Error getting businesses: %v", err)
+			return
+		}
+		for _, business := range businesses {
+			sendExpiryAlerts(ctx, services, business.ID, days)
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(10 * time.Minute)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			check()
+		}
+	}()
+}
+
+// sendExpiryAlerts tells the managers of a business about lots expiring within days.
+// Each lot is reported once.
+func sendExpiryAlerts(ctx context.Context, services *service.Services, businessID int, days int) {
+	lots, err := services.Inventory.GetExpiringLots(ctx, days, businessID)
+	if err != nil {
+		log.Printf("Error checking expiring lots for business %d: %v", businessID, err)
+		return
+	}
+	if len(lots) == 0 {
+		return
+	}
+
+	stock := make([]notification.ExpiringStock, 0, len(lots))
+	ids := make([]int, 0, len(lots))
+	for _, lot := range lots {
+		daysLeft := 0
+		if lot.DaysLeft != nil {
+			daysLeft = *lot.DaysLeft
+		}
+		stock = append(stock, notification.ExpiringStock{
+			ItemName:  lot.Name,
+			LotNumber: lot.LotNumber,
+			Quantity:  lot.Remaining,
+			Unit:      lot.Unit,
+			ExpiresOn: lot.ExpiresOn,
+			DaysLeft:  daysLeft,
+		})
+		ids = append(ids, lot.ID)
+	}
+
+	if err := services.Notification.SendExpiryAlert(ctx, businessID, stock); err != nil {
+		log.Printf("Error sending expiry alert for business %d: %v", businessID, err)
+		return
+	}
+	if err := services.Inventory.MarkExpiryAlerted(ctx, ids, businessID); err != nil {
+		log.Printf("Error marking expiry alerts for business %d: %v", businessID, err)
+		return
+	}
+	log.Printf("Sent expiry alert for %d lots to managers of business %d", len(lots), businessID)
+}
+
+// startPriceWorker applies scheduled dish prices once a minute, and once at startup to
+// catch up on changes that fell due while the server was down
+func startPriceWorker(services *service.Services) {
+	apply := func() {
+		repriced, err := services.Menu.ApplyDuePriceChanges(context.Background())
+		if err != nil {
+			log.Printf("Error applying scheduled prices: %v", err)
+			return
+		}
+		if repriced > 0 {
+			log.Printf("Applied scheduled prices to %d dishes", repriced)
+		}
+	}
+
+	apply()
+	ticker := time.NewTicker(time.Minute)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			apply()
+		}
+	}()
+}
+
+// startTableWorker frees tables whose cleaning time has passed and switches the tables
+// of upcoming reservations to reserved once a minute
+func startTableWorker(services *service.Services) {
+	hold := func() {
+		freed, err := services.Table.FreeCleanedTables(context.Background(), time.Now())
+		if err != nil {
+			log.Printf("Error freeing cleaned tables: %v", err)
+		} else if freed > 0 {
+			log.Printf("Freed %d tables after cleaning", freed)
+		}
+
+		held, err := services.Reservation.HoldUpcomingTables(context.Background())
+		if err != nil {
+			log.Printf("Error holding tables for reservations: %v", err)
+			return
+		}
+		if held > 0 {
+			log.Printf("Held %d tables for upcoming reservations", held)
+		}
+	}
+
+	hold()
+	ticker := time.NewTicker(time.Minute)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			hold()
+		}
+	}()
+}
+
 func main() {
 	config, err := configs.LoadConfig()
 	if err != nil {
@@ -134,10 +298,29 @@ func main() {
 	requestRepo := postgres.NewRequestRepository(postgresDB)
 	waiterRepo := postgres.NewWaiterRepository(postgresDB)
 	notificationRepo := postgres.NewNotificationRepository(postgresDB)
+	reservationRepo := postgres.NewReservationRepository(postgresDB)
 
 	// Initialize email service
 	emailService := email.NewSMTPService(&config.SMTP)
 
+	// Initialize file storage for uploaded images
+	var fileStorage media.Storage
+	if config.Storage.Driver == "s3" {
+		fileStorage, err = s3.NewStorage(&config.Storage.S3)
+	} else {
+		fileStorage, err = local.NewStorage(&config.Storage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	mediaService := service.NewMediaService(fileStorage, config.Storage.MaxUploadSize)
+
+	// Waitlisted guests are only notified through a gateway when one is configured
+	var guestNotifier reservation.GuestNotifier
+	if config.Reservation.NotifyWebhook != "" {
+		guestNotifier = webhook.NewGuestNotifier(config.Reservation.NotifyWebhook)
+	}
+
 	services := service.NewServices(
 		businessRepo,
 		userRepo,
@@ -150,8 +333,15 @@ func main() {
 		requestRepo,
 		waiterRepo,
 		notificationRepo,
+		reservationRepo,
 		emailService,
+		mediaService,
 		config.Server.JWTKey,
+		config.Guest.PublicBaseURL,
+		config.Guest.SessionTTL,
+		config.Reservation.DefaultDuration,
+		config.Reservation.HoldBefore,
+		guestNotifier,
 	)
 
 	handlers := handler.NewControllers(
@@ -166,6 +356,9 @@ func main() {
 		services.Request,
 		services.Waiter,
 		services.Notification,
+		services.Guest,
+		services.Reservation,
+		middleware.NewRateLimiter(config.Guest.OrdersPerHour, time.Hour),
 	)
 
 	r := mux.NewRouter()
@@ -182,12 +375,36 @@ func main() {
 
 	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", wrappedFileServer))
 
+	// Uploaded images are public so menus and logos can be shown without a session
+	if config.Storage.Driver == "local" && strings.HasPrefix(config.Storage.PublicURL, "/") {
+		uploadsPrefix := strings.TrimSuffix(config.Storage.PublicURL, "/") + "/"
+		uploadsServer := http.FileServer(http.Dir(config.Storage.LocalDir))
+		r.PathPrefix(uploadsPrefix).Handler(http.StripPrefix(uploadsPrefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Serve files only, never directory listings
+			if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			uploadsServer.ServeHTTP(w, r)
+		})))
+	}
+
 	htmlRouter := r.PathPrefix("").Subrouter()
 	htmlRouter.Use(middleware.HTMLAuthMiddleware(config.Server.JWTKey))
 
 	r.HandleFunc("/api/login", handlers.Auth.Login).Methods("POST")
 	r.HandleFunc("/api/login/google", handlers.Auth.GoogleLogin).Methods("POST")
 
+	// Public guest API: no staff cookies or tokens, rate limited per client IP
+	public := r.PathPrefix("/api/public").Subrouter()
+	public.Use(middleware.RateLimitMiddleware(middleware.NewRateLimiter(config.Guest.RequestsPerMinute, time.Minute)))
+	handlers.Guest.RegisterPublicRoutes(public)
+	public.HandleFunc("/allergens", handlers.Menu.GetAllergenCatalogue).Methods("GET")
+
+	r.HandleFunc("/t/{token}", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join(config.Paths.Templates, "guest.html"))
+	}).Methods("GET")
+
 	r.HandleFunc("/api/businesses", handlers.Business.GetAllBusinesses).Methods("GET")
 	r.HandleFunc("/api/businesses/{id}", handlers.Business.GetBusinessByID).Methods("GET")
 	r.HandleFunc("/api/businesses/{id}/select", handlers.Business.SetBusinessCookie).Methods("POST")
@@ -200,6 +417,7 @@ func main() {
 	businessAdmin.HandleFunc("", handlers.Business.CreateBusiness).Methods("POST")
 	businessAdmin.HandleFunc("/{id}", handlers.Business.UpdateBusiness).Methods("PUT")
 	businessAdmin.HandleFunc("/{id}", handlers.Business.DeleteBusiness).Methods("DELETE")
+	businessAdmin.HandleFunc("/{id}/logo", handlers.Business.UploadLogo).Methods("POST")
 
 	admin := api.PathPrefix("/admin").Subrouter()
 	admin.HandleFunc("/stats", handlers.Admin.GetStats).Methods("GET")
@@ -219,9 +437,34 @@ func main() {
 
 	manager.HandleFunc("/inventory", handlers.Inventory.GetAll).Methods("GET")
 	manager.HandleFunc("/inventory", handlers.Inventory.Create).Methods("POST")
+	manager.HandleFunc("/inventory/units", handlers.Inventory.GetUnits).Methods("GET")
+	manager.HandleFunc("/inventory/valuation", handlers.Inventory.GetValuation).Methods("GET")
+	manager.HandleFunc("/inventory/cost-of-goods", handlers.Inventory.GetCostOfGoods).Methods("GET")
+	manager.HandleFunc("/inventory/reorder", handlers.Inventory.GetReorderSuggestions).Methods("GET")
+	manager.HandleFunc("/inventory/reorder", handlers.Inventory.CreateReorderRequests).Methods("POST")
+	manager.HandleFunc("/inventory/lots", handlers.Inventory.GetLots).Methods("GET")
+	manager.HandleFunc("/inventory/lots/expired", handlers.Inventory.GetExpiredLots).Methods("GET")
+	manager.HandleFunc("/inventory/lots/expired/write-off", handlers.Inventory.WriteOffExpiredLots).Methods("POST")
+	manager.HandleFunc("/inventory/lots/{id:[0-9]+}", handlers.Inventory.GetLot).Methods("GET")
 	manager.HandleFunc("/inventory/{id}", handlers.Inventory.GetByID).Methods("GET")
 	manager.HandleFunc("/inventory/{id}", handlers.Inventory.Update).Methods("PUT")
 	manager.HandleFunc("/inventory/{id}", handlers.Inventory.Delete).Methods("DELETE")
+	manager.HandleFunc("/inventory/{id}/movements", handlers.Inventory.GetMovements).Methods("GET")
+	manager.HandleFunc("/inventory/{id}/movements", handlers.Inventory.RecordMovement).Methods("POST")
+
+	manager.HandleFunc("/stocktakes", handlers.Inventory.GetStocktakes).Methods("GET")
+	manager.HandleFunc("/stocktakes", handlers.Inventory.OpenStocktake).Methods("POST")
+	manager.HandleFunc("/stocktakes/variance", handlers.Inventory.GetVarianceReport).Methods("GET")
+	manager.HandleFunc("/stocktakes/{id:[0-9]+}", handlers.Inventory.GetStocktake).Methods("GET")
+	manager.HandleFunc("/stocktakes/{id:[0-9]+}/counts", handlers.Inventory.SubmitCounts).Methods("POST")
+	manager.HandleFunc("/stocktakes/{id:[0-9]+}/approve", handlers.Inventory.ApproveStocktake).Methods("POST")
+	manager.HandleFunc("/stocktakes/{id:[0-9]+}/cancel", handlers.Inventory.CancelStocktake).Methods("POST")
+
+	manager.HandleFunc("/waste", handlers.Inventory.GetWasteEntries).Methods("GET")
+	manager.HandleFunc("/waste", handlers.Inventory.LogWaste).Methods("POST")
+	manager.HandleFunc("/waste/report", handlers.Inventory.GetWasteReport).Methods("GET")
+	manager.HandleFunc("/waste/{id:[0-9]+}", handlers.Inventory.GetWaste).Methods("GET")
+	manager.HandleFunc("/waste/{id:[0-9]+}/photo", handlers.Inventory.UploadWastePhoto).Methods("POST")
 
 	manager.HandleFunc("/suppliers", handlers.Supplier.GetAll).Methods("GET")
 	manager.HandleFunc("/suppliers", handlers.Supplier.Create).Methods("POST")
@@ -234,6 +477,7 @@ func main() {
 	manager.HandleFunc("/requests/{id}", handlers.Request.GetByID).Methods("GET")
 	manager.HandleFunc("/requests/{id}", handlers.Request.Update).Methods("PUT")
 	manager.HandleFunc("/requests/{id}", handlers.Request.Delete).Methods("DELETE")
+	manager.HandleFunc("/requests/{id}/approve", handlers.Request.Approve).Methods("POST")
 
 	manager.HandleFunc("/notifications", handlers.Notification.GetRecentNotifications).Methods("GET")
 	manager.HandleFunc("/notifications/stats", handlers.Notification.GetNotificationStats).Methods("GET")
@@ -242,23 +486,50 @@ func main() {
 	manager.HandleFunc("/notifications/hiring-alert", handlers.Notification.SendNewHiringAlert).Methods("POST")
 	manager.HandleFunc("/notifications/process", handlers.Notification.ProcessPendingNotifications).Methods("POST")
 
+	manager.HandleFunc("/menu/engineering", handlers.Menu.GetMenuEngineering).Methods("GET")
+
+	handlers.Guest.RegisterManagerRoutes(manager)
+	handlers.Table.RegisterManagerRoutes(manager)
+
+	manager.HandleFunc("/sections", handlers.Waiter.GetSections).Methods("GET")
+	manager.HandleFunc("/sections", handlers.Waiter.SetSection).Methods("PUT")
+	manager.HandleFunc("/sections/handoff", handlers.Waiter.Handoff).Methods("POST")
+
 	waiter := api.PathPrefix("/waiter").Subrouter()
 	waiter.HandleFunc("/tables", handlers.Waiter.GetTables).Methods("GET")
 	waiter.HandleFunc("/tables/{id}/status", handlers.Waiter.UpdateTableStatus).Methods("PUT")
+	waiter.HandleFunc("/floor-plan", handlers.Table.GetFloorPlan).Methods("GET")
 	waiter.HandleFunc("/orders", handlers.Waiter.GetActiveOrders).Methods("GET")
 	waiter.HandleFunc("/history", handlers.Waiter.GetOrderHistory).Methods("GET")
 	waiter.HandleFunc("/orders", handlers.Waiter.CreateOrder).Methods("POST")
 	waiter.HandleFunc("/orders/{id}/status", handlers.Waiter.UpdateOrderStatus).Methods("PUT")
 	waiter.HandleFunc("/profile", handlers.Waiter.GetProfile).Methods("GET")
+	waiter.HandleFunc("/handoff", handlers.Waiter.HandoffOwn).Methods("POST")
 
 	kitchen := api.PathPrefix("/kitchen").Subrouter()
 	kitchen.HandleFunc("/orders", handlers.Kitchen.GetKitchenOrders).Methods("GET")
 	kitchen.HandleFunc("/orders/{id}/status", handlers.Kitchen.UpdateOrderStatusByCook).Methods("PUT")
 	kitchen.HandleFunc("/history", handlers.Kitchen.GetKitchenHistory).Methods("GET")
 	kitchen.HandleFunc("/inventory", handlers.Kitchen.GetInventory).Methods("GET")
+	kitchen.HandleFunc("/inventory/units", handlers.Inventory.GetUnits).Methods("GET")
+	kitchen.HandleFunc("/inventory/lots", handlers.Inventory.GetLots).Methods("GET")
+	kitchen.HandleFunc("/inventory/lots/expired", handlers.Inventory.GetExpiredLots).Methods("GET")
+	kitchen.HandleFunc("/inventory/lots/expired/write-off", handlers.Inventory.WriteOffExpiredLots).Methods("POST")
+	kitchen.HandleFunc("/inventory/lots/{id:[0-9]+}", handlers.Inventory.GetLot).Methods("GET")
 	kitchen.HandleFunc("/inventory/{id}", handlers.Kitchen.UpdateInventory).Methods("PUT")
+	kitchen.HandleFunc("/inventory/{id}/movements", handlers.Inventory.GetMovements).Methods("GET")
+	kitchen.HandleFunc("/inventory/{id}/movements", handlers.Inventory.RecordKitchenMovement).Methods("POST")
+	kitchen.HandleFunc("/stocktakes", handlers.Inventory.GetStocktakes).Methods("GET")
+	kitchen.HandleFunc("/stocktakes/{id:[0-9]+}", handlers.Inventory.GetStocktake).Methods("GET")
+	kitchen.HandleFunc("/stocktakes/{id:[0-9]+}/counts", handlers.Inventory.SubmitCounts).Methods("POST")
+	kitchen.HandleFunc("/waste", handlers.Inventory.GetWasteEntries).Methods("GET")
+	kitchen.HandleFunc("/waste", handlers.Inventory.LogWaste).Methods("POST")
+	kitchen.HandleFunc("/waste/{id:[0-9]+}", handlers.Inventory.GetWaste).Methods("GET")
+	kitchen.HandleFunc("/waste/{id:[0-9]+}/photo", handlers.Inventory.UploadWastePhoto).Methods("POST")
 
 	handlers.Menu.RegisterRoutes(api)
+	handlers.Reservation.RegisterRoutes(api)
+	handlers.Reservation.RegisterWaitlistRoutes(api)
 
 	apiRouter := api.PathPrefix("/shifts").Subrouter()
 	apiRouter.HandleFunc("", handlers.Shift.GetEmployeeShifts).Methods("GET")
@@ -345,6 +616,10 @@ func main() {
 
 	// Start background notification worker
 	startNotificationWorker(services)
+	startExpiryWorker(services, config.Inventory.ExpiryAlertDays)
+	startReorderWorker(services)
+	startPriceWorker(services)
+	startTableWorker(services)
 
 	log.Printf("Server starting on port %s", config.Server.Port)
 	log.Fatal(http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", config.Server.Port), r))
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -29,8 +31,12 @@ type Config struct {
 		Static      string
 		Templates   string
 	}
-	Google GoogleConfig
-	SMTP   SMTPConfig
+	Google      GoogleConfig
+	SMTP        SMTPConfig
+	Storage     StorageConfig
+	Guest       GuestConfig
+	Reservation ReservationConfig
+	Inventory   InventoryConfig
 }
 
 // GoogleConfig contains Google OAuth configuration
@@ -49,6 +55,46 @@ type SMTPConfig struct {
 	From     string
 }
 
+// StorageConfig contains file storage configuration for uploaded images
+type StorageConfig struct {
+	Driver        string // local or s3
+	LocalDir      string
+	PublicURL     string // URL prefix the local directory is served under
+	MaxUploadSize int64  // bytes
+	S3            S3Config
+}
+
+// S3Config contains configuration for an S3-compatible bucket
+type S3Config struct {
+	Endpoint     string // empty for AWS, e.g. http://localhost:9000 for MinIO
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	PublicURL    string // optional CDN or bucket URL used in image links
+	UsePathStyle bool   // endpoint/bucket/key instead of bucket.endpoint/key
+}
+
+// GuestConfig contains configuration for guest ordering from table QR codes
+type GuestConfig struct {
+	PublicBaseURL     string // scheme and host encoded in QR codes, e.g. https://menu.example.com
+	SessionTTL        time.Duration
+	RequestsPerMinute int // per client IP on the public API
+	OrdersPerHour     int // per table
+}
+
+// ReservationConfig contains configuration for table reservations
+type ReservationConfig struct {
+	DefaultDuration time.Duration // length of a booking made without a duration
+	HoldBefore      time.Duration // how long before a booking its tables switch to reserved
+	NotifyWebhook   string        // optional URL told when a waitlisted party's table is ready
+}
+
+// InventoryConfig contains configuration for stock tracking
+type InventoryConfig struct {
+	ExpiryAlertDays int // lots expiring within this many days are reported to managers
+}
+
 // LoadConfig loads configuration from .env file
 func LoadConfig() (*Config, error) {
 	config := &Config{}
@@ -190,6 +236,78 @@ func LoadConfig() (*Config, error) {
 		return nil, envErr
 	}
 
+	// Storage configuration (optional, defaults to the local filesystem)
+	config.Storage.Driver = getEnv("STORAGE_DRIVER", "local")
+	config.Storage.LocalDir = getEnv("STORAGE_LOCAL_DIR", filepath.Join(projectRoot, "uploads"))
+	config.Storage.PublicURL = getEnv("STORAGE_PUBLIC_URL", "/uploads")
+
+	maxUploadMB, err := strconv.Atoi(getEnv("UPLOAD_MAX_SIZE_MB", "5"))
+	if err != nil || maxUploadMB <= 0 {
+		return nil, fmt.Errorf("invalid UPLOAD_MAX_SIZE_MB, must be a positive integer")
+	}
+	config.Storage.MaxUploadSize = int64(maxUploadMB) << 20
+
+	if config.Storage.Driver == "s3" {
+		config.Storage.S3.Endpoint = os.Getenv("S3_ENDPOINT")
+		config.Storage.S3.Region = getEnv("S3_REGION", "us-east-1")
+		config.Storage.S3.PublicURL = os.Getenv("S3_PUBLIC_URL")
+		config.Storage.S3.UsePathStyle, _ = strconv.ParseBool(os.Getenv("S3_USE_PATH_STYLE"))
+
+		config.Storage.S3.Bucket, envErr = getRequiredEnv("S3_BUCKET")
+		if envErr != nil {
+			return nil, envErr
+		}
+		config.Storage.S3.AccessKey, envErr = getRequiredEnv("S3_ACCESS_KEY")
+		if envErr != nil {
+			return nil, envErr
+		}
+		config.Storage.S3.SecretKey, envErr = getRequiredEnv("S3_SECRET_KEY")
+		if envErr != nil {
+			return nil, envErr
+		}
+	} else if config.Storage.Driver != "local" {
+		return nil, fmt.Errorf("invalid STORAGE_DRIVER %q, must be local or s3", config.Storage.Driver)
+	}
+
+	// Guest ordering configuration (optional)
+	config.Guest.PublicBaseURL = strings.TrimSuffix(getEnv("PUBLIC_BASE_URL", "http://localhost:"+config.Server.Port), "/")
+
+	sessionTTL, err := strconv.Atoi(getEnv("GUEST_SESSION_TTL_MINUTES", "180"))
+	if err != nil || sessionTTL <= 0 {
+		return nil, fmt.Errorf("invalid GUEST_SESSION_TTL_MINUTES, must be a positive integer")
+	}
+	config.Guest.SessionTTL = time.Duration(sessionTTL) * time.Minute
+
+	config.Guest.RequestsPerMinute, err = strconv.Atoi(getEnv("GUEST_RATE_LIMIT_PER_MINUTE", "60"))
+	if err != nil || config.Guest.RequestsPerMinute <= 0 {
+		return nil, fmt.Errorf("invalid GUEST_RATE_LIMIT_PER_MINUTE, must be a positive integer")
+	}
+
+	config.Guest.OrdersPerHour, err = strconv.Atoi(getEnv("GUEST_ORDERS_PER_HOUR", "10"))
+	if err != nil || config.Guest.OrdersPerHour <= 0 {
+		return nil, fmt.Errorf("invalid GUEST_ORDERS_PER_HOUR, must be a positive integer")
+	}
+
+	// Reservation configuration (optional)
+	reservationMinutes, err := strconv.Atoi(getEnv("RESERVATION_DEFAULT_DURATION_MINUTES", "120"))
+	if err != nil || reservationMinutes <= 0 {
+		return nil, fmt.Errorf("invalid RESERVATION_DEFAULT_DURATION_MINUTES, must be a positive integer")
+	}
+	config.Reservation.DefaultDuration = time.Duration(reservationMinutes) * time.Minute
+
+	holdMinutes, err := strconv.Atoi(getEnv("RESERVATION_HOLD_MINUTES", "30"))
+	if err != nil || holdMinutes < 0 {
+		return nil, fmt.Errorf("invalid RESERVATION_HOLD_MINUTES, must be a non-negative integer")
+	}
+	config.Reservation.HoldBefore = time.Duration(holdMinutes) * time.Minute
+	config.Reservation.NotifyWebhook = os.Getenv("WAITLIST_NOTIFY_WEBHOOK_URL")
+
+	// Inventory configuration (optional)
+	config.Inventory.ExpiryAlertDays, err = strconv.Atoi(getEnv("INVENTORY_EXPIRY_ALERT_DAYS", "3"))
+	if err != nil || config.Inventory.ExpiryAlertDays < 0 {
+		return nil, fmt.Errorf("invalid INVENTORY_EXPIRY_ALERT_DAYS, must be a non-negative integer")
+	}
+
 	config.Paths.ProjectRoot = projectRoot
 	config.Paths.Frontend = filepath.Join(projectRoot, frontendPath)
 	config.Paths.Static = filepath.Join(config.Paths.Frontend, "static")
@@ -220,6 +338,14 @@ func getRequiredEnv(key string) (string, error) {
 	return value, nil
 }
 
+// getEnv gets an environment variable or returns the fallback if it's not set
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
 func (c *Config) GetDBConnString() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
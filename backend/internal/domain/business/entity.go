@@ -4,19 +4,46 @@ import "time"
 
 // Business represents a business entity
 type Business struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	Address     string    `json:"address,omitempty"`
-	Phone       string    `json:"phone,omitempty"`
-	Email       string    `json:"email,omitempty"`
-	Website     string    `json:"website,omitempty"`
-	Logo        string    `json:"logo,omitempty"`
-	Status      string    `json:"status"` // active, inactive, suspended
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                 int               `json:"id"`
+	Name               string            `json:"name"`
+	Description        string            `json:"description,omitempty"`
+	Address            string            `json:"address,omitempty"`
+	Phone              string            `json:"phone,omitempty"`
+	Email              string            `json:"email,omitempty"`
+	Website            string            `json:"website,omitempty"`
+	Logo               string            `json:"logo,omitempty"`
+	LogoThumbnails     map[string]string `json:"logo_thumbnails,omitempty"` // size name -> URL, for uploaded logos
+	Timezone           string            `json:"timezone,omitempty"`        // IANA name, e.g. Asia/Almaty
+	DefaultLanguage    string            `json:"default_language"`          // language of the base menu texts
+	SupportedLanguages []string          `json:"supported_languages"`       // always includes the default language
+	AllergenPolicy     string            `json:"allergen_policy"`           // warn or block orders that conflict with guest allergies
+	CostingMethod      string            `json:"costing_method"`            // how stock is valued: average or fifo
+	// TableAutoFreeMinutes frees a table this long after it started waiting for cleaning; 0 leaves it to staff
+	TableAutoFreeMinutes *int      `json:"table_auto_free_minutes,omitempty"`
+	Status               string    `json:"status"` // active, inactive, suspended
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
 }
 
+// DefaultLanguage is the menu language of businesses that have not chosen one
+const DefaultLanguage = "ru"
+
+// DefaultLocation is the clock of businesses without a timezone: fixed UTC+5, which the
+// shift and schedule times were always kept in before businesses could choose one
+var DefaultLocation = time.FixedZone("UTC+5", 5*60*60)
+
+// Allergen policies decide what happens when an order contains a dish the guest is allergic to
+const (
+	AllergenPolicyWarn  = "warn"  // accept the order and return warnings
+	AllergenPolicyBlock = "block" // reject the order
+)
+
+// Costing methods decide which cost the stock valuation and the cost of goods use
+const (
+	CostingAverage = "average" // weighted average cost, updated by every costed receipt
+	CostingFIFO    = "fifo"    // first in, first out: stock leaves at the cost of the oldest receipts
+)
+
 // BusinessStats represents business statistics
 type BusinessStats struct {
 	Total    int `json:"total"`
@@ -1,6 +1,9 @@
 package business
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Service defines the business service interface
 type Service interface {
@@ -21,4 +24,7 @@ type Service interface {
 
 	// SetBusinessCookie sets a business cookie for user session
 	SetBusinessCookie(ctx context.Context, businessID int) error
+
+	// UploadLogo stores a business logo with its thumbnails, replacing any previous upload
+	UploadLogo(ctx context.Context, id int, r io.Reader) (*Business, error)
 }
@@ -0,0 +1,84 @@
+package guest
+
+import (
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/domain/order"
+	"time"
+)
+
+// QR code image formats
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+// TableLink represents the signed link encoded in the QR code of a table
+type TableLink struct {
+	TableID     int    `json:"table_id"`
+	TableNumber int    `json:"table_number"`
+	Version     int    `json:"version"` // QR version of the table; rotating it invalidates printed codes
+	Token       string `json:"token"`
+	URL         string `json:"url"`
+}
+
+// Session represents a guest who scanned the QR code of a table. Sessions are not
+// stored: the signed token carries everything the server needs.
+type Session struct {
+	ID          string    `json:"id"`
+	BusinessID  int       `json:"business_id"`
+	TableID     int       `json:"table_id"`
+	TableNumber int       `json:"table_number"`
+	QRVersion   int       `json:"-"`
+	Token       string    `json:"token,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// PublicMenu represents the menu shown to guests, without staff-only fields
+type PublicMenu struct {
+	BusinessID   int              `json:"business_id"`
+	BusinessName string           `json:"business_name"`
+	Logo         string           `json:"logo,omitempty"`
+	Language     string           `json:"language"`
+	Languages    []string         `json:"languages"`
+	Categories   []PublicCategory `json:"categories"`
+	Bundles      []PublicBundle   `json:"bundles"`
+}
+
+// PublicBundle represents a combo or set menu guests can order, with the slots to choose dishes for
+type PublicBundle struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Price       float64           `json:"price"`
+	Slots       []menu.BundleSlot `json:"slots"`
+}
+
+// PublicCategory represents a menu category with the dishes guests can order
+type PublicCategory struct {
+	ID    int              `json:"id"`
+	Name  string           `json:"name"`
+	Items []PublicMenuItem `json:"items"`
+}
+
+// PublicMenuItem represents a dish as shown to guests
+type PublicMenuItem struct {
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	Price           float64           `json:"price"`
+	ImageURL        string            `json:"image_url,omitempty"`
+	Thumbnails      map[string]string `json:"thumbnails,omitempty"`
+	PreparationTime int               `json:"preparation_time,omitempty"`
+	Calories        int               `json:"calories,omitempty"`
+	Allergens       []string          `json:"allergens,omitempty"`
+	DietaryTags     []string          `json:"dietary_tags,omitempty"`
+}
+
+// OrderRequest represents an order submitted by a guest. The table is taken from the session.
+type OrderRequest struct {
+	Comment   string                   `json:"comment,omitempty"`
+	Items     []order.OrderItemInput   `json:"items"`
+	Bundles   []order.OrderBundleInput `json:"bundles,omitempty"`
+	Allergies []string                 `json:"allergies,omitempty"`
+	Seats     []order.SeatAllergies    `json:"seats,omitempty"`
+}
@@ -0,0 +1,17 @@
+package guest
+
+import "errors"
+
+var (
+	// ErrInvalidQRToken is returned when a table QR token is malformed, forged or has been rotated
+	ErrInvalidQRToken = errors.New("invalid table QR code")
+
+	// ErrInvalidSession is returned when a guest session token is invalid or expired
+	ErrInvalidSession = errors.New("invalid or expired guest session")
+
+	// ErrInvalidQRFormat is returned when a QR image format other than png or svg, or an unsupported size, is requested
+	ErrInvalidQRFormat = errors.New("invalid QR code format or size")
+
+	// ErrBusinessUnavailable is returned when the business does not accept guests
+	ErrBusinessUnavailable = errors.New("business is not available")
+)
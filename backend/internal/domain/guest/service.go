@@ -0,0 +1,32 @@
+package guest
+
+import (
+	"context"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/domain/order"
+)
+
+// Service defines the guest ordering service interface. Guests are not users: they
+// are identified by the signed QR code of a table and a short-lived session token.
+type Service interface {
+	// TableLink returns the signed link encoded in the QR code of a table
+	TableLink(ctx context.Context, tableID int, businessID int) (*TableLink, error)
+
+	// TableQR renders the QR code of a table as a PNG or SVG image, size pixels wide
+	TableQR(ctx context.Context, tableID int, businessID int, format string, size int) ([]byte, error)
+
+	// RotateTableLink invalidates the printed QR codes of a table and returns the new link
+	RotateTableLink(ctx context.Context, tableID int, businessID int) (*TableLink, error)
+
+	// StartSession opens a guest session from a scanned QR token
+	StartSession(ctx context.Context, qrToken string) (*Session, error)
+
+	// ParseSession validates a guest session token
+	ParseSession(ctx context.Context, token string) (*Session, error)
+
+	// GetMenu returns the dishes on offer now, in the first requested language the business supports
+	GetMenu(ctx context.Context, businessID int, filter menu.MenuItemFilter, languages []string) (*PublicMenu, error)
+
+	// PlaceOrder submits a guest order as a new order on the session table
+	PlaceOrder(ctx context.Context, session *Session, req OrderRequest) (*order.Order, error)
+}
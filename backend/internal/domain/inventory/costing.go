@@ -0,0 +1,77 @@
+package inventory
+
+// CostLayerTotal sums the FIFO layers still holding stock of one item
+type CostLayerTotal struct {
+	InventoryID int
+	Remaining   float64 // stock covered by layers
+	Value       float64 // value of the costed layers
+	Uncosted    float64 // stock in layers without a cost
+}
+
+// ItemValuation is the value of the stock of one item
+type ItemValuation struct {
+	InventoryID int      `json:"inventory_id"`
+	Name        string   `json:"name"`
+	Unit        string   `json:"unit"`
+	Quantity    float64  `json:"quantity"`
+	UnitCost    *float64 `json:"unit_cost,omitempty"` // value per unit under the method; nil when unknown
+	Value       float64  `json:"value"`
+	Uncosted    bool     `json:"uncosted,omitempty"` // part of the stock has no cost, so Value leaves it out
+}
+
+// Valuation is the value of the stock on hand of a business under one costing method
+type Valuation struct {
+	Method   string          `json:"method"`
+	Value    float64         `json:"value"`
+	Uncosted int             `json:"uncosted"` // items whose stock is not fully costed
+	Items    []ItemValuation `json:"items"`
+}
+
+// CostOfGoodsQuery holds the inclusive local dates and costing method of the cost of
+// goods report. An empty method uses the business setting.
+type CostOfGoodsQuery struct {
+	From   string
+	To     string
+	Method string
+}
+
+// CostLine is the stock of one item that left through one movement type, as read for
+// the cost of goods report
+type CostLine struct {
+	InventoryID  int
+	Name         string
+	Unit         string
+	Type         MovementType
+	Quantity     float64 // positive amount used
+	AverageValue float64 // value at the weighted average cost
+	FIFOValue    float64 // value at the FIFO cost
+	Uncosted     bool    // some movements have no cost under a method
+	FIFOUncosted bool
+}
+
+// ItemCostOfGoods is the cost of one item used in the period
+type ItemCostOfGoods struct {
+	InventoryID int     `json:"inventory_id"`
+	Name        string  `json:"name"`
+	Unit        string  `json:"unit"`
+	Consumed    float64 `json:"consumed"`
+	Wasted      float64 `json:"wasted"`
+	Consumption float64 `json:"consumption"` // cost of the stock consumed by orders and by hand
+	Waste       float64 `json:"waste"`       // cost of the stock wasted
+	Total       float64 `json:"total"`
+	Uncosted    bool    `json:"uncosted,omitempty"` // part of the stock has no cost, so the values leave it out
+}
+
+// CostOfGoods is the cost of the stock used in a period, set against the sales of
+// completed orders. FoodCostPercent is nil without sales.
+type CostOfGoods struct {
+	From            string            `json:"from"`
+	To              string            `json:"to"`
+	Method          string            `json:"method"`
+	Consumption     float64           `json:"consumption"`
+	Waste           float64           `json:"waste"`
+	Total           float64           `json:"total"`
+	Sales           float64           `json:"sales"`
+	FoodCostPercent *float64          `json:"food_cost_percent"`
+	Items           []ItemCostOfGoods `json:"items"`
+}
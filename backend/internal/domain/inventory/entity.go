@@ -4,13 +4,21 @@ import "time"
 
 // Inventory represents an inventory item entity
 type Inventory struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category"`
-	Quantity    float64   `json:"quantity"`
-	Unit        string    `json:"unit"`
-	MinQuantity float64   `json:"min_quantity"`
-	BusinessID  int       `json:"business_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Category    string         `json:"category"`
+	Quantity    float64        `json:"quantity"`
+	Unit        string         `json:"unit"`
+	MinQuantity float64        `json:"min_quantity"`
+	MinUnit     string         `json:"min_unit,omitempty"`    // unit of MinQuantity; empty for the stock unit
+	MinStock    float64        `json:"min_stock"`             // MinQuantity converted to the stock unit, compared with Quantity
+	ParQuantity float64        `json:"par_quantity"`          // stock wanted after a reorder, in the stock unit; 0 orders by usage only
+	Conversions []Conversion   `json:"conversions,omitempty"` // item units, e.g. 1 case = 12 bottles; nil on update keeps them
+	Suppliers   []ItemSupplier `json:"suppliers,omitempty"`   // suppliers selling the item; nil on update keeps them
+	Allergens   []string       `json:"allergens,omitempty"`   // canonical allergen codes, checked against guest allergies
+	UnitCost    *float64       `json:"unit_cost,omitempty"`   // purchase cost of one unit; nil when unknown
+	ChangedBy   int            `json:"-"`                     // user editing the item, recorded on the stock ledger
+	BusinessID  int            `json:"business_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
 }
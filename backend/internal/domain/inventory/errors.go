@@ -20,4 +20,69 @@ var (
 
 	// ErrLowStock is returned when inventory stock is low
 	ErrLowStock = errors.New("inventory stock is low")
+
+	// ErrInvalidMovement is returned when a stock movement fails validation
+	ErrInvalidMovement = errors.New("invalid stock movement")
+
+	// ErrInsufficientStock is returned when a movement would take the stock below zero
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	// ErrInvalidMovementPeriod is returned when the from/to dates of the movement history cannot be parsed
+	ErrInvalidMovementPeriod = errors.New("invalid movement period")
+
+	// ErrIncompatibleUnits is returned when a quantity cannot be converted to the unit of the item
+	ErrIncompatibleUnits = errors.New("incompatible units")
+
+	// ErrInvalidConversion is returned when a unit conversion is malformed or contradicts another
+	ErrInvalidConversion = errors.New("invalid unit conversion")
+
+	// ErrStocktakeNotFound is returned when a stocktake session is not found
+	ErrStocktakeNotFound = errors.New("stocktake not found")
+
+	// ErrStocktakeInProgress is returned when a session is opened while another one is still open
+	ErrStocktakeInProgress = errors.New("stocktake already in progress")
+
+	// ErrStocktakeClosed is returned when counting, approving or cancelling a session that is no longer open
+	ErrStocktakeClosed = errors.New("stocktake is closed")
+
+	// ErrInvalidStocktake is returned when a session would count no items
+	ErrInvalidStocktake = errors.New("invalid stocktake")
+
+	// ErrInvalidCount is returned when a count is negative or for an item outside the session
+	ErrInvalidCount = errors.New("invalid count")
+
+	// ErrInvalidVariancePeriod is returned when the period of a variance report cannot be parsed
+	ErrInvalidVariancePeriod = errors.New("invalid variance period")
+
+	// ErrWasteNotFound is returned when a waste log entry is not found
+	ErrWasteNotFound = errors.New("waste entry not found")
+
+	// ErrInvalidWaste is returned when waste has no valid reason or quantity, or not exactly one item or dish
+	ErrInvalidWaste = errors.New("invalid waste entry")
+
+	// ErrInvalidWastePeriod is returned when the period of the waste log or report cannot be parsed
+	ErrInvalidWastePeriod = errors.New("invalid waste period")
+
+	// ErrInvalidCostingMethod is returned when a costing method is neither average nor fifo
+	ErrInvalidCostingMethod = errors.New("invalid costing method")
+
+	// ErrInvalidCostPeriod is returned when the period of the cost of goods report cannot be parsed
+	ErrInvalidCostPeriod = errors.New("invalid cost of goods period")
+
+	// ErrLotNotFound is returned when a lot is not found
+	ErrLotNotFound = errors.New("lot not found")
+
+	// ErrInvalidLot is returned when lot details are given outside a receipt, the expiry date
+	// cannot be parsed or the supplier is unknown
+	ErrInvalidLot = errors.New("invalid lot")
+
+	// ErrInvalidWriteOff is returned when a lot to write off is not expired or has no stock left
+	ErrInvalidWriteOff = errors.New("invalid write-off")
+
+	// ErrInvalidSupplier is returned when an item is linked to an unknown supplier, the same
+	// supplier twice or more than one preferred supplier
+	ErrInvalidSupplier = errors.New("invalid item supplier")
+
+	// ErrInvalidReorder is returned when the cover or lookback days of reorder suggestions are out of range
+	ErrInvalidReorder = errors.New("invalid reorder parameters")
 )
@@ -0,0 +1,54 @@
+package inventory
+
+import "time"
+
+// Lot is the stock of one item brought in by one receipt. Stock leaves the lots
+// first-expiring-first-out; lots without an expiry date go last.
+type Lot struct {
+	ID           int       `json:"id"`
+	InventoryID  int       `json:"inventory_id"`
+	BusinessID   int       `json:"business_id"`
+	Name         string    `json:"name"` // item name
+	Unit         string    `json:"unit"` // stock unit of the item
+	LotNumber    string    `json:"lot_number,omitempty"`
+	SupplierID   *int      `json:"supplier_id,omitempty"`
+	SupplierName string    `json:"supplier_name,omitempty"`
+	Quantity     float64   `json:"quantity"` // received
+	Remaining    float64   `json:"remaining"`
+	ExpiresOn    string    `json:"expires_on,omitempty"` // YYYY-MM-DD; empty when the stock does not expire
+	DaysLeft     *int      `json:"days_left,omitempty"`  // days until expiry in business local time, negative once expired
+	ReceivedAt   time.Time `json:"received_at"`
+	Draws        []LotDraw `json:"draws,omitempty"`
+}
+
+// LotDraw is the stock one movement took out of a lot
+type LotDraw struct {
+	MovementID int          `json:"movement_id"`
+	Type       MovementType `json:"type"`
+	Quantity   float64      `json:"quantity"`
+	Reason     string       `json:"reason,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// LotQuery holds the query parameters of the lot list
+type LotQuery struct {
+	InventoryID    int
+	All            bool // include used-up lots
+	ExpiringWithin *int // only lots expiring within this many days, expired ones included
+}
+
+// LotFilter narrows the lot list. Zero values are ignored.
+type LotFilter struct {
+	InventoryID   int
+	Open          bool   // only lots with stock left
+	ExpiresBefore string // YYYY-MM-DD, exclusive
+	Unalerted     bool   // only lots no expiry alert was sent for
+}
+
+// WriteOffRequest writes expired lots off as spoiled waste. No lot IDs means every
+// expired lot of the business.
+type WriteOffRequest struct {
+	LotIDs []int  `json:"lot_ids"`
+	Note   string `json:"note"`
+	UserID int    `json:"-"`
+}
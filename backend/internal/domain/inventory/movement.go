@@ -0,0 +1,87 @@
+package inventory
+
+import "time"
+
+// MovementType is the kind of a stock movement
+type MovementType string
+
+const (
+	MovementReceipt     MovementType = "receipt"     // goods received, e.g. against a supplier request
+	MovementConsumption MovementType = "consumption" // used in the kitchen or by an order
+	MovementWaste       MovementType = "waste"       // spoiled, spilled or otherwise lost
+	MovementAdjustment  MovementType = "adjustment"  // correction of the book stock, either way
+	MovementTransfer    MovementType = "transfer"    // moved to or from another inventory item
+)
+
+// Movement is one entry of the append-only stock ledger. Quantity is the signed
+// change of the item's stock and BalanceAfter the stock once it was applied.
+type Movement struct {
+	ID             int          `json:"id"`
+	InventoryID    int          `json:"inventory_id"`
+	BusinessID     int          `json:"business_id"`
+	Type           MovementType `json:"type"`
+	Quantity       float64      `json:"quantity"`
+	BalanceAfter   float64      `json:"balance_after"`
+	Unit           string       `json:"unit"`              // stock unit of the item when the movement was recorded
+	UserID         int          `json:"user_id,omitempty"` // 0 for movements recorded by the system
+	UserName       string       `json:"user_name,omitempty"`
+	Reason         string       `json:"reason,omitempty"`
+	OrderID        *int         `json:"order_id,omitempty"`
+	RequestID      *int         `json:"request_id,omitempty"`
+	TransferItemID *int         `json:"transfer_item_id,omitempty"` // the other item of a transfer
+	WasteID        *int         `json:"waste_id,omitempty"`         // waste log entry behind a waste movement
+	UnitCost       *float64     `json:"unit_cost,omitempty"`        // weighted average cost of one unit, or the cost paid for a receipt
+	FIFOCost       *float64     `json:"fifo_cost,omitempty"`        // cost of one unit under FIFO
+	LotID          *int         `json:"lot_id,omitempty"`           // lot opened by a receipt, or drawn first by an outflow
+	Lot            *Lot         `json:"-"`                          // details of the lot a receipt opens
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// MovementRequest records a stock movement. Quantity is the amount moved and must be
+// positive, except for adjustments, which carry the signed correction.
+type MovementRequest struct {
+	Type          MovementType `json:"type"`
+	Quantity      float64      `json:"quantity"`
+	Unit          string       `json:"unit,omitempty"`        // unit of Quantity; empty for the stock unit
+	UnitCost      *float64     `json:"unit_cost,omitempty"`   // price paid per Unit; receipts only
+	LotNumber     string       `json:"lot_number,omitempty"`  // receipts only
+	ExpiresOn     string       `json:"expires_on,omitempty"`  // YYYY-MM-DD; receipts only
+	SupplierID    int          `json:"supplier_id,omitempty"` // receipts only
+	Reason        string       `json:"reason"`
+	OrderID       *int         `json:"order_id,omitempty"`
+	RequestID     *int         `json:"request_id,omitempty"`
+	ToInventoryID int          `json:"to_inventory_id,omitempty"` // destination of a transfer
+	UserID        int          `json:"-"`                         // taken from the auth token
+}
+
+// MovementQuery holds the query parameters of the movement history
+type MovementQuery struct {
+	Type  MovementType
+	From  string // YYYY-MM-DD in business local time, inclusive
+	To    string // YYYY-MM-DD in business local time, inclusive
+	Limit int
+}
+
+// MovementFilter narrows the movement history. Zero values are ignored.
+type MovementFilter struct {
+	Type  MovementType
+	From  time.Time
+	To    time.Time // exclusive
+	Limit int
+}
+
+const (
+	// DefaultMovementLimit is the number of movements returned when no limit is given
+	DefaultMovementLimit = 100
+	// MaxMovementLimit caps the number of movements in one response
+	MaxMovementLimit = 1000
+)
+
+// ValidMovementType reports whether t is a known movement type
+func ValidMovementType(t MovementType) bool {
+	switch t {
+	case MovementReceipt, MovementConsumption, MovementWaste, MovementAdjustment, MovementTransfer:
+		return true
+	}
+	return false
+}
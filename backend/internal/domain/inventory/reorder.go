@@ -0,0 +1,69 @@
+package inventory
+
+// ItemSupplier links an item to a supplier selling it. At most one supplier of an
+// item is preferred.
+type ItemSupplier struct {
+	SupplierID int    `json:"supplier_id"`
+	Name       string `json:"name,omitempty"`
+	Preferred  bool   `json:"preferred"`
+}
+
+// Sources of the supplier a reorder line goes to
+const (
+	ReorderSourcePreferred = "preferred" // the preferred supplier of the item
+	ReorderSourceCategory  = "category"  // a supplier covering the category of the item
+)
+
+// Defaults of the reorder suggestions
+const (
+	DefaultReorderCoverDays    = 7
+	DefaultReorderLookbackDays = 14
+	MaxReorderDays             = 365
+)
+
+// ReorderQuery holds the parameters of the reorder suggestions. Zero values use the defaults.
+type ReorderQuery struct {
+	CoverDays    int // days of usage the order should cover beyond the minimum
+	LookbackDays int // days of consumption the daily usage is averaged over
+}
+
+// ReorderLine is the quantity of one low stock item to order. Target is the larger of
+// the par level and the minimum plus the usage over the cover days; stock on hand and
+// on open requests counts towards it.
+type ReorderLine struct {
+	InventoryID   int     `json:"inventory_id"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Unit          string  `json:"unit"`
+	Quantity      float64 `json:"quantity"` // stock on hand
+	MinStock      float64 `json:"min_stock"`
+	ParQuantity   float64 `json:"par_quantity"`
+	DailyUsage    float64 `json:"daily_usage"`
+	OnOrder       float64 `json:"on_order"` // on draft and pending requests
+	Target        float64 `json:"target"`
+	OrderQuantity float64 `json:"order_quantity"`
+	Source        string  `json:"source,omitempty"` // how the supplier was picked; empty without one
+}
+
+// ReorderGroup collects the lines going to one supplier. A nil SupplierID collects the
+// items no supplier could be found for.
+type ReorderGroup struct {
+	SupplierID   *int          `json:"supplier_id"`
+	SupplierName string        `json:"supplier_name,omitempty"`
+	Lines        []ReorderLine `json:"lines"`
+	RequestID    *int          `json:"request_id,omitempty"` // draft request created for the group
+}
+
+// ReorderPlan holds the reorder suggestions of a business grouped by supplier
+type ReorderPlan struct {
+	CoverDays    int            `json:"cover_days"`
+	LookbackDays int            `json:"lookback_days"`
+	Groups       []ReorderGroup `json:"groups"`
+}
+
+// SupplierInfo is what the reorder suggestions need of a supplier
+type SupplierInfo struct {
+	ID         int
+	Name       string
+	Categories []string
+}
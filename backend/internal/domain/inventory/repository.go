@@ -1,6 +1,9 @@
 package inventory
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for inventory data operations
 type Repository interface {
@@ -13,9 +16,80 @@ type Repository interface {
 	// CreateInventory creates a new inventory item
 	CreateInventory(ctx context.Context, item *Inventory) error
 
-	// UpdateInventory updates an existing inventory item
-	UpdateInventory(ctx context.Context, item *Inventory) error
+	// UpdateInventory updates an existing inventory item. The quantity is left to the stock ledger.
+	// After a unit change the stock, recipes, cost layers, lots and requested quantities are
+	// multiplied by factor (1 leaves them alone), non-nil conversions and item.Suppliers replace
+	// the item's, and a non-nil adjustment is booked on the ledger, setting item.Quantity to the
+	// new balance. Everything is written in one transaction.
+	UpdateInventory(ctx context.Context, item *Inventory, factor float64, conversions []Conversion, adjustment *Movement) error
 
 	// DeleteInventory deletes an inventory item
 	DeleteInventory(ctx context.Context, id int, businessID int) error
+
+	// RecordMovements appends movements to the stock ledger and applies them to the item
+	// quantities in one transaction. Unless allowNegative is set, a movement that would take
+	// a stock below zero fails the whole batch with ErrInsufficientStock.
+	RecordMovements(ctx context.Context, movements []Movement, allowNegative bool) ([]Movement, error)
+
+	// GetMovements lists the ledger of an item, newest first
+	GetMovements(ctx context.Context, inventoryID int, filter MovementFilter, businessID int) ([]Movement, error)
+
+	// GetOrderConsumption works out the ingredients used by an order from the dish recipes
+	GetOrderConsumption(ctx context.Context, orderID int, businessID int) ([]Movement, error)
+
+	// HasOrderConsumption checks if the consumption of an order has been recorded already
+	HasOrderConsumption(ctx context.Context, orderID int, businessID int) (bool, error)
+
+	// Units of measure
+	GetUnits(ctx context.Context) ([]Unit, error)
+	// GetConversions returns the item conversions of the business by item ID; inventoryID 0 returns all items
+	GetConversions(ctx context.Context, inventoryID int, businessID int) (map[int][]Conversion, error)
+	SetConversions(ctx context.Context, inventoryID int, conversions []Conversion, businessID int) error
+
+	// Stocktakes
+	CreateStocktake(ctx context.Context, st *Stocktake) error
+	GetStocktakes(ctx context.Context, status StocktakeStatus, businessID int) ([]Stocktake, error)
+	GetStocktake(ctx context.Context, id int, businessID int) (*Stocktake, error)
+	SaveCounts(ctx context.Context, stocktakeID int, userID int, counts []StocktakeCount, businessID int) error
+	// ApproveStocktake closes an open session and records the adjustments in the same transaction
+	ApproveStocktake(ctx context.Context, id int, userID int, adjustments []Movement, businessID int) error
+	CancelStocktake(ctx context.Context, id int, businessID int) error
+	GetStocktakeVariances(ctx context.Context, from, to time.Time, inventoryID int, businessID int) ([]ItemVariance, error)
+
+	// Waste
+	// GetDishUsage returns the waste movements for portions of a dish, not recorded yet. It
+	// returns ErrInvalidWaste when the dish is not on the menu of the business.
+	GetDishUsage(ctx context.Context, dishID int, portions float64, businessID int) ([]Movement, error)
+	// CreateWaste stores a waste entry and records its movements in the same transaction
+	CreateWaste(ctx context.Context, w *Waste, movements []Movement, allowNegative bool) error
+	GetWaste(ctx context.Context, id int, businessID int) (*Waste, error)
+	GetWasteEntries(ctx context.Context, from, to time.Time, reason WasteReason, businessID int) ([]Waste, error)
+	SetWastePhoto(ctx context.Context, id int, photoURL string, businessID int) error
+	GetWasteLines(ctx context.Context, from, to time.Time, businessID int) ([]WasteLine, error)
+
+	// Costing
+	GetCostLayers(ctx context.Context, businessID int) ([]CostLayerTotal, error)
+	// GetCostLines sums the consumption and waste movements recorded in [from, to) by item
+	GetCostLines(ctx context.Context, from, to time.Time, businessID int) ([]CostLine, error)
+	// GetSalesTotal sums the completed orders created in [from, to)
+	GetSalesTotal(ctx context.Context, from, to time.Time, businessID int) (float64, error)
+
+	// Lots
+	GetLots(ctx context.Context, filter LotFilter, businessID int) ([]Lot, error)
+	// GetLot returns a lot with the movements that drew from it
+	GetLot(ctx context.Context, id int, businessID int) (*Lot, error)
+	MarkExpiryAlerted(ctx context.Context, lotIDs []int, businessID int) error
+	// CreateWastes stores several waste entries, recording the movements each one carries,
+	// in a single transaction
+	CreateWastes(ctx context.Context, entries []*Waste) error
+
+	// Suppliers and reordering
+	// GetItemSuppliers returns the suppliers of an item, or of every item when inventoryID is 0
+	GetItemSuppliers(ctx context.Context, inventoryID int, businessID int) (map[int][]ItemSupplier, error)
+	SetItemSuppliers(ctx context.Context, inventoryID int, suppliers []ItemSupplier, businessID int) error
+	GetActiveSuppliers(ctx context.Context, businessID int) ([]SupplierInfo, error)
+	GetConsumption(ctx context.Context, from, to time.Time, businessID int) (map[int]float64, error)
+	GetOnOrder(ctx context.Context, businessID int) (map[int]float64, error)
+	// CreateReorderRequests stores a draft request for every group with a supplier
+	CreateReorderRequests(ctx context.Context, groups []ReorderGroup, businessID int) error
 }
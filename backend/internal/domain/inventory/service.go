@@ -1,6 +1,9 @@
 package inventory
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Service defines the inventory service interface
 type Service interface {
@@ -21,4 +24,58 @@ type Service interface {
 
 	// CheckLowStockLevels checks for items with low stock and returns them
 	CheckLowStockLevels(ctx context.Context, businessID int) ([]Inventory, error)
+
+	// RecordMovement adds a movement to the stock ledger of an item. A transfer also
+	// records the matching receipt on the destination item.
+	RecordMovement(ctx context.Context, inventoryID int, req MovementRequest, businessID int) ([]Movement, error)
+
+	// GetMovements lists the stock ledger of an item, newest first
+	GetMovements(ctx context.Context, inventoryID int, query MovementQuery, businessID int) ([]Movement, error)
+
+	// ConsumeOrder records the ingredients used by a completed order. It is safe to call twice.
+	ConsumeOrder(ctx context.Context, orderID int, userID int, businessID int) error
+
+	// GetUnits lists the units catalogue
+	GetUnits(ctx context.Context) ([]Unit, error)
+
+	// ConvertQuantity converts a quantity entered in unit to the stock unit of an item.
+	// An empty unit means the stock unit.
+	ConvertQuantity(ctx context.Context, inventoryID int, quantity float64, unit string, businessID int) (float64, error)
+
+	// Stocktakes: a manager opens a session, staff submit counts and approving the
+	// session posts the variance as adjustments
+	OpenStocktake(ctx context.Context, req StocktakeRequest, businessID int) (*Stocktake, error)
+	GetStocktakes(ctx context.Context, status StocktakeStatus, businessID int) ([]Stocktake, error)
+	GetStocktake(ctx context.Context, id int, businessID int) (*Stocktake, error)
+	SubmitCounts(ctx context.Context, stocktakeID int, req CountRequest, businessID int) (*Stocktake, error)
+	ApproveStocktake(ctx context.Context, id int, userID int, businessID int) (*Stocktake, error)
+	CancelStocktake(ctx context.Context, id int, businessID int) error
+	GetVarianceReport(ctx context.Context, query VarianceQuery, businessID int) (*VarianceReport, error)
+
+	// Waste log: deducts the stock and feeds the waste report
+	LogWaste(ctx context.Context, req WasteRequest, businessID int) (*Waste, error)
+	GetWaste(ctx context.Context, id int, businessID int) (*Waste, error)
+	GetWasteEntries(ctx context.Context, query WasteQuery, businessID int) ([]Waste, error)
+	UploadWastePhoto(ctx context.Context, id int, businessID int, r io.Reader) (*Waste, error)
+	GetWasteReport(ctx context.Context, query WasteQuery, businessID int) (*WasteReport, error)
+
+	// Costing: an empty method uses the costing method of the business
+	GetValuation(ctx context.Context, method string, businessID int) (*Valuation, error)
+	GetCostOfGoods(ctx context.Context, query CostOfGoodsQuery, businessID int) (*CostOfGoods, error)
+
+	// Lots: every receipt opens one, and stock leaves them first-expiring-first-out
+	GetLots(ctx context.Context, query LotQuery, businessID int) ([]Lot, error)
+	GetLot(ctx context.Context, id int, businessID int) (*Lot, error)
+	GetExpiredLots(ctx context.Context, businessID int) ([]Lot, error)
+	// WriteOffExpiredLots logs the stock left in expired lots as spoiled waste in one step
+	WriteOffExpiredLots(ctx context.Context, req WriteOffRequest, businessID int) ([]Waste, error)
+	// GetExpiringLots returns the lots with stock expiring within days that no alert was
+	// sent for yet; MarkExpiryAlerted records that one was
+	GetExpiringLots(ctx context.Context, days int, businessID int) ([]Lot, error)
+	MarkExpiryAlerted(ctx context.Context, lotIDs []int, businessID int) error
+
+	// Reordering: low stock items are grouped by supplier and can be turned into draft
+	// requests awaiting approval
+	GetReorderSuggestions(ctx context.Context, query ReorderQuery, businessID int) (*ReorderPlan, error)
+	CreateReorderRequests(ctx context.Context, query ReorderQuery, businessID int) (*ReorderPlan, error)
 }
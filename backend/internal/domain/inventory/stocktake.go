@@ -0,0 +1,116 @@
+package inventory
+
+import "time"
+
+// StocktakeStatus is the state of a count session
+type StocktakeStatus string
+
+const (
+	StocktakeOpen      StocktakeStatus = "open"
+	StocktakeApproved  StocktakeStatus = "approved"
+	StocktakeCancelled StocktakeStatus = "cancelled"
+)
+
+// ValidStocktakeStatus reports whether s is a known stocktake status
+func ValidStocktakeStatus(s StocktakeStatus) bool {
+	switch s {
+	case StocktakeOpen, StocktakeApproved, StocktakeCancelled:
+		return true
+	}
+	return false
+}
+
+// Stocktake is a count session over all items or one category. The system quantities
+// are taken when the session opens; approving it posts the variance as adjustments.
+type Stocktake struct {
+	ID             int             `json:"id"`
+	BusinessID     int             `json:"business_id"`
+	Category       string          `json:"category,omitempty"` // empty for all items
+	Status         StocktakeStatus `json:"status"`
+	Note           string          `json:"note,omitempty"`
+	OpenedBy       int             `json:"opened_by,omitempty"`
+	OpenedByName   string          `json:"opened_by_name,omitempty"`
+	ApprovedBy     int             `json:"approved_by,omitempty"`
+	ApprovedByName string          `json:"approved_by_name,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	Lines          []StocktakeLine `json:"lines,omitempty"`
+	VarianceValue  float64         `json:"variance_value"` // costed variance of the counted lines, negative for a loss
+}
+
+// StocktakeLine is one item of a session. Counted is the sum of the latest count of
+// every counter, so several people can count different shelves of the same item.
+type StocktakeLine struct {
+	InventoryID    int              `json:"inventory_id"`
+	Name           string           `json:"name"`
+	Category       string           `json:"category"`
+	Unit           string           `json:"unit"`
+	SystemQuantity float64          `json:"system_quantity"`
+	Counted        *float64         `json:"counted,omitempty"`  // nil until someone counts the item
+	Variance       *float64         `json:"variance,omitempty"` // Counted - SystemQuantity
+	UnitCost       *float64         `json:"unit_cost,omitempty"`
+	VarianceValue  *float64         `json:"variance_value,omitempty"` // Variance * UnitCost
+	Counts         []StocktakeCount `json:"counts,omitempty"`
+}
+
+// StocktakeCount is the quantity one counter found for an item, in its stock unit
+type StocktakeCount struct {
+	InventoryID int       `json:"inventory_id"`
+	UserID      int       `json:"user_id"`
+	UserName    string    `json:"user_name,omitempty"`
+	Quantity    float64   `json:"quantity"`
+	CountedAt   time.Time `json:"counted_at"`
+}
+
+// StocktakeRequest opens a count session
+type StocktakeRequest struct {
+	Category string `json:"category"` // empty counts every item
+	Note     string `json:"note"`
+	UserID   int    `json:"-"`
+}
+
+// CountEntry is a counted quantity in the request of a counter
+type CountEntry struct {
+	InventoryID int     `json:"inventory_id"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit,omitempty"` // empty for the stock unit
+}
+
+// CountRequest submits counts; each replaces the earlier count of the same counter
+type CountRequest struct {
+	Counts []CountEntry `json:"counts"`
+	UserID int          `json:"-"`
+}
+
+// VarianceQuery holds the inclusive local dates of a variance report
+type VarianceQuery struct {
+	From        string
+	To          string
+	InventoryID int
+}
+
+// SessionVariance is the variance of an item in one approved session
+type SessionVariance struct {
+	StocktakeID int       `json:"stocktake_id"`
+	ApprovedAt  time.Time `json:"approved_at"`
+	Quantity    float64   `json:"quantity"`
+	Value       *float64  `json:"value,omitempty"`
+}
+
+// ItemVariance sums the variance of an item over the approved sessions of a period
+type ItemVariance struct {
+	InventoryID int               `json:"inventory_id"`
+	Name        string            `json:"name"`
+	Unit        string            `json:"unit"`
+	Quantity    float64           `json:"quantity"`
+	ValueLost   float64           `json:"value_lost"` // cost of the shrinkage net of surpluses; positive for a loss
+	Sessions    []SessionVariance `json:"sessions"`
+}
+
+// VarianceReport lists the items by value lost over a period
+type VarianceReport struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Items     []ItemVariance `json:"items"`
+	ValueLost float64        `json:"value_lost"`
+}
@@ -0,0 +1,28 @@
+package inventory
+
+// Dimension is what a unit measures. Catalogue units convert freely within a dimension.
+type Dimension string
+
+const (
+	DimensionMass   Dimension = "mass"   // base unit g
+	DimensionVolume Dimension = "volume" // base unit ml
+	DimensionCount  Dimension = "count"  // base unit pcs
+)
+
+// Unit is an entry of the units catalogue
+type Unit struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Dimension Dimension `json:"dimension"`
+	Factor    float64   `json:"factor"`            // base units in one unit, e.g. 1000 for kg
+	Aliases   []string  `json:"aliases,omitempty"` // other spellings accepted on input, e.g. "кг"
+}
+
+// Conversion is an item-specific unit conversion: one FromUnit equals Factor ToUnit,
+// e.g. 1 case = 12 bottles or 1 bottle = 0.75 l. Units outside the catalogue, like
+// case or bottle, only exist through such conversions.
+type Conversion struct {
+	FromUnit string  `json:"from_unit"`
+	ToUnit   string  `json:"to_unit"`
+	Factor   float64 `json:"factor"`
+}
@@ -0,0 +1,113 @@
+package inventory
+
+import "time"
+
+// WasteReason says why stock was thrown away
+type WasteReason string
+
+const (
+	WasteSpoiled      WasteReason = "spoiled"
+	WasteDropped      WasteReason = "dropped"
+	WasteOverproduced WasteReason = "overproduced"
+	WasteReturned     WasteReason = "returned" // sent back by a guest
+)
+
+// ValidWasteReason reports whether r is a known waste reason
+func ValidWasteReason(r WasteReason) bool {
+	switch r {
+	case WasteSpoiled, WasteDropped, WasteOverproduced, WasteReturned:
+		return true
+	}
+	return false
+}
+
+// Waste is a logged loss of either an inventory item or portions of a dish. A dish
+// takes its recipe ingredients out of stock.
+type Waste struct {
+	ID          int         `json:"id"`
+	BusinessID  int         `json:"business_id"`
+	InventoryID *int        `json:"inventory_id,omitempty"`
+	DishID      *int        `json:"dish_id,omitempty"`
+	Name        string      `json:"name"`           // item or dish name
+	Quantity    float64     `json:"quantity"`       // in the item stock unit, or dish portions
+	Unit        string      `json:"unit,omitempty"` // stock unit of the item; empty for dishes
+	Reason      WasteReason `json:"reason"`
+	Note        string      `json:"note,omitempty"`
+	PhotoURL    string      `json:"photo_url,omitempty"`
+	UserID      int         `json:"user_id,omitempty"`
+	UserName    string      `json:"user_name,omitempty"`
+	ShiftID     *int        `json:"shift_id,omitempty"` // shift running when the waste was logged
+	Value       *float64    `json:"value,omitempty"`    // cost of the stock lost; nil when an ingredient has no unit cost
+	CreatedAt   time.Time   `json:"created_at"`
+	Movements   []Movement  `json:"movements,omitempty"`
+}
+
+// WasteRequest logs waste of an inventory item or of dish portions, exactly one of them
+type WasteRequest struct {
+	InventoryID int         `json:"inventory_id,omitempty"`
+	DishID      int         `json:"dish_id,omitempty"`
+	Quantity    float64     `json:"quantity"`
+	Unit        string      `json:"unit,omitempty"`   // unit of an item quantity; empty for the stock unit
+	LotID       int         `json:"lot_id,omitempty"` // lot of the item to take the stock from first
+	Reason      WasteReason `json:"reason"`
+	Note        string      `json:"note"`
+	UserID      int         `json:"-"`
+}
+
+// WasteQuery holds the inclusive local dates and filters of the waste log and report
+type WasteQuery struct {
+	From   string
+	To     string
+	Reason WasteReason
+}
+
+// WasteLine is the stock one waste entry took out of one item, as read for the report
+type WasteLine struct {
+	WasteID     int
+	Reason      WasteReason
+	ShiftID     *int
+	ShiftLabel  string
+	InventoryID int
+	Name        string
+	Unit        string
+	Quantity    float64 // positive amount lost
+	UnitCost    *float64
+}
+
+// WasteReasonTotal is the waste of one reason in the report
+type WasteReasonTotal struct {
+	Reason  WasteReason `json:"reason"`
+	Entries int         `json:"entries"`
+	Value   float64     `json:"value"`
+}
+
+// WasteItemTotal is the waste of one inventory item in the report
+type WasteItemTotal struct {
+	InventoryID int     `json:"inventory_id"`
+	Name        string  `json:"name"`
+	Unit        string  `json:"unit"`
+	Quantity    float64 `json:"quantity"`
+	Value       float64 `json:"value"`
+	Uncosted    bool    `json:"uncosted,omitempty"` // the item has no unit cost, so Value leaves it out
+}
+
+// WasteShiftTotal is the waste logged during one shift in the report; a nil ShiftID
+// collects waste logged outside any shift
+type WasteShiftTotal struct {
+	ShiftID *int    `json:"shift_id"`
+	Label   string  `json:"label"`
+	Entries int     `json:"entries"`
+	Value   float64 `json:"value"`
+}
+
+// WasteReport sums the waste of a period by reason, item and shift. Values use the
+// unit cost when the waste was logged.
+type WasteReport struct {
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Entries  int                `json:"entries"`
+	Value    float64            `json:"value"`
+	ByReason []WasteReasonTotal `json:"by_reason"`
+	ByItem   []WasteItemTotal   `json:"by_item"`
+	ByShift  []WasteShiftTotal  `json:"by_shift"`
+}
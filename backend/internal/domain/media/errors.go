@@ -0,0 +1,17 @@
+package media
+
+import "errors"
+
+var (
+	// ErrUnsupportedImageType is returned when an upload is not a JPEG, PNG or GIF image
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+
+	// ErrImageTooLarge is returned when an upload exceeds the configured size limit
+	ErrImageTooLarge = errors.New("image is too large")
+
+	// ErrInvalidImage is returned when an upload cannot be decoded as an image
+	ErrInvalidImage = errors.New("invalid image")
+
+	// ErrForeignImage is returned when deleting an image stored outside the business's folder
+	ErrForeignImage = errors.New("image belongs to another business")
+)
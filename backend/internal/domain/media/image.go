@@ -0,0 +1,27 @@
+package media
+
+// Image represents an uploaded image and its generated thumbnails
+type Image struct {
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Thumbnails  map[string]string `json:"thumbnails,omitempty"` // size name -> URL
+}
+
+// ThumbnailSize describes a generated thumbnail, scaled to fit within Width pixels
+type ThumbnailSize struct {
+	Name  string
+	Width int
+}
+
+// ThumbnailSizes lists the thumbnails generated for every uploaded image
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Width: 160},
+	{Name: "medium", Width: 480},
+	{Name: "large", Width: 1024},
+}
+
+// AllowedContentTypes lists the image types accepted for upload
+var AllowedContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
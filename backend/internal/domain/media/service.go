@@ -0,0 +1,22 @@
+package media
+
+import (
+	"context"
+	"io"
+)
+
+// Service defines the media service interface
+type Service interface {
+	// UploadImage validates an image, stores it with its thumbnails under folder and returns the stored image
+	UploadImage(ctx context.Context, folder string, r io.Reader) (*Image, error)
+
+	// DeleteImage removes an uploaded image of a business and its thumbnails. URLs not owned by
+	// the storage are ignored; images stored for another business are rejected.
+	DeleteImage(ctx context.Context, url string, businessID int) error
+
+	// ThumbnailURLs returns the thumbnail URLs for an uploaded image URL, or nil for foreign URLs
+	ThumbnailURLs(url string) map[string]string
+
+	// MaxUploadSize returns the largest accepted upload in bytes
+	MaxUploadSize() int64
+}
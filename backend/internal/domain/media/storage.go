@@ -0,0 +1,21 @@
+package media
+
+import (
+	"context"
+	"io"
+)
+
+// Storage defines the interface for a file storage backend (local disk, S3-compatible)
+type Storage interface {
+	// Put stores the content under key, replacing any existing object
+	Put(ctx context.Context, key string, contentType string, body io.Reader, size int64) error
+
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the public URL of the object stored under key
+	URL(key string) string
+
+	// Key returns the key for a URL produced by this storage, or false for foreign URLs
+	Key(url string) (string, bool)
+}
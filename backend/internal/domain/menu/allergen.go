@@ -0,0 +1,112 @@
+package menu
+
+// CatalogueEntry represents a canonical allergen or dietary tag. Dishes store the code;
+// names are for display.
+type CatalogueEntry struct {
+	Code    string            `json:"code"`
+	Names   map[string]string `json:"names"` // language -> display name
+	Aliases []string          `json:"-"`     // lower-case free-text spellings mapped to Code
+}
+
+// Name returns the display name in lang, falling back to English
+func (e CatalogueEntry) Name(lang string) string {
+	if name, ok := e.Names[lang]; ok {
+		return name
+	}
+	return e.Names["en"]
+}
+
+// FindCatalogueEntry looks a code up among the allergens and the dietary tags
+func FindCatalogueEntry(code string) (CatalogueEntry, bool) {
+	for _, catalogue := range [][]CatalogueEntry{Allergens, DietaryTags} {
+		for _, entry := range catalogue {
+			if entry.Code == code {
+				return entry, true
+			}
+		}
+	}
+	return CatalogueEntry{}, false
+}
+
+// Allergens lists the 14 allergens that must be declared under EU Regulation 1169/2011
+var Allergens = []CatalogueEntry{
+	{Code: "gluten", Names: map[string]string{"en": "Cereals containing gluten", "ru": "Злаки, содержащие глютен"},
+		Aliases: []string{"wheat", "rye", "barley", "oats", "spelt", "kamut", "глютен", "пшеница", "рожь", "ячмень", "овёс", "овес"}},
+	{Code: "crustaceans", Names: map[string]string{"en": "Crustaceans", "ru": "Ракообразные"},
+		Aliases: []string{"crustacean", "shellfish", "shrimp", "prawn", "prawns", "crab", "lobster", "ракообразные", "креветки", "краб", "омар"}},
+	{Code: "eggs", Names: map[string]string{"en": "Eggs", "ru": "Яйца"},
+		Aliases: []string{"egg", "яйца", "яйцо"}},
+	{Code: "fish", Names: map[string]string{"en": "Fish", "ru": "Рыба"},
+		Aliases: []string{"рыба"}},
+	{Code: "peanuts", Names: map[string]string{"en": "Peanuts", "ru": "Арахис"},
+		Aliases: []string{"peanut", "groundnut", "арахис"}},
+	{Code: "soybeans", Names: map[string]string{"en": "Soybeans", "ru": "Соя"},
+		Aliases: []string{"soy", "soya", "soybean", "соя"}},
+	{Code: "milk", Names: map[string]string{"en": "Milk", "ru": "Молоко"},
+		Aliases: []string{"dairy", "lactose", "cheese", "butter", "cream", "молоко", "молочные продукты", "лактоза", "сыр"}},
+	{Code: "nuts", Names: map[string]string{"en": "Tree nuts", "ru": "Орехи"},
+		Aliases: []string{"nut", "tree nuts", "almond", "almonds", "hazelnut", "hazelnuts", "walnut", "walnuts", "cashew", "cashews", "pecan", "pistachio", "pistachios", "macadamia", "орехи", "миндаль", "фундук", "грецкий орех"}},
+	{Code: "celery", Names: map[string]string{"en": "Celery", "ru": "Сельдерей"},
+		Aliases: []string{"сельдерей"}},
+	{Code: "mustard", Names: map[string]string{"en": "Mustard", "ru": "Горчица"},
+		Aliases: []string{"горчица"}},
+	{Code: "sesame", Names: map[string]string{"en": "Sesame seeds", "ru": "Кунжут"},
+		Aliases: []string{"sesame seeds", "кунжут"}},
+	{Code: "sulphites", Names: map[string]string{"en": "Sulphur dioxide and sulphites", "ru": "Диоксид серы и сульфиты"},
+		Aliases: []string{"sulphite", "sulfites", "sulfite", "sulphur dioxide", "sulfur dioxide", "сульфиты"}},
+	{Code: "lupin", Names: map[string]string{"en": "Lupin", "ru": "Люпин"},
+		Aliases: []string{"lupine", "люпин"}},
+	{Code: "molluscs", Names: map[string]string{"en": "Molluscs", "ru": "Моллюски"},
+		Aliases: []string{"mollusc", "mollusks", "mollusk", "squid", "octopus", "mussels", "oysters", "clams", "scallops", "моллюски", "кальмар", "мидии"}},
+}
+
+// DietaryTags lists the dietary tags a dish can carry
+var DietaryTags = []CatalogueEntry{
+	{Code: "vegetarian", Names: map[string]string{"en": "Vegetarian", "ru": "Вегетарианское"},
+		Aliases: []string{"veggie", "вегетарианское", "вегетарианский"}},
+	{Code: "vegan", Names: map[string]string{"en": "Vegan", "ru": "Веганское"},
+		Aliases: []string{"plant-based", "веганское", "веганский"}},
+	{Code: "pescatarian", Names: map[string]string{"en": "Pescatarian", "ru": "Пескетарианское"},
+		Aliases: []string{"pescetarian"}},
+	{Code: "halal", Names: map[string]string{"en": "Halal", "ru": "Халяль"},
+		Aliases: []string{"халяль"}},
+	{Code: "kosher", Names: map[string]string{"en": "Kosher", "ru": "Кошерное"},
+		Aliases: []string{"кошерное"}},
+	{Code: "gluten_free", Names: map[string]string{"en": "Gluten-free", "ru": "Без глютена"},
+		Aliases: []string{"gluten-free", "gluten free", "без глютена"}},
+	{Code: "lactose_free", Names: map[string]string{"en": "Lactose-free", "ru": "Без лактозы"},
+		Aliases: []string{"lactose-free", "lactose free", "dairy-free", "dairy free", "без лактозы"}},
+	{Code: "spicy", Names: map[string]string{"en": "Spicy", "ru": "Острое"},
+		Aliases: []string{"hot", "острое", "острый"}},
+}
+
+// MenuItemFilter narrows GetMenuItems results
+type MenuItemFilter struct {
+	CategoryID       *int
+	ExcludeAllergens []string // hide dishes containing any of these allergens
+	DietaryTags      []string // only show dishes carrying all of these tags
+	VisibleOnly      bool     // hide dishes that are hidden themselves or sit in a hidden category
+}
+
+// AllergenMatrix represents the printable allergen chart of a business menu
+type AllergenMatrix struct {
+	BusinessName string              `json:"business_name"`
+	Language     string              `json:"language"`
+	Allergens    []AllergenColumn    `json:"allergens"`
+	Rows         []AllergenMatrixRow `json:"rows"`
+}
+
+// AllergenColumn represents an allergen column of the matrix
+type AllergenColumn struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// AllergenMatrixRow represents a dish row of the matrix. Contains is parallel to the matrix Allergens.
+type AllergenMatrixRow struct {
+	ItemID      int      `json:"item_id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Contains    []bool   `json:"contains"`
+	DietaryTags []string `json:"dietary_tags"`
+}
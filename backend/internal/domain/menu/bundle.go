@@ -0,0 +1,60 @@
+package menu
+
+import "time"
+
+// Bundle represents a combo or set menu sold at a fixed price, e.g. a set lunch of a
+// starter, a main and a drink. Each slot is a choice the guest makes when ordering.
+type Bundle struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Price       float64      `json:"price"`
+	IsAvailable bool         `json:"is_available"`
+	Slots       []BundleSlot `json:"slots"`
+	BusinessID  int          `json:"business_id,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// BundleSlot represents a choice within a bundle. The options are the dishes of
+// CategoryID together with DishIDs; at least one of them is set.
+type BundleSlot struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Quantity   int    `json:"quantity"`              // dishes to choose, 1 when omitted
+	CategoryID int    `json:"category_id,omitempty"` // any dish of the category
+	DishIDs    []int  `json:"dish_ids,omitempty"`    // specific dishes
+}
+
+// BundleCreate represents data for creating a bundle
+type BundleCreate struct {
+	Name        string       `json:"name" validate:"required"`
+	Description string       `json:"description,omitempty"`
+	Price       float64      `json:"price" validate:"required,gt=0"`
+	IsAvailable *bool        `json:"is_available,omitempty"` // defaults to true
+	Slots       []BundleSlot `json:"slots" validate:"required,min=1"`
+	BusinessID  int          `json:"business_id,omitempty"`
+}
+
+// BundleUpdate represents data for updating a bundle. Nil Slots leave the slots untouched.
+type BundleUpdate struct {
+	Name        string       `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Price       float64      `json:"price,omitempty"`
+	IsAvailable *bool        `json:"is_available,omitempty"`
+	Slots       []BundleSlot `json:"slots"`
+	BusinessID  int          `json:"business_id,omitempty"`
+}
+
+// Offers reports whether the slot lets the guest choose the dish
+func (s BundleSlot) Offers(dishID, categoryID int) bool {
+	if s.CategoryID > 0 && s.CategoryID == categoryID {
+		return true
+	}
+	for _, id := range s.DishIDs {
+		if id == dishID {
+			return true
+		}
+	}
+	return false
+}
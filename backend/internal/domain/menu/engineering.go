@@ -0,0 +1,96 @@
+package menu
+
+import "time"
+
+// Menu engineering classes, after Kasavana and Smith: popularity compares a dish's share
+// of portions sold with an even share, profitability compares its contribution margin
+// with the average margin of the group
+const (
+	DishClassStar      = "star"      // popular and profitable: keep and feature
+	DishClassPlowhorse = "plowhorse" // popular, low margin: reprice or cut portion cost
+	DishClassPuzzle    = "puzzle"    // profitable, rarely ordered: promote or reposition
+	DishClassDog       = "dog"       // unpopular and low margin: replace
+)
+
+// Where a dish cost comes from
+const (
+	CostSourceManual = "manual" // the cost entered on the dish
+	CostSourceRecipe = "recipe" // sum of recipe quantities times inventory unit costs
+)
+
+// DishSales represents the portions of a dish sold at one price over a period
+type DishSales struct {
+	DishID   int
+	Price    float64 // unit price charged on the orders
+	Quantity int
+	Revenue  float64
+}
+
+// PriceSales represents the portions of a dish sold at one price
+type PriceSales struct {
+	Price   float64 `json:"price"`
+	Sold    int     `json:"sold"`
+	Revenue float64 `json:"revenue"`
+}
+
+// MenuEngineeringQuery selects the period and grouping of a menu engineering report
+type MenuEngineeringQuery struct {
+	From       string // YYYY-MM-DD in business local time, inclusive; defaults to 90 days before To
+	To         string // YYYY-MM-DD in business local time, inclusive; defaults to today
+	ByCategory bool   // classify dishes against their category instead of the whole menu
+}
+
+// MenuEngineeringReport represents the profitability and popularity of every dish over a period.
+// Cost figures only cover dishes with a known cost.
+type MenuEngineeringReport struct {
+	From               time.Time             `json:"from"`
+	To                 time.Time             `json:"to"` // exclusive
+	ByCategory         bool                  `json:"by_category"`
+	Sold               int                   `json:"sold"`
+	Revenue            float64               `json:"revenue"`
+	FoodCost           float64               `json:"food_cost"`
+	ContributionMargin float64               `json:"contribution_margin"`
+	FoodCostPercent    float64               `json:"food_cost_percent"`
+	Dishes             []DishEngineering     `json:"dishes"`
+	Categories         []CategoryEngineering `json:"categories"`
+}
+
+// DishEngineering represents the menu engineering figures of one dish
+type DishEngineering struct {
+	DishID       int      `json:"dish_id"`
+	Name         string   `json:"name"`
+	CategoryID   int      `json:"category_id"`
+	Category     string   `json:"category"`
+	Price        float64  `json:"price"`         // current menu price
+	AveragePrice float64  `json:"average_price"` // average price charged in the period, or the menu price if unsold
+	Cost         *float64 `json:"cost"`          // per portion; nil when neither a manual nor a recipe cost is known
+	CostSource   string   `json:"cost_source,omitempty"`
+	Sold         int      `json:"sold"`
+	Revenue      float64  `json:"revenue"`
+	// Prices breaks the sales down by the price charged, lowest first
+	Prices []PriceSales `json:"prices"`
+	// MenuMix is the dish's share of the portions sold in its group, in percent
+	MenuMix float64 `json:"menu_mix"`
+	// PopularityIndex is the menu mix relative to an even share of the group: 1 is average
+	PopularityIndex float64 `json:"popularity_index"`
+	// ContributionMargin is the average price minus the cost of one portion
+	ContributionMargin      float64 `json:"contribution_margin"`
+	TotalContributionMargin float64 `json:"total_contribution_margin"`
+	FoodCostPercent         float64 `json:"food_cost_percent"`
+	Class                   string  `json:"class,omitempty"` // empty when the cost is unknown or nothing in the group sold
+}
+
+// CategoryEngineering represents the menu engineering totals of a category
+type CategoryEngineering struct {
+	CategoryID         int     `json:"category_id"`
+	Name               string  `json:"name"`
+	Dishes             int     `json:"dishes"`
+	Sold               int     `json:"sold"`
+	Revenue            float64 `json:"revenue"`
+	FoodCost           float64 `json:"food_cost"`
+	ContributionMargin float64 `json:"contribution_margin"`
+	// AverageContributionMargin is the contribution margin per portion sold
+	AverageContributionMargin float64        `json:"average_contribution_margin"`
+	FoodCostPercent           float64        `json:"food_cost_percent"`
+	Classes                   map[string]int `json:"classes"` // class -> number of dishes
+}
@@ -6,27 +6,41 @@ import "time"
 type Category struct {
 	ID         int       `json:"id"`
 	Name       string    `json:"name"`
+	ParentID   int       `json:"parent_id,omitempty"` // 0 for a top-level category
+	SortOrder  int       `json:"sort_order"`          // display position among its siblings
+	IsVisible  bool      `json:"is_visible"`          // hidden categories and everything below them stay off waiter and guest screens
+	Language   string    `json:"language,omitempty"`  // set when Name is a translation
 	BusinessID int       `json:"business_id,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Children and Items are only filled in the menu layout
+	Children []Category `json:"children,omitempty"`
+	Items    []MenuItem `json:"items,omitempty"`
 }
 
 // MenuItem represents a menu item entity
 type MenuItem struct {
-	ID              int       `json:"id"`
-	Name            string    `json:"name"`
-	CategoryID      int       `json:"category_id"`
-	Category        Category  `json:"category,omitempty"`
-	Price           float64   `json:"price"`
-	ImageURL        string    `json:"image_url,omitempty"`
-	IsAvailable     bool      `json:"is_available"`
-	PreparationTime int       `json:"preparation_time,omitempty"`
-	Calories        int       `json:"calories,omitempty"`
-	Allergens       []string  `json:"allergens,omitempty"`
-	Description     string    `json:"description,omitempty"`
-	BusinessID      int       `json:"business_id,omitempty"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	CategoryID      int               `json:"category_id"`
+	Category        Category          `json:"category,omitempty"`
+	Price           float64           `json:"price"`
+	ImageURL        string            `json:"image_url,omitempty"`
+	Thumbnails      map[string]string `json:"thumbnails,omitempty"` // size name -> URL, for uploaded images
+	IsAvailable     bool              `json:"is_available"`
+	PreparationTime int               `json:"preparation_time,omitempty"`
+	Calories        int               `json:"calories,omitempty"`
+	Allergens       []string          `json:"allergens,omitempty"`    // canonical allergen codes
+	DietaryTags     []string          `json:"dietary_tags,omitempty"` // canonical dietary tag codes
+	Cost            *float64          `json:"cost,omitempty"`         // manual cost of one portion; nil to cost the dish from its recipe
+	SortOrder       int               `json:"sort_order"`             // display position within its category
+	IsVisible       bool              `json:"is_visible"`             // hidden dishes stay off waiter and guest screens
+	Description     string            `json:"description,omitempty"`
+	Language        string            `json:"language,omitempty"` // set when texts are a translation
+	BusinessID      int               `json:"business_id,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
 }
 
 // MenuItemCreate represents data for creating a menu item
@@ -39,6 +53,10 @@ type MenuItemCreate struct {
 	PreparationTime int      `json:"preparation_time,omitempty"`
 	Calories        int      `json:"calories,omitempty"`
 	Allergens       []string `json:"allergens,omitempty"`
+	DietaryTags     []string `json:"dietary_tags,omitempty"`
+	Cost            *float64 `json:"cost,omitempty"`
+	SortOrder       *int     `json:"sort_order,omitempty"` // defaults to the end of the category
+	IsVisible       *bool    `json:"is_visible,omitempty"` // defaults to true
 	Description     string   `json:"description,omitempty"`
 	BusinessID      int      `json:"business_id,omitempty"`
 }
@@ -53,6 +71,10 @@ type MenuItemUpdate struct {
 	PreparationTime int      `json:"preparation_time,omitempty"`
 	Calories        int      `json:"calories,omitempty"`
 	Allergens       []string `json:"allergens,omitempty"`
+	DietaryTags     []string `json:"dietary_tags,omitempty"`
+	Cost            *float64 `json:"cost,omitempty"` // 0 clears the manual cost
+	SortOrder       *int     `json:"sort_order,omitempty"`
+	IsVisible       *bool    `json:"is_visible,omitempty"`
 	Description     string   `json:"description,omitempty"`
 	BusinessID      int      `json:"business_id,omitempty"`
 }
@@ -60,11 +82,31 @@ type MenuItemUpdate struct {
 // CategoryCreate represents data for creating a category
 type CategoryCreate struct {
 	Name       string `json:"name" validate:"required"`
+	ParentID   int    `json:"parent_id,omitempty"`
+	SortOrder  *int   `json:"sort_order,omitempty"` // defaults to the end of its siblings
+	IsVisible  *bool  `json:"is_visible,omitempty"` // defaults to true
 	BusinessID int    `json:"business_id,omitempty"`
 }
 
 // CategoryUpdate represents data for updating a category
 type CategoryUpdate struct {
 	Name       string `json:"name,omitempty"`
+	ParentID   *int   `json:"parent_id,omitempty"` // 0 moves the category to the top level
+	SortOrder  *int   `json:"sort_order,omitempty"`
+	IsVisible  *bool  `json:"is_visible,omitempty"`
 	BusinessID int    `json:"business_id,omitempty"`
 }
+
+// MenuLayout represents the full display order of a menu: every category of the business
+// in order, each with its parent and, optionally, its dishes in order
+type MenuLayout struct {
+	Categories []CategoryLayout `json:"categories"`
+}
+
+// CategoryLayout represents the place of a category in the menu layout. Listing a dish
+// under a category moves it there; nil ItemIDs leave the dishes of the category as they are.
+type CategoryLayout struct {
+	ID       int   `json:"id"`
+	ParentID int   `json:"parent_id,omitempty"`
+	ItemIDs  []int `json:"item_ids,omitempty"`
+}
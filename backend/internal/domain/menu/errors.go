@@ -35,4 +35,61 @@ var (
 
 	// ErrMenuItemUnavailable is returned when trying to access an unavailable menu item
 	ErrMenuItemUnavailable = errors.New("menu item is unavailable")
+
+	// ErrMenuNotFound is returned when a scheduled menu is not found
+	ErrMenuNotFound = errors.New("menu not found")
+
+	// ErrInvalidMenuSchedule is returned when a menu schedule or preview time cannot be parsed
+	ErrInvalidMenuSchedule = errors.New("invalid menu schedule")
+
+	// ErrInvalidImportFile is returned when a bulk import file cannot be parsed
+	ErrInvalidImportFile = errors.New("invalid menu import file")
+
+	// ErrUnsupportedImportFormat is returned for an unknown import/export format
+	ErrUnsupportedImportFormat = errors.New("unsupported menu import format")
+
+	// ErrTranslationNotFound is returned when a translation is not found
+	ErrTranslationNotFound = errors.New("translation not found")
+
+	// ErrUnsupportedLanguage is returned when a language is not among the business supported languages
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+
+	// ErrUnknownAllergen is returned for an allergen that is not in the catalogue
+	ErrUnknownAllergen = errors.New("unknown allergen")
+
+	// ErrUnknownDietaryTag is returned for a dietary tag that is not in the catalogue
+	ErrUnknownDietaryTag = errors.New("unknown dietary tag")
+
+	// ErrUnknownCatalogueEntry is returned when naming a code that is neither an allergen nor a dietary tag
+	ErrUnknownCatalogueEntry = errors.New("unknown allergen or dietary tag")
+
+	// ErrIngredientNotFound is returned when a recipe refers to an unknown inventory item
+	ErrIngredientNotFound = errors.New("ingredient not found")
+
+	// ErrInvalidReportPeriod is returned when a report period cannot be parsed or ends before it starts
+	ErrInvalidReportPeriod = errors.New("invalid report period")
+
+	// ErrInvalidPriceChange is returned for a price change without a valid price or effective time
+	ErrInvalidPriceChange = errors.New("invalid price change")
+
+	// ErrPriceChangeNotFound is returned when a scheduled price change is not found
+	ErrPriceChangeNotFound = errors.New("price change not found")
+
+	// ErrInvalidCategoryParent is returned when a category parent does not exist or would create a cycle
+	ErrInvalidCategoryParent = errors.New("invalid category parent")
+
+	// ErrInvalidSearchQuery is returned when a search query has no searchable words
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+
+	// ErrInvalidMenuLayout is returned when a menu layout does not list every category exactly once
+	ErrInvalidMenuLayout = errors.New("invalid menu layout")
+
+	// ErrBundleNotFound is returned when a bundle is not found
+	ErrBundleNotFound = errors.New("bundle not found")
+
+	// ErrInvalidBundle is returned when a bundle has no slots or a slot offers no dishes
+	ErrInvalidBundle = errors.New("invalid bundle")
+
+	// ErrIncompatibleUnit is returned when a recipe quantity is in a unit the ingredient cannot be converted from
+	ErrIncompatibleUnit = errors.New("incompatible ingredient unit")
 )
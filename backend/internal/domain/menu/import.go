@@ -0,0 +1,72 @@
+package menu
+
+// ImportFormat represents a bulk menu import/export file format
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatJSON ImportFormat = "json"
+)
+
+// ImportMode controls what happens when an imported dish already exists
+type ImportMode string
+
+const (
+	// ImportModeCreate rejects rows whose dish name already exists
+	ImportModeCreate ImportMode = "create"
+	// ImportModeUpsert updates existing dishes matched by name
+	ImportModeUpsert ImportMode = "upsert"
+)
+
+// MenuExport represents a business menu in the bulk import/export format.
+// Its JSON shape matches frontend/static/data/menu.json.
+type MenuExport struct {
+	Categories []CategoryExport `json:"categories"`
+	Dishes     []MenuItemExport `json:"dishes"`
+}
+
+// CategoryExport represents a category row in the bulk import/export format
+type CategoryExport struct {
+	Name string `json:"name"`
+}
+
+// MenuItemExport represents a dish row in the bulk import/export format.
+// Categories are referenced by name so files can move between businesses.
+type MenuItemExport struct {
+	Name            string   `json:"name"`
+	Category        string   `json:"category"`
+	Price           float64  `json:"price"`
+	Description     string   `json:"description,omitempty"`
+	Allergens       []string `json:"allergens,omitempty"`
+	DietaryTags     []string `json:"dietary_tags,omitempty"`
+	PreparationTime int      `json:"preparation_time,omitempty"`
+	Calories        int      `json:"calories,omitempty"`
+	IsAvailable     *bool    `json:"is_available,omitempty"` // defaults to true on import
+}
+
+// MenuImportOptions represents options for a bulk menu import
+type MenuImportOptions struct {
+	Format ImportFormat `json:"format"`
+	Mode   ImportMode   `json:"mode"`
+	DryRun bool         `json:"dry_run"`
+}
+
+// MenuImportRowError describes a validation problem with a single imported row
+type MenuImportRowError struct {
+	Row     int    `json:"row"` // CSV line number or 1-based JSON dish index
+	Name    string `json:"name,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// MenuImportResult summarises a bulk menu import
+type MenuImportResult struct {
+	DryRun            bool                 `json:"dry_run"`
+	Applied           bool                 `json:"applied"`
+	Mode              ImportMode           `json:"mode"`
+	TotalRows         int                  `json:"total_rows"`
+	CategoriesCreated int                  `json:"categories_created"`
+	ItemsCreated      int                  `json:"items_created"`
+	ItemsUpdated      int                  `json:"items_updated"`
+	Errors            []MenuImportRowError `json:"errors"`
+}
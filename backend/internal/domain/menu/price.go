@@ -0,0 +1,57 @@
+package menu
+
+import "time"
+
+// Status of a price change
+const (
+	PriceStatusApplied   = "applied"   // the price is or was the dish price
+	PriceStatusScheduled = "scheduled" // the price becomes the dish price at EffectiveFrom
+)
+
+// PriceChange represents a dish price with the moment it takes effect. The applied
+// changes of a dish form its price history; the latest one is the current price.
+type PriceChange struct {
+	ID            int        `json:"id"`
+	DishID        int        `json:"dish_id"`
+	Price         float64    `json:"price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty"`
+	Status        string     `json:"status"`
+	Note          string     `json:"note,omitempty"`
+	BusinessID    int        `json:"business_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// PriceChangeInput represents a price change to schedule for one dish
+type PriceChangeInput struct {
+	Price         float64 `json:"price"`
+	EffectiveFrom string  `json:"effective_from,omitempty"` // YYYY-MM-DDTHH:MM in business local time or RFC 3339; empty means now
+	Note          string  `json:"note,omitempty"`
+}
+
+// BulkPriceChange represents a price change over many dishes, e.g. +5% on a category.
+// Exactly one of Percent and Amount is set.
+type BulkPriceChange struct {
+	CategoryID    int     `json:"category_id,omitempty"` // 0 changes the whole menu
+	Percent       float64 `json:"percent,omitempty"`     // 5 raises prices by 5%, -10 lowers them by 10%
+	Amount        float64 `json:"amount,omitempty"`      // added to every price, may be negative
+	RoundTo       float64 `json:"round_to,omitempty"`    // round new prices to a multiple of this, e.g. 0.10; defaults to 0.01
+	EffectiveFrom string  `json:"effective_from,omitempty"`
+	Note          string  `json:"note,omitempty"`
+	DryRun        bool    `json:"-"` // preview the new prices without scheduling them, from ?dry_run
+}
+
+// BulkPriceResult lists the old and new price of every dish a bulk change touches
+type BulkPriceResult struct {
+	EffectiveFrom time.Time             `json:"effective_from"`
+	DryRun        bool                  `json:"dry_run"`
+	Items         []BulkPriceResultItem `json:"items"`
+}
+
+// BulkPriceResultItem represents the price change of one dish
+type BulkPriceResultItem struct {
+	DishID   int     `json:"dish_id"`
+	Name     string  `json:"name"`
+	OldPrice float64 `json:"old_price"`
+	NewPrice float64 `json:"new_price"`
+}
@@ -0,0 +1,18 @@
+package menu
+
+// RecipeIngredient represents an inventory item used to prepare a dish
+type RecipeIngredient struct {
+	InventoryID int      `json:"inventory_id"`
+	Name        string   `json:"name"`
+	Quantity    float64  `json:"quantity"` // per portion, in the inventory item unit
+	Unit        string   `json:"unit"`
+	Allergens   []string `json:"allergens,omitempty"` // canonical allergen codes of the inventory item
+	UnitCost    *float64 `json:"unit_cost,omitempty"` // inventory cost of one unit
+}
+
+// RecipeIngredientInput represents an ingredient line when replacing a recipe
+type RecipeIngredientInput struct {
+	InventoryID int     `json:"inventory_id"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit,omitempty"` // unit of Quantity, e.g. g for an item stocked in kg; empty for the item unit
+}
@@ -1,6 +1,9 @@
 package menu
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for menu data operations
 type Repository interface {
@@ -11,6 +14,9 @@ type Repository interface {
 	UpdateMenuItem(ctx context.Context, id int, item MenuItemUpdate) (*MenuItem, error)
 	DeleteMenuItem(ctx context.Context, id int, businessID int) error
 
+	// SearchMenuItems returns the dishes matching a search, best match first
+	SearchMenuItems(ctx context.Context, search MenuSearch, businessID int) ([]MenuItem, error)
+
 	// Categories
 	GetCategories(ctx context.Context, businessID int) ([]Category, error)
 	GetCategoryByID(ctx context.Context, id int, businessID int) (*Category, error)
@@ -18,6 +24,55 @@ type Repository interface {
 	UpdateCategory(ctx context.Context, id int, category CategoryUpdate) (*Category, error)
 	DeleteCategory(ctx context.Context, id int, businessID int) error
 
+	// SetMenuLayout applies the order and nesting of categories and dishes in a single transaction
+	SetMenuLayout(ctx context.Context, layout MenuLayout, businessID int) error
+
 	// GetDishByID retrieves a specific dish by its ID
 	GetDishByID(ctx context.Context, id int) (*MenuItem, error)
+
+	// Scheduled menus
+	GetMenus(ctx context.Context, businessID int) ([]Menu, error)
+	GetMenuByID(ctx context.Context, id int, businessID int) (*Menu, error)
+	CreateMenu(ctx context.Context, m MenuCreate) (*Menu, error)
+	UpdateMenu(ctx context.Context, id int, m MenuUpdate) (*Menu, error)
+	DeleteMenu(ctx context.Context, id int, businessID int) error
+
+	// Bundles
+	GetBundles(ctx context.Context, businessID int) ([]Bundle, error)
+	GetBundleByID(ctx context.Context, id int, businessID int) (*Bundle, error)
+	CreateBundle(ctx context.Context, b BundleCreate) (*Bundle, error)
+	UpdateBundle(ctx context.Context, id int, b BundleUpdate) (*Bundle, error)
+	DeleteBundle(ctx context.Context, id int, businessID int) error
+
+	// ImportMenu applies validated categories and dishes in a single transaction
+	ImportMenu(ctx context.Context, data MenuExport, mode ImportMode, businessID int) (*MenuImportResult, error)
+
+	// Translations
+	GetTranslations(ctx context.Context, language string, businessID int) ([]Translation, error)
+	GetEntityTranslations(ctx context.Context, entityType string, entityID int, businessID int) ([]Translation, error)
+	UpsertTranslation(ctx context.Context, t Translation) (*Translation, error)
+	DeleteTranslation(ctx context.Context, entityType string, entityID int, language string, businessID int) error
+	// GetCatalogueTranslations lists the allergen and dietary tag names of a business in one language, or all when language is ""
+	GetCatalogueTranslations(ctx context.Context, language string, businessID int) ([]CatalogueTranslation, error)
+	UpsertCatalogueTranslation(ctx context.Context, t CatalogueTranslation) (*CatalogueTranslation, error)
+	DeleteCatalogueTranslation(ctx context.Context, code string, language string, businessID int) error
+
+	// Recipes
+	GetRecipe(ctx context.Context, itemID int, businessID int) ([]RecipeIngredient, error)
+	SetRecipe(ctx context.Context, itemID int, ingredients []RecipeIngredientInput, businessID int) error
+
+	// GetRecipeCosts returns the cost of one portion of each dish whose ingredients all have a unit cost
+	GetRecipeCosts(ctx context.Context, businessID int) (map[int]float64, error)
+
+	// Price history. Direct price edits and imports record an applied change themselves.
+	GetPriceHistory(ctx context.Context, dishID int, businessID int) ([]PriceChange, error)
+	SchedulePriceChanges(ctx context.Context, changes []PriceChange) ([]PriceChange, error)
+	DeletePriceChange(ctx context.Context, id int, dishID int, businessID int) error
+
+	// ApplyDuePriceChanges applies, across all businesses, the scheduled prices effective at or
+	// before the given moment and returns the number of dishes repriced
+	ApplyDuePriceChanges(ctx context.Context, at time.Time) (int, error)
+
+	// Menu engineering
+	GetDishSales(ctx context.Context, from, to time.Time, businessID int) ([]DishSales, error)
 }
@@ -0,0 +1,59 @@
+package menu
+
+import "time"
+
+// Menu represents a scheduled menu (daypart) made up of categories and items
+type Menu struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	IsActive    bool           `json:"is_active"`
+	CategoryIDs []int          `json:"category_ids"`
+	ItemIDs     []int          `json:"item_ids"`
+	Schedules   []MenuSchedule `json:"schedules"`
+	BusinessID  int            `json:"business_id,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// MenuSchedule describes when a menu is on offer. All fields are optional;
+// an empty field places no restriction on that dimension.
+type MenuSchedule struct {
+	ID         int    `json:"id,omitempty"`
+	DaysOfWeek []int  `json:"days_of_week,omitempty"` // 0 = Sunday ... 6 = Saturday
+	StartTime  string `json:"start_time,omitempty"`   // HH:MM, business local time
+	EndTime    string `json:"end_time,omitempty"`     // HH:MM, before StartTime means the range crosses midnight
+	StartDate  string `json:"start_date,omitempty"`   // YYYY-MM-DD, inclusive
+	EndDate    string `json:"end_date,omitempty"`     // YYYY-MM-DD, inclusive
+}
+
+// MenuCreate represents data for creating a menu
+type MenuCreate struct {
+	Name        string         `json:"name" validate:"required"`
+	Description string         `json:"description,omitempty"`
+	IsActive    bool           `json:"is_active"`
+	CategoryIDs []int          `json:"category_ids,omitempty"`
+	ItemIDs     []int          `json:"item_ids,omitempty"`
+	Schedules   []MenuSchedule `json:"schedules,omitempty"`
+	BusinessID  int            `json:"business_id,omitempty"`
+}
+
+// MenuUpdate represents data for updating a menu. Nil slices leave the
+// corresponding relation untouched, empty slices clear it.
+type MenuUpdate struct {
+	Name        string         `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	IsActive    *bool          `json:"is_active,omitempty"`
+	CategoryIDs []int          `json:"category_ids"`
+	ItemIDs     []int          `json:"item_ids"`
+	Schedules   []MenuSchedule `json:"schedules"`
+	BusinessID  int            `json:"business_id,omitempty"`
+}
+
+// MenuPreview represents the menus and items on offer at a given moment
+type MenuPreview struct {
+	At       time.Time  `json:"at"`
+	Timezone string     `json:"timezone"`
+	Menus    []Menu     `json:"menus"`
+	Items    []MenuItem `json:"items"`
+}
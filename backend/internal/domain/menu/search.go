@@ -0,0 +1,18 @@
+package menu
+
+// MenuSearch is a ranked dish search within one business. Terms are the normalised words of
+// Query; each must match the start of a word in the dish name, its category or its description.
+// Query itself is also compared with the dish name by trigram similarity, so typos still match.
+type MenuSearch struct {
+	Query string
+	Terms []string
+	Limit int
+}
+
+const (
+	// DefaultSearchLimit is the number of results returned when no limit is given
+	DefaultSearchLimit = 20
+
+	// MaxSearchLimit caps the number of results of one search
+	MaxSearchLimit = 100
+)
@@ -1,16 +1,24 @@
 package menu
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 // Service defines the menu service interface
 type Service interface {
 	// Menu Items
-	GetMenuItems(ctx context.Context, categoryID *int, businessID int) ([]MenuItem, error)
+	GetMenuItems(ctx context.Context, filter MenuItemFilter, businessID int) ([]MenuItem, error)
 	GetMenuItemByID(ctx context.Context, id int, businessID int) (*MenuItem, error)
 	CreateMenuItem(ctx context.Context, item MenuItemCreate, businessID int) (*MenuItem, error)
 	UpdateMenuItem(ctx context.Context, id int, item MenuItemUpdate, businessID int) (*MenuItem, error)
 	DeleteMenuItem(ctx context.Context, id int, businessID int) error
 
+	// SearchMenuItems finds dishes on offer now by name, category or description, best match
+	// first. Words match by prefix and the whole query by similarity to tolerate typos.
+	SearchMenuItems(ctx context.Context, query string, limit int, businessID int) ([]MenuItem, error)
+
 	// Categories
 	GetCategories(ctx context.Context, businessID int) ([]Category, error)
 	GetCategoryByID(ctx context.Context, id int, businessID int) (*Category, error)
@@ -18,9 +26,93 @@ type Service interface {
 	UpdateCategory(ctx context.Context, id int, category CategoryUpdate, businessID int) (*Category, error)
 	DeleteCategory(ctx context.Context, id int, businessID int) error
 
+	// GetMenuLayout returns the category tree with the dishes of each category, in display order.
+	// Without includeHidden it is the waiter view: visible dishes on offer now.
+	GetMenuLayout(ctx context.Context, businessID int, includeHidden bool) ([]Category, error)
+
+	// SetMenuLayout reorders and renests the whole menu at once
+	SetMenuLayout(ctx context.Context, layout MenuLayout, businessID int) ([]Category, error)
+
 	// Menu Summary
 	GetMenuSummary(ctx context.Context, businessID int) (interface{}, error)
 
 	// GetDishByID retrieves a specific dish by its ID
 	GetDishByID(ctx context.Context, id int) (*MenuItem, error)
+
+	// Scheduled menus
+	GetMenus(ctx context.Context, businessID int) ([]Menu, error)
+	GetMenuByID(ctx context.Context, id int, businessID int) (*Menu, error)
+	CreateMenu(ctx context.Context, m MenuCreate, businessID int) (*Menu, error)
+	UpdateMenu(ctx context.Context, id int, m MenuUpdate, businessID int) (*Menu, error)
+	DeleteMenu(ctx context.Context, id int, businessID int) error
+
+	// Bundles (combos and set menus)
+	GetBundles(ctx context.Context, businessID int) ([]Bundle, error)
+	GetBundleByID(ctx context.Context, id int, businessID int) (*Bundle, error)
+	CreateBundle(ctx context.Context, b BundleCreate, businessID int) (*Bundle, error)
+	UpdateBundle(ctx context.Context, id int, b BundleUpdate, businessID int) (*Bundle, error)
+	DeleteBundle(ctx context.Context, id int, businessID int) error
+
+	// GetMenuItemsAt retrieves the menu items on offer at the given moment
+	GetMenuItemsAt(ctx context.Context, categoryID *int, businessID int, at time.Time) ([]MenuItem, error)
+
+	// ActiveMenusAt returns the menus on offer at the given moment. restricted is false when
+	// the business has no enabled menus, in which case every dish is on offer.
+	ActiveMenusAt(ctx context.Context, businessID int, at time.Time) (active []Menu, restricted bool, err error)
+
+	// PreviewMenu returns the menus and items on offer at the given local time
+	// (YYYY-MM-DDTHH:MM or RFC 3339); an empty value means now
+	PreviewMenu(ctx context.Context, businessID int, at string) (*MenuPreview, error)
+
+	// ExportMenu writes all categories and dishes in the given format
+	ExportMenu(ctx context.Context, w io.Writer, format ImportFormat, businessID int) error
+
+	// ImportMenu validates and, unless DryRun is set, applies a bulk menu file
+	ImportMenu(ctx context.Context, r io.Reader, opts MenuImportOptions, businessID int) (*MenuImportResult, error)
+
+	// UploadMenuItemImage stores a dish photo with its thumbnails, replacing any previous upload
+	UploadMenuItemImage(ctx context.Context, id int, businessID int, r io.Reader) (*MenuItem, error)
+
+	// GetRecipe lists the inventory items used to prepare a dish
+	GetRecipe(ctx context.Context, itemID int, businessID int) ([]RecipeIngredient, error)
+
+	// SetRecipe replaces the ingredients of a dish
+	SetRecipe(ctx context.Context, itemID int, ingredients []RecipeIngredientInput, businessID int) ([]RecipeIngredient, error)
+
+	// GetPriceHistory lists the applied and scheduled prices of a dish, latest first
+	GetPriceHistory(ctx context.Context, itemID int, businessID int) ([]PriceChange, error)
+
+	// SchedulePriceChange sets a dish price from a future moment, or right away when none is given
+	SchedulePriceChange(ctx context.Context, itemID int, input PriceChangeInput, businessID int) (*PriceChange, error)
+
+	// CancelPriceChange removes a scheduled price change that has not been applied yet
+	CancelPriceChange(ctx context.Context, itemID int, changeID int, businessID int) error
+
+	// BulkChangePrices changes the prices of a category or the whole menu; DryRun only previews them
+	BulkChangePrices(ctx context.Context, change BulkPriceChange, businessID int) (*BulkPriceResult, error)
+
+	// ApplyDuePriceChanges applies the scheduled prices that have become effective, for all businesses
+	ApplyDuePriceChanges(ctx context.Context) (int, error)
+
+	// GetMenuEngineering classifies every dish by popularity and contribution margin over a period
+	GetMenuEngineering(ctx context.Context, query MenuEngineeringQuery, businessID int) (*MenuEngineeringReport, error)
+
+	// GetAllergenMatrix builds the printable allergen chart of every dish, named in the context language
+	GetAllergenMatrix(ctx context.Context, businessID int) (*AllergenMatrix, error)
+
+	// Translations. Menu reads translate texts into the language set with WithLanguage.
+	GetTranslations(ctx context.Context, language string, businessID int) ([]Translation, error)
+	GetEntityTranslations(ctx context.Context, entityType string, entityID int, businessID int) ([]Translation, error)
+	SetTranslation(ctx context.Context, entityType string, entityID int, language string, input TranslationInput, businessID int) (*Translation, error)
+	DeleteTranslation(ctx context.Context, entityType string, entityID int, language string, businessID int) error
+	// Allergen and dietary tag names in the business languages
+	GetCatalogueTranslations(ctx context.Context, language string, businessID int) ([]CatalogueTranslation, error)
+	SetCatalogueTranslation(ctx context.Context, code string, language string, input TranslationInput, businessID int) (*CatalogueTranslation, error)
+	DeleteCatalogueTranslation(ctx context.Context, code string, language string, businessID int) error
+	// CatalogueNames returns the display name of every allergen and dietary tag code in lang
+	CatalogueNames(ctx context.Context, lang string, businessID int) (map[string]string, error)
+
+	// ResolveLanguage picks the first requested language the business supports, falling
+	// back to its default language. It also returns the default language.
+	ResolveLanguage(ctx context.Context, businessID int, requested []string) (string, string, error)
 }
@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"context"
+	"time"
+)
+
+// Translatable entity types
+const (
+	TranslationEntityCategory = "category"
+	TranslationEntityDish     = "dish"
+)
+
+// Translation represents a category or dish text in one language. Empty fields fall
+// back to the base text, which is written in the business default language. Allergens
+// are canonical codes and are named from the catalogue instead.
+type Translation struct {
+	ID          int       `json:"id"`
+	EntityType  string    `json:"entity_type"` // category or dish
+	EntityID    int       `json:"entity_id"`
+	Language    string    `json:"language"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	BusinessID  int       `json:"business_id,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TranslationInput represents data for creating or replacing a translation
+type TranslationInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// CatalogueTranslation names an allergen or dietary tag in a business language. It
+// covers languages the built-in catalogue has no name for and overrides built-in names.
+type CatalogueTranslation struct {
+	Code       string    `json:"code"`
+	Language   string    `json:"language"`
+	Name       string    `json:"name"`
+	BusinessID int       `json:"business_id,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type languageContextKey struct{}
+
+// WithLanguage returns a context asking menu reads to translate texts into lang
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageContextKey{}, lang)
+}
+
+// LanguageFromContext returns the language set by WithLanguage, or "" for base texts
+func LanguageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(languageContextKey{}).(string)
+	return lang
+}
@@ -9,6 +9,7 @@ type NotificationType string
 
 const (
 	NotificationTypeLowInventory NotificationType = "low_inventory"
+	NotificationTypeExpiry       NotificationType = "expiry"
 	NotificationTypeNewHiring    NotificationType = "new_hiring"
 	NotificationTypeWeeklyReport NotificationType = "weekly_report"
 	NotificationTypeDailyReport  NotificationType = "daily_report"
@@ -49,6 +50,16 @@ type CreateNotificationRequest struct {
 	Recipients []string         `json:"recipients"`
 }
 
+// ExpiringStock is one lot listed in an expiry alert
+type ExpiringStock struct {
+	ItemName  string
+	LotNumber string
+	Quantity  float64
+	Unit      string
+	ExpiresOn string // YYYY-MM-DD
+	DaysLeft  int    // negative once expired
+}
+
 // NotificationStats represents notification statistics
 type NotificationStats struct {
 	TotalSent     int `json:"total_sent"`
@@ -22,6 +22,9 @@ type Service interface {
 	// SendLowInventoryAlert sends an alert when inventory is low
 	SendLowInventoryAlert(ctx context.Context, businessID int, itemName string, currentStock, minStock float64, unit string) error
 
+	// SendExpiryAlert sends an alert listing stock that expires soon or has expired
+	SendExpiryAlert(ctx context.Context, businessID int, lots []ExpiringStock) error
+
 	// SendNewHiringAlert sends an alert for new hiring applications
 	SendNewHiringAlert(ctx context.Context, businessID int, applicantName, position string, experience string, location string) error
 
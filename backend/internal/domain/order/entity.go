@@ -15,6 +15,14 @@ const (
 	OrderStatusCancelled OrderStatus = "cancelled"
 )
 
+// OrderSource tells who placed an order
+type OrderSource string
+
+const (
+	OrderSourceStaff OrderSource = "staff"
+	OrderSourceGuest OrderSource = "guest" // placed from the table QR code, waiting for a waiter to accept it
+)
+
 // OrderItem represents an item within an order
 type OrderItem struct {
 	ID       int     `json:"id"`       // Corresponds to 'order_items.id'
@@ -26,21 +34,72 @@ type OrderItem struct {
 	Price    float64 `json:"price"`           // Price of one unit AT THE TIME OF ORDER. Corresponds to 'order_items.price'
 	Total    float64 `json:"total"`           // Subtotal for this item (Quantity * Price). Can be calculated or stored.
 	Notes    string  `json:"notes,omitempty"` // Corresponds to 'order_items.notes'
+	Seat     int     `json:"seat,omitempty"`  // Corresponds to 'order_items.seat'; 0 means shared by the table
+	// AllergenConflicts lists the guest allergies the dish conflicts with. Corresponds to 'order_items.allergen_conflicts'
+	AllergenConflicts []string `json:"allergen_conflicts,omitempty"`
+	// OrderBundleID links a bundle component to its line in Order.Bundles. Corresponds to 'order_items.order_bundle_id'.
+	// The Price of a component is its share of the bundle price.
+	OrderBundleID int `json:"order_bundle_id,omitempty"`
+}
+
+// OrderBundle represents a combo or set menu on an order, billed at the bundle price.
+// Its dishes are OrderItems with the OrderBundleID of the line.
+type OrderBundle struct {
+	ID       int     `json:"id"`        // Corresponds to 'order_bundles.id'
+	BundleID int     `json:"bundle_id"` // Corresponds to 'order_bundles.bundle_id'; 0 once the bundle is deleted
+	Name     string  `json:"name"`      // Name of the bundle at the time of order
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"` // Price of one bundle at the time of order
+	Total    float64 `json:"total"`
+	Seat     int     `json:"seat,omitempty"`
+	Notes    string  `json:"notes,omitempty"`
+
+	// Items are the components to create with the bundle line
+	Items []OrderItem `json:"-"`
 }
 
 // Order represents an order entity
 type Order struct {
-	ID          int         `json:"id"`                     // Corresponds to 'orders.id'
-	TableID     int         `json:"table_id"`               // Corresponds to 'orders.table_id'
-	WaiterID    int         `json:"waiter_id"`              // Corresponds to 'orders.waiter_id'
-	Status      OrderStatus `json:"status"`                 // Corresponds to 'orders.status'
-	TotalAmount float64     `json:"total_amount"`           // Corresponds to 'orders.total_amount'
-	Comment     string      `json:"comment,omitempty"`      // Corresponds to 'orders.comment'
-	CreatedAt   time.Time   `json:"created_at"`             // Corresponds to 'orders.created_at'
-	UpdatedAt   time.Time   `json:"updated_at"`             // Corresponds to 'orders.updated_at'
-	CompletedAt *time.Time  `json:"completed_at,omitempty"` // Corresponds to 'orders.completed_at'
-	CancelledAt *time.Time  `json:"cancelled_at,omitempty"` // Corresponds to 'orders.cancelled_at'
-	Items       []OrderItem `json:"items,omitempty"`        // Populated from 'order_items' table
+	ID          int           `json:"id"`                     // Corresponds to 'orders.id'
+	TableID     int           `json:"table_id"`               // Corresponds to 'orders.table_id'
+	WaiterID    int           `json:"waiter_id"`              // Corresponds to 'orders.waiter_id'; 0 until a waiter accepts a guest order
+	Status      OrderStatus   `json:"status"`                 // Corresponds to 'orders.status'
+	TotalAmount float64       `json:"total_amount"`           // Corresponds to 'orders.total_amount'
+	Comment     string        `json:"comment,omitempty"`      // Corresponds to 'orders.comment'
+	CreatedAt   time.Time     `json:"created_at"`             // Corresponds to 'orders.created_at'
+	UpdatedAt   time.Time     `json:"updated_at"`             // Corresponds to 'orders.updated_at'
+	CompletedAt *time.Time    `json:"completed_at,omitempty"` // Corresponds to 'orders.completed_at'
+	CancelledAt *time.Time    `json:"cancelled_at,omitempty"` // Corresponds to 'orders.cancelled_at'
+	Items       []OrderItem   `json:"items,omitempty"`        // Populated from 'order_items' table, bundle components included
+	Bundles     []OrderBundle `json:"bundles,omitempty"`      // Populated from 'order_bundles' table
+	Source      OrderSource   `json:"source"`                 // Corresponds to 'orders.source'
+
+	Allergies     []string        `json:"allergies,omitempty"`      // Allergen codes of the whole table. Corresponds to 'orders.allergies'
+	SeatAllergies []SeatAllergies `json:"seat_allergies,omitempty"` // Corresponds to 'orders.seat_allergies'
+
+	// AllergyWarnings lists the conflicts found when the order was created
+	AllergyWarnings []AllergenWarning `json:"allergy_warnings,omitempty"`
+
+	// AllergyBanner summarises the allergies and conflicts for kitchen tickets
+	AllergyBanner string `json:"allergy_banner,omitempty"`
+
+	// SectionWarning is set when the order was taken on a table in another waiter's section
+	SectionWarning string `json:"section_warning,omitempty"`
+}
+
+// SeatAllergies represents the allergies of the guest at one seat
+type SeatAllergies struct {
+	Seat      int      `json:"seat"`
+	Allergies []string `json:"allergies"` // canonical allergen codes
+}
+
+// AllergenWarning represents a dish that contains something a guest is allergic to
+type AllergenWarning struct {
+	DishID   int    `json:"dish_id"`
+	DishName string `json:"dish_name"`
+	Seat     int    `json:"seat,omitempty"` // 0 for dishes shared by the table
+	Allergen string `json:"allergen"`
+	Source   string `json:"source"` // "dish" for declared allergens, otherwise the ingredient name
 }
 
 // OrderStats represents order statistics
@@ -58,9 +117,13 @@ type OrderStats struct {
 
 // CreateOrderRequest represents data for creating an order
 type CreateOrderRequest struct {
-	TableID int              `json:"tableId" binding:"required"`
-	Comment string           `json:"comment,omitempty"`
-	Items   []OrderItemInput `json:"items" binding:"required,min=1"`
+	TableID int                `json:"tableId" binding:"required"`
+	Comment string             `json:"comment,omitempty"`
+	Items   []OrderItemInput   `json:"items"`
+	Bundles []OrderBundleInput `json:"bundles,omitempty"` // an order needs at least one item or bundle
+	// Guest allergies, as allergen codes or names, for the whole table and per seat
+	Allergies []string        `json:"allergies,omitempty"`
+	Seats     []SeatAllergies `json:"seats,omitempty"`
 	// WaiterID will be extracted from the auth token on the backend
 }
 
@@ -69,11 +132,31 @@ type OrderItemInput struct {
 	DishID   int    `json:"dishId" binding:"required"`
 	Quantity int    `json:"quantity" binding:"required,gt=0"`
 	Notes    string `json:"notes,omitempty"`
+	Seat     int    `json:"seat,omitempty"` // 0 for dishes shared by the table
+}
+
+// OrderBundleInput represents a bundle ordered with the dishes chosen for its slots
+type OrderBundleInput struct {
+	BundleID int                 `json:"bundleId" binding:"required"`
+	Quantity int                 `json:"quantity" binding:"required,gt=0"`
+	Notes    string              `json:"notes,omitempty"`
+	Seat     int                 `json:"seat,omitempty"`
+	Choices  []BundleChoiceInput `json:"choices" binding:"required"`
+}
+
+// BundleChoiceInput represents a dish chosen for a bundle slot. A slot with a quantity
+// of two takes two choices, which may be the same dish.
+type BundleChoiceInput struct {
+	SlotID int    `json:"slotId" binding:"required"`
+	DishID int    `json:"dishId" binding:"required"`
+	Notes  string `json:"notes,omitempty"`
 }
 
 // UpdateOrderStatusRequest represents data for updating order status
 type UpdateOrderStatusRequest struct {
 	Status OrderStatus `json:"status" binding:"required"`
+	// WaiterID is taken from the auth token; a guest order is assigned to the waiter who accepts it
+	WaiterID int `json:"-"`
 }
 
 // Dish represents a dish entity (simplified for orders)
@@ -83,4 +166,6 @@ type Dish struct {
 	Price       float64 `json:"price"`
 	CategoryID  int     `json:"category_id"`
 	IsAvailable bool    `json:"is_available"`
+	IsVisible   bool    `json:"is_visible"` // false when the dish or one of its categories is hidden
+	BusinessID  int     `json:"business_id"`
 }
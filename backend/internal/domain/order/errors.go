@@ -53,4 +53,36 @@ var (
 
 	// ErrTableNotAvailable is returned when a table is not available for orders
 	ErrTableNotAvailable = errors.New("table not available")
+
+	// ErrDishNotOnMenu is returned when a dish is not on any menu active at the time of ordering
+	ErrDishNotOnMenu = errors.New("dish is not on the current menu")
+
+	// ErrUnknownAllergy is returned when a guest allergy is not in the allergen catalogue
+	ErrUnknownAllergy = errors.New("unknown allergy")
+
+	// ErrBundleNotFound is returned when an ordered bundle does not exist
+	ErrBundleNotFound = errors.New("bundle not found")
+
+	// ErrBundleNotAvailable is returned when an ordered bundle is not available
+	ErrBundleNotAvailable = errors.New("bundle not available")
+
+	// ErrInvalidBundleChoice is returned when the dishes chosen do not fill the bundle slots
+	ErrInvalidBundleChoice = errors.New("invalid bundle choice")
+
+	// ErrAllergenConflict is returned when the business blocks orders that conflict with guest allergies
+	ErrAllergenConflict = errors.New("order conflicts with guest allergies")
 )
+
+// AllergenConflictError carries the conflicts of an order rejected by the business allergen policy.
+// It matches ErrAllergenConflict with errors.Is.
+type AllergenConflictError struct {
+	Warnings []AllergenWarning
+}
+
+func (e *AllergenConflictError) Error() string {
+	return ErrAllergenConflict.Error()
+}
+
+func (e *AllergenConflictError) Unwrap() error {
+	return ErrAllergenConflict
+}
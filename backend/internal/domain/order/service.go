@@ -13,6 +13,9 @@ type Service interface {
 	// CreateOrder creates a new order with validation
 	CreateOrder(ctx context.Context, req CreateOrderRequest, waiterID, businessID int) (*Order, error)
 
+	// CreateGuestOrder creates a new order placed by a guest, to be accepted by a waiter
+	CreateGuestOrder(ctx context.Context, req CreateOrderRequest, businessID int) (*Order, error)
+
 	// UpdateOrderStatus updates an order's status with business rules
 	UpdateOrderStatus(ctx context.Context, id int, req UpdateOrderStatusRequest, businessID int) error
 
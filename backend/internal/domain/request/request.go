@@ -2,9 +2,21 @@ package request
 
 import (
 	"database/sql/driver"
+	"errors"
 	"time"
 )
 
+// Request statuses. Reorder suggestions create drafts, which a manager approves into
+// pending requests.
+const (
+	StatusDraft     = "draft"
+	StatusPending   = "pending"
+	StatusCompleted = "completed"
+)
+
+// ErrNotDraft is returned when approving a request that is not a draft
+var ErrNotDraft = errors.New("request is not a draft")
+
 // Request represents a request entity
 type Request struct {
 	ID          int        `json:"id"`
@@ -9,4 +9,6 @@ type Service interface {
 	Create(ctx context.Context, request CreateRequestRequest, businessID int) (*Request, error)
 	Update(ctx context.Context, id int, request UpdateRequestRequest, businessID int) (*Request, error)
 	Delete(ctx context.Context, id int, businessID int) error
+	// Approve turns a draft request into a pending one
+	Approve(ctx context.Context, id int, businessID int) (*Request, error)
 }
@@ -0,0 +1,84 @@
+package reservation
+
+import "time"
+
+// Reservation statuses
+const (
+	StatusBooked    = "booked"    // upcoming; its tables are held shortly before it starts
+	StatusSeated    = "seated"    // the party has arrived and sits at its tables
+	StatusCompleted = "completed" // the party has left
+	StatusCancelled = "cancelled"
+	StatusNoShow    = "no_show"
+)
+
+// DefaultDuration is how long a table is booked for when no duration is given
+const DefaultDuration = 2 * time.Hour
+
+// Reservation is a booking of one table, or several combined tables, for a party
+type Reservation struct {
+	ID         int       `json:"id"`
+	BusinessID int       `json:"business_id"`
+	GuestName  string    `json:"guest_name"`
+	Phone      string    `json:"phone"`
+	PartySize  int       `json:"party_size"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Duration   int       `json:"duration_minutes"`
+	TableIDs   []int     `json:"table_ids"` // empty while no table is assigned
+	Status     string    `json:"status"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Active reports whether the reservation still occupies its tables in the book
+func (r Reservation) Active() bool {
+	return r.Status == StatusBooked || r.Status == StatusSeated
+}
+
+// ReservationCreate represents data for creating a reservation. StartsAt is RFC 3339 or
+// YYYY-MM-DDTHH:MM in business local time.
+type ReservationCreate struct {
+	GuestName string `json:"guest_name"`
+	Phone     string `json:"phone"`
+	PartySize int    `json:"party_size"`
+	StartsAt  string `json:"starts_at"`
+	Duration  int    `json:"duration_minutes,omitempty"`
+	TableIDs  []int  `json:"table_ids,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// ReservationUpdate represents data for changing a booked reservation. Unset fields are kept;
+// an empty table list unassigns the tables.
+type ReservationUpdate struct {
+	GuestName *string `json:"guest_name,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+	PartySize *int    `json:"party_size,omitempty"`
+	StartsAt  *string `json:"starts_at,omitempty"`
+	Duration  *int    `json:"duration_minutes,omitempty"`
+	TableIDs  *[]int  `json:"table_ids,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// StatusUpdateRequest represents a request to move a reservation to another status
+type StatusUpdateRequest struct {
+	Status    string `json:"status"`
+	ChangedBy int    `json:"-"` // taken from the auth token
+}
+
+// OpeningHours is one opening period of a weekday. A closing time at or before the opening
+// time means the period runs past midnight.
+type OpeningHours struct {
+	DayOfWeek int    `json:"day_of_week"` // 0 = Sunday ... 6 = Saturday
+	OpensAt   string `json:"opens_at"`    // HH:MM, business local time
+	ClosesAt  string `json:"closes_at"`   // HH:MM, business local time
+}
+
+// Book is the reservation book of one day: every reservation starting that day in
+// business local time, in start order
+type Book struct {
+	Date         string         `json:"date"` // YYYY-MM-DD
+	Reservations []Reservation  `json:"reservations"`
+	Covers       int            `json:"covers"` // guests expected by active reservations
+	OpeningHours []OpeningHours `json:"opening_hours"`
+}
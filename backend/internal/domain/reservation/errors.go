@@ -0,0 +1,35 @@
+package reservation
+
+import "errors"
+
+var (
+	// ErrReservationNotFound is returned when a reservation is not found
+	ErrReservationNotFound = errors.New("reservation not found")
+
+	// ErrInvalidReservation is returned when reservation data validation fails
+	ErrInvalidReservation = errors.New("invalid reservation")
+
+	// ErrReservationConflict is returned when one of the tables is booked for an overlapping time
+	ErrReservationConflict = errors.New("table already booked for that time")
+
+	// ErrOutsideOpeningHours is returned when a reservation does not fit in an opening period
+	ErrOutsideOpeningHours = errors.New("reservation outside opening hours")
+
+	// ErrNotEnoughSeats is returned when the assigned tables seat fewer guests than the party
+	ErrNotEnoughSeats = errors.New("tables do not seat the party")
+
+	// ErrTableNotFound is returned when an assigned table does not belong to the business
+	ErrTableNotFound = errors.New("table not found")
+
+	// ErrInvalidStatus is returned for an unknown status or a transition that is not allowed
+	ErrInvalidStatus = errors.New("invalid reservation status")
+
+	// ErrWaitlistEntryNotFound is returned when a waitlist entry is not found
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+
+	// ErrInvalidWaitlistEntry is returned when waitlist data validation fails
+	ErrInvalidWaitlistEntry = errors.New("invalid waitlist entry")
+
+	// ErrInvalidOpeningHours is returned when opening hours cannot be parsed
+	ErrInvalidOpeningHours = errors.New("invalid opening hours")
+)
@@ -0,0 +1,43 @@
+package reservation
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for reservation data operations
+type Repository interface {
+	// GetReservations returns the reservations starting in [from, to), in start order
+	GetReservations(ctx context.Context, from, to time.Time, businessID int) ([]Reservation, error)
+	GetReservationByID(ctx context.Context, id int, businessID int) (*Reservation, error)
+
+	// CreateReservation and UpdateReservation lock the assigned tables and fail with
+	// ErrReservationConflict if any of them has an overlapping active reservation
+	CreateReservation(ctx context.Context, r Reservation) (*Reservation, error)
+	UpdateReservation(ctx context.Context, r Reservation) (*Reservation, error)
+	UpdateReservationStatus(ctx context.Context, id int, status string, businessID int) error
+
+	// HoldTables marks free tables reserved, across all businesses, when a booked reservation
+	// starts within holdBefore of now, and returns the number of tables held
+	HoldTables(ctx context.Context, now time.Time, holdBefore time.Duration) (int, error)
+
+	// ReleaseTables frees the tables still held for a reservation
+	ReleaseTables(ctx context.Context, r Reservation) error
+
+	// Opening hours
+	GetOpeningHours(ctx context.Context, businessID int) ([]OpeningHours, error)
+	SetOpeningHours(ctx context.Context, hours []OpeningHours, businessID int) error
+
+	// Waitlist. GetWaitlist returns the parties still waiting, longest waiting first.
+	GetWaitlist(ctx context.Context, businessID int) ([]WaitlistEntry, error)
+	GetWaitlistEntry(ctx context.Context, id int, businessID int) (*WaitlistEntry, error)
+	CreateWaitlistEntry(ctx context.Context, e WaitlistEntry) (*WaitlistEntry, error)
+
+	// UpdateWaitlistStatus moves an entry to a status, stamping notified_at or seated_at and
+	// recording the tables of a seated party
+	UpdateWaitlistStatus(ctx context.Context, id int, status string, tableIDs []int, businessID int) error
+
+	// GetTurnTimes returns the average time a party keeps a table since the given moment,
+	// by number of table seats
+	GetTurnTimes(ctx context.Context, since time.Time, businessID int) (map[int]time.Duration, error)
+}
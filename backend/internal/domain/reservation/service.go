@@ -0,0 +1,37 @@
+package reservation
+
+import "context"
+
+// Service defines the reservation service interface
+type Service interface {
+	// GetBook returns the reservation book of a day (YYYY-MM-DD, business local time)
+	GetBook(ctx context.Context, date string, businessID int) (*Book, error)
+
+	GetReservation(ctx context.Context, id int, businessID int) (*Reservation, error)
+	CreateReservation(ctx context.Context, r ReservationCreate, businessID int) (*Reservation, error)
+	UpdateReservation(ctx context.Context, id int, r ReservationUpdate, businessID int) (*Reservation, error)
+
+	// UpdateStatus seats, completes, cancels or marks a no-show. Seating occupies the tables;
+	// cancelling or a no-show frees the tables held for the reservation.
+	UpdateStatus(ctx context.Context, id int, req StatusUpdateRequest, businessID int) (*Reservation, error)
+
+	// Opening hours bookings must fit in. Without opening hours any time can be booked.
+	GetOpeningHours(ctx context.Context, businessID int) ([]OpeningHours, error)
+	SetOpeningHours(ctx context.Context, hours []OpeningHours, businessID int) ([]OpeningHours, error)
+
+	// GetWaitlist returns the waiting parties in queue order with their estimated waits
+	GetWaitlist(ctx context.Context, businessID int) ([]WaitlistEntry, error)
+	AddToWaitlist(ctx context.Context, e WaitlistCreate, businessID int) (*WaitlistEntry, error)
+
+	// NotifyWaitlistEntry tells the guest their table is ready, through the notifier if one is set up
+	NotifyWaitlistEntry(ctx context.Context, id int, businessID int) (*WaitlistEntry, error)
+
+	// SeatWaitlistEntry seats a waiting party and occupies its tables
+	SeatWaitlistEntry(ctx context.Context, id int, req SeatRequest, businessID int) (*WaitlistEntry, error)
+
+	// CloseWaitlistEntry takes a party off the waitlist as a no-show or because they left
+	CloseWaitlistEntry(ctx context.Context, id int, status string, businessID int) (*WaitlistEntry, error)
+
+	// HoldUpcomingTables switches the tables of reservations starting soon to reserved
+	HoldUpcomingTables(ctx context.Context) (int, error)
+}
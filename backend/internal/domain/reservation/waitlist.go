@@ -0,0 +1,59 @@
+package reservation
+
+import (
+	"context"
+	"time"
+)
+
+// Waitlist statuses
+const (
+	WaitlistWaiting  = "waiting"
+	WaitlistNotified = "notified" // told their table is ready
+	WaitlistSeated   = "seated"
+	WaitlistNoShow   = "no_show"
+	WaitlistLeft     = "left" // gave up waiting
+)
+
+// DefaultTurnTime is assumed for a table when there is no order history to learn from
+const DefaultTurnTime = time.Hour
+
+// WaitlistEntry is a walk-in party waiting for a table
+type WaitlistEntry struct {
+	ID            int        `json:"id"`
+	BusinessID    int        `json:"business_id"`
+	GuestName     string     `json:"guest_name"`
+	Phone         string     `json:"phone"`
+	PartySize     int        `json:"party_size"`
+	Notes         string     `json:"notes,omitempty"`
+	Status        string     `json:"status"`
+	TableIDs      []int      `json:"table_ids,omitempty"` // where the party was seated
+	AddedAt       time.Time  `json:"added_at"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"`
+	SeatedAt      *time.Time `json:"seated_at,omitempty"`
+	Position      int        `json:"position,omitempty"`               // place in the queue while waiting
+	EstimatedWait *int       `json:"estimated_wait_minutes,omitempty"` // unset when no single table fits the party
+}
+
+// Waiting reports whether the party is still in the queue
+func (e WaitlistEntry) Waiting() bool {
+	return e.Status == WaitlistWaiting || e.Status == WaitlistNotified
+}
+
+// WaitlistCreate represents data for adding a party to the waitlist
+type WaitlistCreate struct {
+	GuestName string `json:"guest_name"`
+	Phone     string `json:"phone"`
+	PartySize int    `json:"party_size"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// SeatRequest assigns the tables a waiting party is seated at
+type SeatRequest struct {
+	TableIDs  []int `json:"table_ids"`
+	ChangedBy int   `json:"-"` // taken from the auth token
+}
+
+// GuestNotifier tells a waiting guest that their table is ready, e.g. by SMS
+type GuestNotifier interface {
+	NotifyTableReady(ctx context.Context, entry WaitlistEntry) error
+}
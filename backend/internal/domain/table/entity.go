@@ -9,6 +9,8 @@ const (
 	TableStatusFree     TableStatus = "free"
 	TableStatusOccupied TableStatus = "occupied"
 	TableStatusReserved TableStatus = "reserved"
+	// TableStatusNeedsCleaning is set when the last order of a table is paid
+	TableStatusNeedsCleaning TableStatus = "needs_cleaning"
 )
 
 // TableOrderInfo represents order information for a table
@@ -21,21 +23,34 @@ type TableOrderInfo struct {
 
 // Table represents a table entity
 type Table struct {
-	ID           int              `json:"id"`
-	Number       int              `json:"number"`
-	Seats        int              `json:"seats"`
-	Status       TableStatus      `json:"status"`
-	Orders       []TableOrderInfo `json:"orders,omitempty"` // Active orders associated with the table
-	ReservedAt   *time.Time       `json:"reserved_at,omitempty"`
-	OccupiedAt   *time.Time       `json:"occupied_at,omitempty"`
-	CurrentOrder *int             `json:"current_order,omitempty"`
+	ID            int              `json:"id"`
+	Number        int              `json:"number"`
+	Seats         int              `json:"seats"`
+	Status        TableStatus      `json:"status"`
+	Orders        []TableOrderInfo `json:"orders,omitempty"` // Active orders associated with the table
+	ReservedAt    *time.Time       `json:"reserved_at,omitempty"`
+	OccupiedAt    *time.Time       `json:"occupied_at,omitempty"`
+	CleaningSince *time.Time       `json:"cleaning_since,omitempty"` // set while the table needs cleaning
+	CurrentOrder  *int             `json:"current_order,omitempty"`
+	QRVersion     int              `json:"qr_version,omitempty"` // bumped to invalidate printed QR codes
+
+	// Floor plan placement
+	Name     string     `json:"name,omitempty"`
+	ZoneID   int        `json:"zone_id,omitempty"`
+	Shape    TableShape `json:"shape"`
+	X        float64    `json:"x"`
+	Y        float64    `json:"y"`
+	Width    float64    `json:"width"`
+	Height   float64    `json:"height"`
+	Rotation float64    `json:"rotation"` // degrees clockwise
 }
 
 // TableStats represents table statistics
 type TableStats struct {
-	Total     int     `json:"total"`
-	Free      int     `json:"free"`
-	Occupied  int     `json:"occupied"`
-	Reserved  int     `json:"reserved"`
-	Occupancy float64 `json:"occupancy"`
+	Total         int     `json:"total"`
+	Free          int     `json:"free"`
+	Occupied      int     `json:"occupied"`
+	Reserved      int     `json:"reserved"`
+	NeedsCleaning int     `json:"needs_cleaning"`
+	Occupancy     float64 `json:"occupancy"`
 }
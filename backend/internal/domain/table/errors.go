@@ -26,4 +26,16 @@ var (
 
 	// ErrTableUpdateFailed is returned when table update fails
 	ErrTableUpdateFailed = errors.New("failed to update table")
+
+	// ErrTableNumberTaken is returned when another table of the business already has the number
+	ErrTableNumberTaken = errors.New("table number already in use")
+
+	// ErrZoneNotFound is returned when a floor plan zone is not found
+	ErrZoneNotFound = errors.New("zone not found")
+
+	// ErrInvalidZoneData is returned when zone data validation fails
+	ErrInvalidZoneData = errors.New("invalid zone data")
+
+	// ErrInvalidReportPeriod is returned when a history or report period cannot be parsed
+	ErrInvalidReportPeriod = errors.New("invalid report period")
 )
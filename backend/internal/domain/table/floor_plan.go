@@ -0,0 +1,88 @@
+package table
+
+import "time"
+
+// TableShape is how a table is drawn on the floor plan
+type TableShape string
+
+const (
+	TableShapeRound     TableShape = "round"
+	TableShapeSquare    TableShape = "square"
+	TableShapeRectangle TableShape = "rectangle"
+)
+
+// Default size of a table on the floor plan, in plan units
+const (
+	DefaultTableWidth  = 80
+	DefaultTableHeight = 80
+)
+
+// Zone is a named area of the floor, such as the main hall or the terrace. Table positions
+// are relative to the top-left corner of their zone.
+type Zone struct {
+	ID         int       `json:"id"`
+	BusinessID int       `json:"business_id"`
+	Name       string    `json:"name"`
+	SortOrder  int       `json:"sort_order"`
+	Width      float64   `json:"width"`
+	Height     float64   `json:"height"`
+	Tables     []Table   `json:"tables,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ZoneCreate represents data for creating a zone. Without a sort order the zone goes last.
+type ZoneCreate struct {
+	Name       string  `json:"name"`
+	SortOrder  *int    `json:"sort_order,omitempty"`
+	Width      float64 `json:"width"`
+	Height     float64 `json:"height"`
+	BusinessID int     `json:"-"`
+}
+
+// ZoneUpdate represents data for updating a zone
+type ZoneUpdate struct {
+	Name       *string  `json:"name,omitempty"`
+	SortOrder  *int     `json:"sort_order,omitempty"`
+	Width      *float64 `json:"width,omitempty"`
+	Height     *float64 `json:"height,omitempty"`
+	BusinessID int      `json:"-"`
+}
+
+// TableCreate represents data for creating a table
+type TableCreate struct {
+	Number     int        `json:"number"`
+	Name       string     `json:"name,omitempty"`
+	Seats      int        `json:"seats"`
+	ZoneID     int        `json:"zone_id,omitempty"`
+	Shape      TableShape `json:"shape,omitempty"`
+	X          float64    `json:"x"`
+	Y          float64    `json:"y"`
+	Width      float64    `json:"width,omitempty"`
+	Height     float64    `json:"height,omitempty"`
+	Rotation   float64    `json:"rotation"`
+	BusinessID int        `json:"-"`
+}
+
+// TableUpdate represents data for updating a table. A zone ID of 0 takes the table out of its zone.
+type TableUpdate struct {
+	Number     *int        `json:"number,omitempty"`
+	Name       *string     `json:"name,omitempty"`
+	Seats      *int        `json:"seats,omitempty"`
+	ZoneID     *int        `json:"zone_id,omitempty"`
+	Shape      *TableShape `json:"shape,omitempty"`
+	X          *float64    `json:"x,omitempty"`
+	Y          *float64    `json:"y,omitempty"`
+	Width      *float64    `json:"width,omitempty"`
+	Height     *float64    `json:"height,omitempty"`
+	Rotation   *float64    `json:"rotation,omitempty"`
+	BusinessID int         `json:"-"`
+}
+
+// FloorPlan is the whole floor of a business as the waiter screen draws it: the zones in order
+// with their tables, and the tables not placed in any zone
+type FloorPlan struct {
+	BusinessID int     `json:"business_id"`
+	Zones      []Zone  `json:"zones"`
+	Tables     []Table `json:"tables"`
+}
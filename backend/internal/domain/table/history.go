@@ -0,0 +1,107 @@
+package table
+
+import "time"
+
+// StatusAudit tells who changed a table's status and, when seating, how many
+// guests sat down. A zero ChangedBy records a change made by the system.
+type StatusAudit struct {
+	ChangedBy int
+	PartySize int
+}
+
+// StatusChange is one entry of a table's status history
+type StatusChange struct {
+	ID            int         `json:"id"`
+	TableID       int         `json:"table_id"`
+	TableNumber   int         `json:"table_number"`
+	FromStatus    TableStatus `json:"from_status,omitempty"` // empty for a newly created table
+	ToStatus      TableStatus `json:"to_status"`
+	PartySize     int         `json:"party_size,omitempty"`
+	ChangedBy     int         `json:"changed_by,omitempty"` // 0 when changed by the system
+	ChangedByName string      `json:"changed_by_name,omitempty"`
+	ChangedAt     time.Time   `json:"changed_at"`
+}
+
+// StatusHistoryFilter narrows the status history. Zero values are ignored.
+type StatusHistoryFilter struct {
+	TableID int
+	From    time.Time
+	To      time.Time // exclusive
+	Limit   int
+}
+
+const (
+	// DefaultHistoryLimit is the number of history entries returned when no limit is given
+	DefaultHistoryLimit = 100
+	// MaxHistoryLimit caps the number of history entries in one response
+	MaxHistoryLimit = 1000
+)
+
+// StatusHistoryQuery holds the query parameters of the status history endpoint
+type StatusHistoryQuery struct {
+	TableID int
+	From    string // YYYY-MM-DD in business local time, inclusive
+	To      string // YYYY-MM-DD in business local time, inclusive
+	Limit   int
+}
+
+// Occupancy is one finished stay of a party at a table: from the moment the table
+// became occupied to its next status change
+type Occupancy struct {
+	TableID   int
+	Seats     int
+	PartySize int // 0 when the party size was not recorded
+	Start     time.Time
+	End       time.Time
+}
+
+// Day parts used to group dwell times, by the local hour a party sat down
+const (
+	DayPartBreakfast = "breakfast" // 05:00-11:00
+	DayPartLunch     = "lunch"     // 11:00-16:00
+	DayPartDinner    = "dinner"    // 16:00-22:00
+	DayPartLate      = "late"      // 22:00-05:00
+)
+
+// TurnTimeQuery holds the period of a turn-time report
+type TurnTimeQuery struct {
+	From string // YYYY-MM-DD in business local time, inclusive; defaults to 30 days before To
+	To   string // YYYY-MM-DD in business local time, inclusive; defaults to today
+}
+
+// DwellTime is the average stay of parties of one size in one day part
+type DwellTime struct {
+	PartySize      int     `json:"party_size"` // 0 for parties whose size was not recorded
+	DayPart        string  `json:"day_part"`
+	Turns          int     `json:"turns"`
+	AverageMinutes float64 `json:"average_minutes"`
+}
+
+// TableUtilisation reports how one table was used over the period
+type TableUtilisation struct {
+	TableID            int     `json:"table_id"`
+	Number             int     `json:"number"`
+	Seats              int     `json:"seats"`
+	Turns              int     `json:"turns"`
+	AverageTurnMinutes float64 `json:"average_turn_minutes"`
+	OccupiedMinutes    float64 `json:"occupied_minutes"`
+	Utilisation        float64 `json:"utilisation"` // share of open time the table was occupied, 0-1
+	Revenue            float64 `json:"revenue"`
+	RevenuePerSeatHour float64 `json:"revenue_per_seat_hour"`
+}
+
+// TurnTimeReport summarises table turns over a period. Open time comes from the
+// opening hours, or the whole period when none are set.
+type TurnTimeReport struct {
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"` // exclusive
+	OpenHours          float64            `json:"open_hours"`
+	Turns              int                `json:"turns"`
+	AverageTurnMinutes float64            `json:"average_turn_minutes"`
+	Utilisation        float64            `json:"utilisation"`
+	Revenue            float64            `json:"revenue"`
+	SeatHours          float64            `json:"seat_hours"` // seats times open hours
+	RevenuePerSeatHour float64            `json:"revenue_per_seat_hour"`
+	DwellTimes         []DwellTime        `json:"dwell_times"`
+	Tables             []TableUtilisation `json:"tables"`
+}
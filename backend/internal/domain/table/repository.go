@@ -19,9 +19,39 @@ type Repository interface {
 	// UpdateTableStatus updates a table's status
 	UpdateTableStatus(ctx context.Context, tableID int, status string) error
 
-	// UpdateTableStatusWithTimes updates a table's status and timestamp fields
-	UpdateTableStatusWithTimes(ctx context.Context, tableID int, status string, reservedAt, occupiedAt *time.Time) error
+	// UpdateTableStatusWithTimes updates a table's status and timestamp fields, recording who made the change
+	UpdateTableStatusWithTimes(ctx context.Context, tableID int, status string, reservedAt, occupiedAt *time.Time, audit StatusAudit) error
 
 	// TableHasActiveOrders checks if a table has any active orders
 	TableHasActiveOrders(ctx context.Context, tableID int) (bool, error)
+
+	// HasCompletedOrdersSince checks if a table had an order completed since the given time
+	HasCompletedOrdersSince(ctx context.Context, tableID int, since time.Time) (bool, error)
+
+	// FreeCleanedTables frees the tables that have waited for cleaning longer than the
+	// auto-free delay of their business and returns how many were freed
+	FreeCleanedTables(ctx context.Context, now time.Time) (int, error)
+
+	// GetBusinessTable retrieves a table of a business, including its QR code version
+	GetBusinessTable(ctx context.Context, id int, businessID int) (*Table, error)
+
+	// RotateQRVersion bumps the QR code version of a table and returns the new version
+	RotateQRVersion(ctx context.Context, id int, businessID int) (int, error)
+
+	// Table management
+	CreateTable(ctx context.Context, t TableCreate) (*Table, error)
+	UpdateTable(ctx context.Context, id int, t TableUpdate) (*Table, error)
+	DeleteTable(ctx context.Context, id int, businessID int) error
+
+	// Floor plan zones
+	GetZones(ctx context.Context, businessID int) ([]Zone, error)
+	GetZoneByID(ctx context.Context, id int, businessID int) (*Zone, error)
+	CreateZone(ctx context.Context, z ZoneCreate) (*Zone, error)
+	UpdateZone(ctx context.Context, id int, z ZoneUpdate) (*Zone, error)
+	DeleteZone(ctx context.Context, id int, businessID int) error
+
+	// Status history, recorded by the database on every status change
+	GetStatusHistory(ctx context.Context, filter StatusHistoryFilter, businessID int) ([]StatusChange, error)
+	GetOccupancies(ctx context.Context, from, to time.Time, businessID int) ([]Occupancy, error)
+	GetTableRevenue(ctx context.Context, from, to time.Time, businessID int) (map[int]float64, error)
 }
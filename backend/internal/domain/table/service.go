@@ -1,10 +1,15 @@
 package table
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // TableStatusUpdateRequest represents a request to update table status
 type TableStatusUpdateRequest struct {
-	Status string `json:"status"` // "free", "occupied", or "reserved"
+	Status    string `json:"status"`               // "free", "occupied", "reserved" or "needs_cleaning"
+	PartySize int    `json:"party_size,omitempty"` // guests seated, when occupying
+	ChangedBy int    `json:"-"`                    // taken from the auth token
 }
 
 // Service defines the table service interface
@@ -20,4 +25,28 @@ type Service interface {
 
 	// GetTableStats retrieves table statistics
 	GetTableStats(ctx context.Context, businessID int) (*TableStats, error)
+
+	// FreeCleanedTables returns tables waiting for cleaning to free once the
+	// business's auto-free delay has passed
+	FreeCleanedTables(ctx context.Context, now time.Time) (int, error)
+
+	// Table management. Tables with active orders cannot be deleted.
+	CreateTable(ctx context.Context, t TableCreate, businessID int) (*Table, error)
+	UpdateTable(ctx context.Context, id int, t TableUpdate, businessID int) (*Table, error)
+	DeleteTable(ctx context.Context, id int, businessID int) error
+
+	// Floor plan zones. Deleting a zone leaves its tables unplaced.
+	GetZones(ctx context.Context, businessID int) ([]Zone, error)
+	CreateZone(ctx context.Context, z ZoneCreate, businessID int) (*Zone, error)
+	UpdateZone(ctx context.Context, id int, z ZoneUpdate, businessID int) (*Zone, error)
+	DeleteZone(ctx context.Context, id int, businessID int) error
+
+	// GetFloorPlan returns the zones and tables of the business with their current status
+	GetFloorPlan(ctx context.Context, businessID int) (*FloorPlan, error)
+
+	// GetStatusHistory lists status changes, newest first
+	GetStatusHistory(ctx context.Context, query StatusHistoryQuery, businessID int) ([]StatusChange, error)
+
+	// GetTurnTimeReport reports turn and dwell times, utilisation and revenue per seat-hour
+	GetTurnTimeReport(ctx context.Context, query TurnTimeQuery, businessID int) (*TurnTimeReport, error)
 }
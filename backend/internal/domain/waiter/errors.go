@@ -0,0 +1,17 @@
+package waiter
+
+import "errors"
+
+var (
+	// ErrInvalidSection is returned when a section names unknown shifts, tables or zones
+	ErrInvalidSection = errors.New("invalid section")
+
+	// ErrWaiterNotOnShift is returned when a section is set for a waiter who is not on the shift
+	ErrWaiterNotOnShift = errors.New("waiter is not on the shift")
+
+	// ErrSectionConflict is returned when a table or zone is already in another waiter's section
+	ErrSectionConflict = errors.New("table or zone already assigned to another waiter")
+
+	// ErrInvalidHandoff is returned when a handoff has no valid colleague to receive it
+	ErrInvalidHandoff = errors.New("invalid handoff")
+)
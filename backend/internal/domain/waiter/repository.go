@@ -11,4 +11,11 @@ type Repository interface {
 	GetTablesAssignedToWaiter(ctx context.Context, waiterID int, businessID int) ([]Table, error)
 	GetWaiterOrderStats(ctx context.Context, waiterID int, businessID int) (OrderStatusCounts, error)
 	GetWaiterPerformanceMetrics(ctx context.Context, waiterID int, businessID int) (PerformanceMetrics, error)
+
+	// Sections
+	GetSections(ctx context.Context, shiftID int, businessID int) ([]Section, error)
+	IsOnShift(ctx context.Context, shiftID int, waiterID int, businessID int) (bool, error)
+	ReplaceSection(ctx context.Context, section Section, businessID int) error
+	GetTableOwner(ctx context.Context, tableID int, businessID int) (*SectionOwner, error)
+	Handoff(ctx context.Context, req HandoffRequest, businessID int) (*HandoffResult, error)
 }
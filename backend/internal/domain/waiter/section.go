@@ -0,0 +1,33 @@
+package waiter
+
+// Section is the set of tables and floor zones a waiter covers during one shift.
+// A table assigned directly takes precedence over the zone it stands in.
+type Section struct {
+	ShiftID    int    `json:"shift_id"`
+	WaiterID   int    `json:"waiter_id"`
+	WaiterName string `json:"waiter_name,omitempty"`
+	TableIDs   []int  `json:"table_ids"`
+	ZoneIDs    []int  `json:"zone_ids"`
+}
+
+// SectionOwner identifies the waiter whose current section covers a table
+type SectionOwner struct {
+	TableID    int    `json:"table_id"`
+	ShiftID    int    `json:"shift_id"`
+	WaiterID   int    `json:"waiter_id"`
+	WaiterName string `json:"waiter_name"`
+}
+
+// HandoffRequest moves a waiter's current section and open orders to a colleague
+type HandoffRequest struct {
+	FromWaiterID int `json:"from_waiter_id"`
+	ToWaiterID   int `json:"to_waiter_id"`
+}
+
+// HandoffResult reports what a handoff moved
+type HandoffResult struct {
+	FromWaiterID int `json:"from_waiter_id"`
+	ToWaiterID   int `json:"to_waiter_id"`
+	Assignments  int `json:"assignments_moved"`
+	Orders       int `json:"orders_moved"`
+}
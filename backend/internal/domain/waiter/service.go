@@ -11,4 +11,10 @@ type Service interface {
 	GetTablesAssignedToWaiter(ctx context.Context, waiterID int, businessID int) ([]Table, error)
 	GetWaiterOrderStats(ctx context.Context, waiterID int, businessID int) (OrderStatusCounts, error)
 	GetWaiterPerformanceMetrics(ctx context.Context, waiterID int, businessID int) (PerformanceMetrics, error)
+
+	// Sections
+	GetSections(ctx context.Context, shiftID int, businessID int) ([]Section, error)
+	SetSection(ctx context.Context, section Section, businessID int) (*Section, error)
+	CheckTableSection(ctx context.Context, tableID int, waiterID int, businessID int) (*SectionOwner, error)
+	Handoff(ctx context.Context, req HandoffRequest, businessID int) (*HandoffResult, error)
 }
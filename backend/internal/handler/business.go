@@ -165,6 +165,42 @@ func (c *BusinessController) DeleteBusiness(w http.ResponseWriter, r *http.Reque
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Business deleted successfully"})
 }
 
+// UploadLogo stores the "image" field of a multipart form as the business logo
+func (c *BusinessController) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid business ID format")
+		return
+	}
+
+	part, err := imageUpload(r)
+	if err != nil {
+		if !writeImageUploadError(w, err) {
+			respondWithError(w, http.StatusBadRequest, "Invalid multipart form")
+		}
+		return
+	}
+	defer part.Close()
+
+	updated, err := c.businessService.UploadLogo(r.Context(), id, part)
+	if err != nil {
+		log.Printf("Error uploading logo for business %d: %v", id, err)
+		if writeImageUploadError(w, err) {
+			return
+		}
+		switch err {
+		case business.ErrBusinessNotFound:
+			respondWithError(w, http.StatusNotFound, "Business not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Failed to upload logo")
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
 // SetBusinessCookie sets a cookie with the business ID
 func (c *BusinessController) SetBusinessCookie(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -2,16 +2,19 @@ package handler
 
 import (
 	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/guest"
 	"restaurant-management/internal/domain/inventory"
 	"restaurant-management/internal/domain/menu"
 	"restaurant-management/internal/domain/notification"
 	"restaurant-management/internal/domain/order"
 	"restaurant-management/internal/domain/request"
+	"restaurant-management/internal/domain/reservation"
 	"restaurant-management/internal/domain/shift"
 	"restaurant-management/internal/domain/supplier"
 	"restaurant-management/internal/domain/table"
 	"restaurant-management/internal/domain/user"
 	"restaurant-management/internal/domain/waiter"
+	"restaurant-management/internal/middleware"
 )
 
 // Controllers contains all application controllers
@@ -32,6 +35,15 @@ type Controllers struct {
 	// Controllers now using services
 	Supplier *SupplierController
 	Request  *RequestController
+
+	// Guest ordering from table QR codes
+	Guest *GuestController
+
+	// Table management and floor plan
+	Table *TableController
+
+	// Table reservations
+	Reservation *ReservationController
 }
 
 // NewControllers creates a new instance of Controllers with all dependencies
@@ -47,6 +59,9 @@ func NewControllers(
 	requestService request.Service,
 	waiterService waiter.Service,
 	notificationService notification.Service,
+	guestService guest.Service,
+	reservationService reservation.Service,
+	guestOrderLimiter *middleware.RateLimiter,
 ) *Controllers {
 	return &Controllers{
 		Auth:         NewAuthController(userService),
@@ -62,5 +77,8 @@ func NewControllers(
 		Notification: NewNotificationController(notificationService),
 		Supplier:     NewSupplierController(supplierService),
 		Request:      NewRequestController(requestService),
+		Guest:        NewGuestController(guestService, guestOrderLimiter),
+		Table:        NewTableController(tableService),
+		Reservation:  NewReservationController(reservationService),
 	}
 }
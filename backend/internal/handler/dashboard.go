@@ -65,7 +65,7 @@ func (c *DashboardController) GetOverviewStats(w http.ResponseWriter, r *http.Re
 	if err == nil {
 		inventoryCount = len(inventory)
 		for _, item := range inventory {
-			if item.Quantity <= item.MinQuantity {
+			if item.Quantity <= item.MinStock {
 				lowStockCount++
 			}
 		}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/guest"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/domain/order"
+	"restaurant-management/internal/domain/table"
+	"restaurant-management/internal/middleware"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GuestController serves the public menu and QR table ordering. Its public routes never
+// see staff cookies or tokens: guests authenticate with a session token opened from a
+// table QR code.
+type GuestController struct {
+	guestService guest.Service
+	orderLimiter *middleware.RateLimiter
+}
+
+// NewGuestController creates a new GuestController. orderLimiter caps orders per table.
+func NewGuestController(guestService guest.Service, orderLimiter *middleware.RateLimiter) *GuestController {
+	return &GuestController{guestService: guestService, orderLimiter: orderLimiter}
+}
+
+// RegisterPublicRoutes registers the unauthenticated guest API
+func (c *GuestController) RegisterPublicRoutes(r *mux.Router) {
+	r.HandleFunc("/businesses/{id:[0-9]+}/menu", c.GetPublicMenu).Methods("GET")
+	r.HandleFunc("/sessions", c.StartSession).Methods("POST")
+	r.HandleFunc("/session", c.GetSession).Methods("GET")
+	r.HandleFunc("/orders", c.PlaceOrder).Methods("POST")
+}
+
+// RegisterManagerRoutes registers the table QR code management routes
+func (c *GuestController) RegisterManagerRoutes(r *mux.Router) {
+	r.HandleFunc("/tables/{id:[0-9]+}/qr", c.GetTableQR).Methods("GET")
+	r.HandleFunc("/tables/{id:[0-9]+}/qr/link", c.GetTableLink).Methods("GET")
+	r.HandleFunc("/tables/{id:[0-9]+}/qr/rotate", c.RotateTableLink).Methods("POST")
+}
+
+// GetPublicMenu returns the menu of a business in the ?lang or Accept-Language language.
+// Guests can filter it with exclude_allergens and dietary like the staff menu.
+func (c *GuestController) GetPublicMenu(w http.ResponseWriter, r *http.Request) {
+	businessID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid business ID", http.StatusBadRequest)
+		return
+	}
+
+	filter := menu.MenuItemFilter{
+		ExcludeAllergens: splitQueryList(r.URL.Query().Get("exclude_allergens")),
+		DietaryTags:      splitQueryList(r.URL.Query().Get("dietary")),
+	}
+	publicMenu, err := c.guestService.GetMenu(r.Context(), businessID, filter, requestedLanguages(r))
+	if err != nil {
+		switch err {
+		case guest.ErrBusinessUnavailable:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case menu.ErrUnknownAllergen, menu.ErrUnknownDietaryTag:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Error getting public menu for business %d: %v", businessID, err)
+			http.Error(w, "Failed to get menu", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", publicMenu.Language)
+	w.Header().Add("Vary", "Accept-Language")
+	json.NewEncoder(w).Encode(publicMenu)
+}
+
+// StartSession opens a guest session from the token of a scanned table QR code
+func (c *GuestController) StartSession(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, err := c.guestService.StartSession(r.Context(), req.Token)
+	if err != nil {
+		switch err {
+		case guest.ErrInvalidQRToken, guest.ErrBusinessUnavailable:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Printf("Error starting guest session: %v", err)
+			http.Error(w, "Failed to start session", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// GetSession returns the guest session of the bearer token
+func (c *GuestController) GetSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := c.guestSession(w, r)
+	if !ok {
+		return
+	}
+	session.Token = ""
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// PlaceOrder submits a guest order to the session table, where it waits for a waiter to accept it
+func (c *GuestController) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	session, ok := c.guestSession(w, r)
+	if !ok {
+		return
+	}
+	// Keyed by table: scanning the QR again opens a new session and must not reset the cap
+	if allowed, retryAfter := c.orderLimiter.Allow(strconv.Itoa(session.TableID)); !allowed {
+		middleware.WriteTooManyRequests(w, retryAfter)
+		return
+	}
+
+	var req guest.OrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.guestService.PlaceOrder(r.Context(), session, req)
+	if err != nil {
+		var conflict *order.AllergenConflictError
+		if errors.As(err, &conflict) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":            conflict.Error(),
+				"allergy_warnings": conflict.Warnings,
+			})
+			return
+		}
+		switch err {
+		case guest.ErrInvalidSession:
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case guest.ErrBusinessUnavailable:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case order.ErrDishNotFound, order.ErrDishNotOnMenu, order.ErrDishNotAvailable, order.ErrUnknownAllergy, order.ErrInvalidOrderData, order.ErrInvalidTableID:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case order.ErrBundleNotFound, order.ErrBundleNotAvailable, order.ErrInvalidBundleChoice:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Error placing guest order for table %d: %v", session.TableID, err)
+			http.Error(w, "Failed to place order", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// GetTableQR renders the QR code of a table as ?format=png (default) or svg, ?size pixels wide
+func (c *GuestController) GetTableQR(w http.ResponseWriter, r *http.Request) {
+	tableID, businessID, ok := tableQRParams(w, r)
+	if !ok {
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = guest.QRFormatPNG
+	}
+	size := 0
+	if value := r.URL.Query().Get("size"); value != "" {
+		var err error
+		if size, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid size", http.StatusBadRequest)
+			return
+		}
+	}
+
+	image, err := c.guestService.TableQR(r.Context(), tableID, businessID, format, size)
+	if err != nil {
+		writeTableQRError(w, err)
+		return
+	}
+
+	if format == guest.QRFormatSVG {
+		w.Header().Set("Content-Type", "image/svg+xml")
+	} else {
+		w.Header().Set("Content-Type", "image/png")
+	}
+	w.Header().Set("Content-Disposition", `inline; filename="table-`+strconv.Itoa(tableID)+`.`+format+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(image)
+}
+
+// GetTableLink returns the signed guest link encoded in the QR code of a table
+func (c *GuestController) GetTableLink(w http.ResponseWriter, r *http.Request) {
+	tableID, businessID, ok := tableQRParams(w, r)
+	if !ok {
+		return
+	}
+
+	link, err := c.guestService.TableLink(r.Context(), tableID, businessID)
+	if err != nil {
+		writeTableQRError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(link)
+}
+
+// RotateTableLink invalidates the printed QR codes and open guest sessions of a table
+func (c *GuestController) RotateTableLink(w http.ResponseWriter, r *http.Request) {
+	tableID, businessID, ok := tableQRParams(w, r)
+	if !ok {
+		return
+	}
+
+	link, err := c.guestService.RotateTableLink(r.Context(), tableID, businessID)
+	if err != nil {
+		writeTableQRError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(link)
+}
+
+// guestSession reads the guest session from the Authorization header, writing a 401 if it is missing or invalid
+func (c *GuestController) guestSession(w http.ResponseWriter, r *http.Request) (*guest.Session, bool) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		http.Error(w, "Guest session required", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	session, err := c.guestService.ParseSession(r.Context(), token)
+	if err != nil {
+		http.Error(w, guest.ErrInvalidSession.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return session, true
+}
+
+func tableQRParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	tableID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid table ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return tableID, businessID, true
+}
+
+func writeTableQRError(w http.ResponseWriter, err error) {
+	switch err {
+	case table.ErrTableNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case table.ErrInvalidTableID, table.ErrInvalidTableData, guest.ErrInvalidQRFormat:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Error handling table QR code: %v", err)
+		http.Error(w, "Failed to handle table QR code", http.StatusInternalServerError)
+	}
+}
@@ -81,12 +81,19 @@ func (c *InventoryController) Create(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	item.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.inventoryService.CreateInventory(r.Context(), &item, businessID); err != nil {
 		log.Printf("Error creating inventory item: %v", err)
 		switch err {
 		case inventory.ErrInvalidInventoryData:
 			http.Error(w, "Invalid inventory data", http.StatusBadRequest)
+		case inventory.ErrInvalidConversion:
+			http.Error(w, "Invalid unit conversions", http.StatusBadRequest)
+		case inventory.ErrInvalidSupplier:
+			http.Error(w, "Invalid suppliers: link known suppliers once each, with at most one preferred", http.StatusBadRequest)
+		case inventory.ErrIncompatibleUnits:
+			http.Error(w, "Minimum unit does not convert to the stock unit", http.StatusBadRequest)
 		default:
 			http.Error(w, "Failed to create inventory item", http.StatusInternalServerError)
 		}
@@ -119,14 +126,23 @@ func (c *InventoryController) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	item.ID = id
+	item.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.inventoryService.UpdateInventory(r.Context(), &item, businessID); err != nil {
 		log.Printf("Error updating inventory item %d: %v", id, err)
 		switch err {
+		case inventory.ErrInsufficientStock:
+			http.Error(w, "Stock cannot go below zero", http.StatusConflict)
 		case inventory.ErrInventoryItemNotFound:
 			http.Error(w, "Inventory item not found", http.StatusNotFound)
 		case inventory.ErrInvalidInventoryData:
 			http.Error(w, "Invalid inventory data", http.StatusBadRequest)
+		case inventory.ErrInvalidConversion:
+			http.Error(w, "Invalid unit conversions", http.StatusBadRequest)
+		case inventory.ErrInvalidSupplier:
+			http.Error(w, "Invalid suppliers: link known suppliers once each, with at most one preferred", http.StatusBadRequest)
+		case inventory.ErrIncompatibleUnits:
+			http.Error(w, "Units do not convert to the stock unit", http.StatusBadRequest)
 		default:
 			http.Error(w, "Failed to update inventory item", http.StatusInternalServerError)
 		}
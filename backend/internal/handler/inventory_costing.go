@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+)
+
+// GetValuation values the stock on hand (?method=average|fifo, default the business setting)
+func (c *InventoryController) GetValuation(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	valuation, err := c.inventoryService.GetValuation(r.Context(), r.URL.Query().Get("method"), businessID)
+	if err != nil {
+		writeCostingError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(valuation)
+}
+
+// GetCostOfGoods sums the cost of the stock used in a period against its sales (?from=&to=&method=)
+func (c *InventoryController) GetCostOfGoods(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := inventory.CostOfGoodsQuery{From: q.Get("from"), To: q.Get("to"), Method: q.Get("method")}
+	report, err := c.inventoryService.GetCostOfGoods(r.Context(), query, businessID)
+	if err != nil {
+		writeCostingError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeCostingError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrInvalidCostingMethod:
+		http.Error(w, "Invalid method, expected average or fifo", http.StatusBadRequest)
+	case inventory.ErrInvalidCostPeriod:
+		http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+	default:
+		log.Printf("Error computing stock costs: %v", err)
+		http.Error(w, "Failed to compute stock costs", http.StatusInternalServerError)
+	}
+}
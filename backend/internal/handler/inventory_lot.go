@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetLots lists the lots with stock left, first expiring first
+// (?inventory_id=&expiring_within=days&all=true to include used-up lots)
+func (c *InventoryController) GetLots(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := inventory.LotQuery{All: q.Get("all") == "true"}
+	if v := q.Get("inventory_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid inventory_id", http.StatusBadRequest)
+			return
+		}
+		query.InventoryID = id
+	}
+	if v := q.Get("expiring_within"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid expiring_within, expected a number of days", http.StatusBadRequest)
+			return
+		}
+		query.ExpiringWithin = &days
+	}
+
+	lots, err := c.inventoryService.GetLots(r.Context(), query, businessID)
+	if err != nil {
+		writeLotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lots)
+}
+
+// GetExpiredLots lists the lots with stock left whose expiry date has passed
+func (c *InventoryController) GetExpiredLots(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	lots, err := c.inventoryService.GetExpiredLots(r.Context(), businessID)
+	if err != nil {
+		writeLotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lots)
+}
+
+// WriteOffExpiredLots logs the stock left in expired lots as spoiled waste, all of
+// them unless lot_ids are given
+func (c *InventoryController) WriteOffExpiredLots(w http.ResponseWriter, r *http.Request) {
+	// The body is optional
+	var req inventory.WriteOffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	entries, err := c.inventoryService.WriteOffExpiredLots(r.Context(), req, businessID)
+	if err != nil {
+		writeLotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetLot returns a lot with the movements that drew from it
+func (c *InventoryController) GetLot(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	lot, err := c.inventoryService.GetLot(r.Context(), id, businessID)
+	if err != nil {
+		writeLotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lot)
+}
+
+func writeLotError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrLotNotFound:
+		http.Error(w, "Lot not found", http.StatusNotFound)
+	case inventory.ErrInventoryItemNotFound:
+		http.Error(w, "Inventory item not found", http.StatusNotFound)
+	case inventory.ErrInvalidLot:
+		http.Error(w, "Invalid lot query, expected a non-negative number of days", http.StatusBadRequest)
+	case inventory.ErrInvalidWriteOff:
+		http.Error(w, "Only expired lots with stock left can be written off", http.StatusBadRequest)
+	case inventory.ErrInsufficientStock:
+		http.Error(w, "Not enough stock to write off these lots", http.StatusConflict)
+	default:
+		log.Printf("Error processing lots: %v", err)
+		http.Error(w, "Failed to process lots", http.StatusInternalServerError)
+	}
+}
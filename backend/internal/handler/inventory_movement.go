@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetMovements lists the stock ledger of an item, newest first (?type=&from=&to=&limit=)
+func (c *InventoryController) GetMovements(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := inventory.MovementQuery{
+		Type: inventory.MovementType(q.Get("type")),
+		From: q.Get("from"),
+		To:   q.Get("to"),
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	movements, err := c.inventoryService.GetMovements(r.Context(), id, query, businessID)
+	if err != nil {
+		writeMovementError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movements)
+}
+
+// RecordMovement books a receipt, consumption, waste, adjustment or transfer for an item
+func (c *InventoryController) RecordMovement(w http.ResponseWriter, r *http.Request) {
+	c.recordMovement(w, r, nil)
+}
+
+// RecordKitchenMovement lets cooks book what the kitchen uses or loses. Receipts set the
+// stock valuation and adjustments rewrite the book stock, so both stay with managers.
+func (c *InventoryController) RecordKitchenMovement(w http.ResponseWriter, r *http.Request) {
+	c.recordMovement(w, r, []inventory.MovementType{inventory.MovementConsumption, inventory.MovementWaste})
+}
+
+// recordMovement books the movement in the request body; a non-empty allowed list
+// restricts the movement types the caller may record
+func (c *InventoryController) recordMovement(w http.ResponseWriter, r *http.Request, allowed []inventory.MovementType) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var req inventory.MovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(allowed) > 0 && !movementTypeAllowed(req.Type, allowed) {
+		http.Error(w, "This movement type can only be recorded by a manager", http.StatusForbidden)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	movements, err := c.inventoryService.RecordMovement(r.Context(), id, req, businessID)
+	if err != nil {
+		writeMovementError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(movements)
+}
+
+func movementTypeAllowed(t inventory.MovementType, allowed []inventory.MovementType) bool {
+	for _, a := range allowed {
+		if t == a {
+			return true
+		}
+	}
+	return false
+}
+
+func writeMovementError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrInventoryItemNotFound:
+		http.Error(w, "Inventory item not found", http.StatusNotFound)
+	case inventory.ErrInvalidMovement:
+		http.Error(w, "Invalid stock movement", http.StatusBadRequest)
+	case inventory.ErrInvalidMovementPeriod:
+		http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+	case inventory.ErrInsufficientStock:
+		http.Error(w, "Not enough stock for this movement", http.StatusConflict)
+	case inventory.ErrIncompatibleUnits:
+		http.Error(w, "Unit does not convert to the stock unit", http.StatusBadRequest)
+	case inventory.ErrInvalidLot:
+		http.Error(w, "Invalid lot: lot details go on receipts only, with expires_on as YYYY-MM-DD and a known supplier_id", http.StatusBadRequest)
+	default:
+		log.Printf("Error processing stock movement: %v", err)
+		http.Error(w, "Failed to process stock movement", http.StatusInternalServerError)
+	}
+}
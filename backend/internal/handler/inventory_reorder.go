@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+	"strconv"
+)
+
+// GetReorderSuggestions lists what to order of the low stock items, grouped by supplier
+// (?cover_days=&lookback_days=)
+func (c *InventoryController) GetReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	query, ok := reorderQuery(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := c.inventoryService.GetReorderSuggestions(r.Context(), query, businessID)
+	if err != nil {
+		writeReorderError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
+
+// CreateReorderRequests turns the reorder suggestions into draft requests, one per
+// supplier (?cover_days=&lookback_days=)
+func (c *InventoryController) CreateReorderRequests(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	query, ok := reorderQuery(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := c.inventoryService.CreateReorderRequests(r.Context(), query, businessID)
+	if err != nil {
+		writeReorderError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// reorderQuery reads the optional day counts, writing the error when one is not a number
+func reorderQuery(w http.ResponseWriter, r *http.Request) (inventory.ReorderQuery, bool) {
+	var query inventory.ReorderQuery
+	q := r.URL.Query()
+	for param, dst := range map[string]*int{"cover_days": &query.CoverDays, "lookback_days": &query.LookbackDays} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid "+param+", expected a number of days", http.StatusBadRequest)
+			return query, false
+		}
+		*dst = days
+	}
+	return query, true
+}
+
+func writeReorderError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrInvalidReorder:
+		http.Error(w, "Invalid cover_days or lookback_days, expected 0 to 365", http.StatusBadRequest)
+	default:
+		log.Printf("Error processing reorder: %v", err)
+		http.Error(w, "Failed to process reorder", http.StatusInternalServerError)
+	}
+}
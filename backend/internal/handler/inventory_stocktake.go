@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetStocktakes lists the count sessions, newest first (?status=open|approved|cancelled)
+func (c *InventoryController) GetStocktakes(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	status := inventory.StocktakeStatus(r.URL.Query().Get("status"))
+	stocktakes, err := c.inventoryService.GetStocktakes(r.Context(), status, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktakes)
+}
+
+// OpenStocktake starts a count session over all items or the category in the body
+func (c *InventoryController) OpenStocktake(w http.ResponseWriter, r *http.Request) {
+	var req inventory.StocktakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	st, err := c.inventoryService.OpenStocktake(r.Context(), req, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(st)
+}
+
+// GetStocktake returns a session with its counts and variance per item
+func (c *InventoryController) GetStocktake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	st, err := c.inventoryService.GetStocktake(r.Context(), id, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// SubmitCounts records the counts of the current user for a session
+func (c *InventoryController) SubmitCounts(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var req inventory.CountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	st, err := c.inventoryService.SubmitCounts(r.Context(), id, req, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// ApproveStocktake closes a session and posts its variance as adjustments
+func (c *InventoryController) ApproveStocktake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+
+	st, err := c.inventoryService.ApproveStocktake(r.Context(), id, userID, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// CancelStocktake closes a session without touching the stock
+func (c *InventoryController) CancelStocktake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.inventoryService.CancelStocktake(r.Context(), id, businessID); err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetVarianceReport returns the value lost per item in approved sessions (?from=&to=&inventory_id=)
+func (c *InventoryController) GetVarianceReport(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := inventory.VarianceQuery{From: q.Get("from"), To: q.Get("to")}
+	if id := q.Get("inventory_id"); id != "" {
+		n, err := strconv.Atoi(id)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid inventory_id", http.StatusBadRequest)
+			return
+		}
+		query.InventoryID = n
+	}
+
+	report, err := c.inventoryService.GetVarianceReport(r.Context(), query, businessID)
+	if err != nil {
+		writeStocktakeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeStocktakeError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrStocktakeNotFound:
+		http.Error(w, "Stocktake not found", http.StatusNotFound)
+	case inventory.ErrStocktakeInProgress:
+		http.Error(w, "Another stocktake is still open", http.StatusConflict)
+	case inventory.ErrStocktakeClosed:
+		http.Error(w, "Stocktake is no longer open", http.StatusConflict)
+	case inventory.ErrInvalidStocktake:
+		http.Error(w, "Invalid stocktake: unknown status or no items to count", http.StatusBadRequest)
+	case inventory.ErrInvalidCount:
+		http.Error(w, "Invalid count: quantities must be non-negative and for items of the session", http.StatusBadRequest)
+	case inventory.ErrIncompatibleUnits:
+		http.Error(w, "Unit does not convert to the stock unit", http.StatusBadRequest)
+	case inventory.ErrInvalidVariancePeriod:
+		http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+	case inventory.ErrInventoryItemNotFound:
+		http.Error(w, "Inventory item not found", http.StatusNotFound)
+	default:
+		log.Printf("Error processing stocktake: %v", err)
+		http.Error(w, "Failed to process stocktake", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// GetUnits lists the units catalogue. Item-specific units come with each item.
+func (c *InventoryController) GetUnits(w http.ResponseWriter, r *http.Request) {
+	units, err := c.inventoryService.GetUnits(r.Context())
+	if err != nil {
+		log.Printf("Error getting units: %v", err)
+		http.Error(w, "Failed to fetch units", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"units": units})
+}
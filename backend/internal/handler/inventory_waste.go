@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// LogWaste records spoiled, dropped, overproduced or returned stock of an item or dish
+func (c *InventoryController) LogWaste(w http.ResponseWriter, r *http.Request) {
+	var req inventory.WasteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	waste, err := c.inventoryService.LogWaste(r.Context(), req, businessID)
+	if err != nil {
+		writeWasteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(waste)
+}
+
+// GetWasteEntries lists the waste logged in a period, newest first (?from=&to=&reason=)
+func (c *InventoryController) GetWasteEntries(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := inventory.WasteQuery{From: q.Get("from"), To: q.Get("to"), Reason: inventory.WasteReason(q.Get("reason"))}
+	entries, err := c.inventoryService.GetWasteEntries(r.Context(), query, businessID)
+	if err != nil {
+		writeWasteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetWaste returns a waste entry with the stock movements it caused
+func (c *InventoryController) GetWaste(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	waste, err := c.inventoryService.GetWaste(r.Context(), id, businessID)
+	if err != nil {
+		writeWasteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(waste)
+}
+
+// UploadWastePhoto stores the "image" field of a multipart form as the photo of a waste entry
+func (c *InventoryController) UploadWastePhoto(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	part, err := imageUpload(r)
+	if err != nil {
+		if !writeImageUploadError(w, err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	defer part.Close()
+
+	waste, err := c.inventoryService.UploadWastePhoto(r.Context(), id, businessID, part)
+	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
+		writeWasteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(waste)
+}
+
+// GetWasteReport sums the value of the waste in a period by reason, item and shift (?from=&to=)
+func (c *InventoryController) GetWasteReport(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	report, err := c.inventoryService.GetWasteReport(r.Context(), inventory.WasteQuery{From: q.Get("from"), To: q.Get("to")}, businessID)
+	if err != nil {
+		writeWasteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeWasteError(w http.ResponseWriter, err error) {
+	switch err {
+	case inventory.ErrWasteNotFound:
+		http.Error(w, "Waste entry not found", http.StatusNotFound)
+	case inventory.ErrInventoryItemNotFound:
+		http.Error(w, "Inventory item not found", http.StatusNotFound)
+	case inventory.ErrLotNotFound:
+		http.Error(w, "Lot not found", http.StatusNotFound)
+	case inventory.ErrInvalidWaste:
+		http.Error(w, "Invalid waste: give a known dish or inventory item, a positive quantity and a reason of spoiled, dropped, overproduced or returned", http.StatusBadRequest)
+	case inventory.ErrIncompatibleUnits:
+		http.Error(w, "Unit does not convert to the stock unit", http.StatusBadRequest)
+	case inventory.ErrInsufficientStock:
+		http.Error(w, "Not enough stock to waste this quantity", http.StatusConflict)
+	case inventory.ErrInvalidWastePeriod:
+		http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+	default:
+		log.Printf("Error processing waste: %v", err)
+		http.Error(w, "Failed to process waste", http.StatusInternalServerError)
+	}
+}
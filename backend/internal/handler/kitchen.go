@@ -139,6 +139,7 @@ func (c *KitchenController) UpdateInventory(w http.ResponseWriter, r *http.Reque
 	}
 
 	item.ID = id
+	item.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.inventoryService.UpdateInventory(r.Context(), &item, businessID); err != nil {
 		log.Printf("Error updating inventory item %d: %v", id, err)
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"restaurant-management/internal/domain/media"
+	"strings"
+)
+
+// imageFormField is the multipart field that carries uploaded images
+const imageFormField = "image"
+
+var errMissingImage = errors.New("missing image field in multipart form")
+
+// imageUpload returns the "image" part of a multipart request without buffering the
+// whole body; the media service enforces the size limit while reading it.
+func imageUpload(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errMissingImage
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errMissingImage
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != imageFormField {
+			part.Close()
+			continue
+		}
+
+		// Reject declared non-image types early; the content itself is sniffed later
+		if declared := part.Header.Get("Content-Type"); declared != "" {
+			mediaType, _, err := mime.ParseMediaType(declared)
+			if err != nil || (!strings.HasPrefix(mediaType, "image/") && mediaType != "application/octet-stream") {
+				part.Close()
+				return nil, media.ErrUnsupportedImageType
+			}
+		}
+		return part, nil
+	}
+}
+
+// writeImageUploadError maps upload failures to HTTP responses, returning false for
+// errors that are not upload-related so the caller can handle them
+func writeImageUploadError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case errMissingImage:
+		http.Error(w, "Missing image field in multipart form", http.StatusBadRequest)
+	case media.ErrImageTooLarge:
+		http.Error(w, "Image is too large", http.StatusRequestEntityTooLarge)
+	case media.ErrUnsupportedImageType:
+		http.Error(w, "Unsupported image type, expected "+strings.Join(media.AllowedContentTypes, ", "), http.StatusUnsupportedMediaType)
+	case media.ErrInvalidImage:
+		http.Error(w, "Could not read image", http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
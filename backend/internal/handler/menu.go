@@ -22,16 +22,52 @@ func NewMenuController(menuService menu.Service) *MenuController {
 
 func (c *MenuController) RegisterRoutes(r *mux.Router) {
 	menuRouter := r.PathPrefix("/menu").Subrouter()
+	menuRouter.Use(c.withLanguage)
 	menuRouter.HandleFunc("/items", c.GetMenuItems).Methods("GET")
+	menuRouter.HandleFunc("/search", c.SearchMenuItems).Methods("GET")
 	menuRouter.HandleFunc("/items/{id:[0-9]+}", c.GetMenuItem).Methods("GET")
 	menuRouter.HandleFunc("/items", c.CreateMenuItem).Methods("POST")
 	menuRouter.HandleFunc("/items/{id:[0-9]+}", c.UpdateMenuItem).Methods("PUT")
 	menuRouter.HandleFunc("/items/{id:[0-9]+}", c.DeleteMenuItem).Methods("DELETE")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/image", c.UploadMenuItemImage).Methods("POST")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/recipe", c.GetRecipe).Methods("GET")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/recipe", c.SetRecipe).Methods("PUT")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/prices", c.GetPriceHistory).Methods("GET")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/prices", c.SchedulePriceChange).Methods("POST")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/prices/{priceId:[0-9]+}", c.CancelPriceChange).Methods("DELETE")
+	menuRouter.HandleFunc("/prices/bulk", c.BulkChangePrices).Methods("POST")
 	menuRouter.HandleFunc("/categories", c.GetCategories).Methods("GET")
 	menuRouter.HandleFunc("/categories/{id:[0-9]+}", c.GetCategory).Methods("GET")
 	menuRouter.HandleFunc("/categories", c.CreateCategory).Methods("POST")
 	menuRouter.HandleFunc("/categories/{id:[0-9]+}", c.UpdateCategory).Methods("PUT")
 	menuRouter.HandleFunc("/categories/{id:[0-9]+}", c.DeleteCategory).Methods("DELETE")
+	menuRouter.HandleFunc("/layout", c.GetMenuLayout).Methods("GET")
+	menuRouter.HandleFunc("/layout", c.SetMenuLayout).Methods("PUT")
+	menuRouter.HandleFunc("/menus", c.GetMenus).Methods("GET")
+	menuRouter.HandleFunc("/menus/{id:[0-9]+}", c.GetMenu).Methods("GET")
+	menuRouter.HandleFunc("/menus", c.CreateMenu).Methods("POST")
+	menuRouter.HandleFunc("/menus/{id:[0-9]+}", c.UpdateMenu).Methods("PUT")
+	menuRouter.HandleFunc("/menus/{id:[0-9]+}", c.DeleteMenu).Methods("DELETE")
+	menuRouter.HandleFunc("/bundles", c.GetBundles).Methods("GET")
+	menuRouter.HandleFunc("/bundles/{id:[0-9]+}", c.GetBundle).Methods("GET")
+	menuRouter.HandleFunc("/bundles", c.CreateBundle).Methods("POST")
+	menuRouter.HandleFunc("/bundles/{id:[0-9]+}", c.UpdateBundle).Methods("PUT")
+	menuRouter.HandleFunc("/bundles/{id:[0-9]+}", c.DeleteBundle).Methods("DELETE")
+	menuRouter.HandleFunc("/preview", c.PreviewMenu).Methods("GET")
+	menuRouter.HandleFunc("/export", c.ExportMenu).Methods("GET")
+	menuRouter.HandleFunc("/import", c.ImportMenu).Methods("POST")
+	menuRouter.HandleFunc("/allergens", c.GetAllergenCatalogue).Methods("GET")
+	menuRouter.HandleFunc("/allergens/matrix", c.GetAllergenMatrix).Methods("GET")
+	menuRouter.HandleFunc("/allergens/translations", c.GetCatalogueTranslations).Methods("GET")
+	menuRouter.HandleFunc("/allergens/{code}/translations/{lang}", c.SetCatalogueTranslation).Methods("PUT")
+	menuRouter.HandleFunc("/allergens/{code}/translations/{lang}", c.DeleteCatalogueTranslation).Methods("DELETE")
+	menuRouter.HandleFunc("/translations", c.GetTranslations).Methods("GET")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/translations", c.GetMenuItemTranslations).Methods("GET")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/translations/{lang}", c.SetMenuItemTranslation).Methods("PUT")
+	menuRouter.HandleFunc("/items/{id:[0-9]+}/translations/{lang}", c.DeleteMenuItemTranslation).Methods("DELETE")
+	menuRouter.HandleFunc("/categories/{id:[0-9]+}/translations", c.GetCategoryTranslations).Methods("GET")
+	menuRouter.HandleFunc("/categories/{id:[0-9]+}/translations/{lang}", c.SetCategoryTranslation).Methods("PUT")
+	menuRouter.HandleFunc("/categories/{id:[0-9]+}/translations/{lang}", c.DeleteCategoryTranslation).Methods("DELETE")
 	menuRouter.HandleFunc("", c.GetMenuSummary).Methods("GET")
 }
 
@@ -43,19 +79,29 @@ func (c *MenuController) GetMenuItems(w http.ResponseWriter, r *http.Request) {
 	}
 
 	categoryIDStr := r.URL.Query().Get("category_id")
-	var categoryID *int = nil
+	var filter menu.MenuItemFilter
 	if categoryIDStr != "" {
 		id, err := strconv.Atoi(categoryIDStr)
 		if err != nil || id <= 0 {
 			http.Error(w, "Invalid category_id query parameter", http.StatusBadRequest)
 			return
 		}
-		categoryID = &id
+		filter.CategoryID = &id
 	}
+	filter.ExcludeAllergens = splitQueryList(r.URL.Query().Get("exclude_allergens"))
+	filter.DietaryTags = splitQueryList(r.URL.Query().Get("dietary"))
+	filter.VisibleOnly = r.URL.Query().Get("visible") == "true"
 
-	items, err := c.menuService.GetMenuItems(r.Context(), categoryID, businessID)
+	items, err := c.menuService.GetMenuItems(r.Context(), filter, businessID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch err {
+		case menu.ErrUnknownAllergen:
+			http.Error(w, "Unknown allergen in exclude_allergens", http.StatusBadRequest)
+		case menu.ErrUnknownDietaryTag:
+			http.Error(w, "Unknown dietary tag in dietary", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 	json.NewEncoder(w).Encode(items)
@@ -103,6 +149,10 @@ func (c *MenuController) CreateMenuItem(w http.ResponseWriter, r *http.Request)
 		switch err {
 		case menu.ErrInvalidMenuData:
 			http.Error(w, "Invalid menu item data", http.StatusBadRequest)
+		case menu.ErrUnknownAllergen:
+			http.Error(w, "Unknown allergen", http.StatusBadRequest)
+		case menu.ErrUnknownDietaryTag:
+			http.Error(w, "Unknown dietary tag", http.StatusBadRequest)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -138,6 +188,10 @@ func (c *MenuController) UpdateMenuItem(w http.ResponseWriter, r *http.Request)
 			http.Error(w, "Menu item not found", http.StatusNotFound)
 		case menu.ErrInvalidMenuData:
 			http.Error(w, "Invalid menu item data", http.StatusBadRequest)
+		case menu.ErrUnknownAllergen:
+			http.Error(w, "Unknown allergen", http.StatusBadRequest)
+		case menu.ErrUnknownDietaryTag:
+			http.Error(w, "Unknown dietary tag", http.StatusBadRequest)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -229,6 +283,8 @@ func (c *MenuController) CreateCategory(w http.ResponseWriter, r *http.Request)
 		switch err {
 		case menu.ErrInvalidMenuData:
 			http.Error(w, "Invalid category data", http.StatusBadRequest)
+		case menu.ErrInvalidCategoryParent:
+			http.Error(w, "Invalid parent category", http.StatusBadRequest)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -263,6 +319,8 @@ func (c *MenuController) UpdateCategory(w http.ResponseWriter, r *http.Request)
 			http.Error(w, "Category not found", http.StatusNotFound)
 		case menu.ErrInvalidMenuData:
 			http.Error(w, "Invalid category data", http.StatusBadRequest)
+		case menu.ErrInvalidCategoryParent:
+			http.Error(w, "Invalid parent category", http.StatusBadRequest)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strings"
+)
+
+// allergenMatrixTemplate renders the allergen chart as a page meant to be printed and displayed
+var allergenMatrixTemplate = template.Must(template.New("allergens").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<title>{{.BusinessName}}: allergens</title>
+<style>
+  body { font-family: sans-serif; font-size: 11px; margin: 16px; }
+  h1 { font-size: 18px; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { border: 1px solid #999; padding: 3px 5px; }
+  th.allergen { writing-mode: vertical-rl; transform: rotate(180deg); white-space: nowrap; }
+  td.mark { text-align: center; font-weight: bold; }
+  tr.category td { background: #eee; font-weight: bold; }
+  @media print { @page { size: landscape; } body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>{{.BusinessName}}</h1>
+<table>
+<thead>
+<tr><th></th>{{range .Allergens}}<th class="allergen">{{.Name}}</th>{{end}}<th></th></tr>
+</thead>
+<tbody>
+{{- $columns := len .Allergens}}{{$category := "-"}}
+{{- range .Rows}}
+{{- if ne .Category $category}}{{$category = .Category}}
+<tr class="category"><td colspan="{{$columns}}">{{.Category}}</td><td></td><td></td></tr>
+{{- end}}
+<tr><td>{{.Name}}</td>{{range .Contains}}<td class="mark">{{if .}}&#x25CF;{{end}}</td>{{end}}<td>{{range $i, $tag := .DietaryTags}}{{if $i}}, {{end}}{{$tag}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+// GetAllergenCatalogue lists the canonical allergens and dietary tags with their names
+func (c *MenuController) GetAllergenCatalogue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"allergens":    menu.Allergens,
+		"dietary_tags": menu.DietaryTags,
+	})
+}
+
+// GetAllergenMatrix renders the allergen chart of the menu as ?format=html (default), csv or json
+func (c *MenuController) GetAllergenMatrix(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "csv" && format != "json" {
+		http.Error(w, "Unsupported format, expected html, csv or json", http.StatusBadRequest)
+		return
+	}
+
+	matrix, err := c.menuService.GetAllergenMatrix(r.Context(), businessID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(matrix)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="allergens.csv"`)
+		writeAllergenMatrixCSV(w, matrix)
+	default:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := allergenMatrixTemplate.Execute(w, matrix); err != nil {
+			log.Printf("Error rendering allergen matrix for business %d: %v", businessID, err)
+		}
+	}
+}
+
+func writeAllergenMatrixCSV(w http.ResponseWriter, matrix *menu.AllergenMatrix) {
+	writer := csv.NewWriter(w)
+
+	header := []string{"category", "dish"}
+	for _, allergen := range matrix.Allergens {
+		header = append(header, allergen.Name)
+	}
+	header = append(header, "dietary_tags")
+	writer.Write(header)
+
+	for _, row := range matrix.Rows {
+		record := []string{row.Category, row.Name}
+		for _, contains := range row.Contains {
+			if contains {
+				record = append(record, "x")
+			} else {
+				record = append(record, "")
+			}
+		}
+		record = append(record, strings.Join(row.DietaryTags, "|"))
+		writer.Write(record)
+	}
+	writer.Flush()
+}
+
+// splitQueryList splits a comma-separated query parameter, dropping empty entries
+func splitQueryList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
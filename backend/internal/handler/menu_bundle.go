@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *MenuController) GetBundles(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	bundles, err := c.menuService.GetBundles(r.Context(), businessID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundles)
+}
+
+func (c *MenuController) GetBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid bundle ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	b, err := c.menuService.GetBundleByID(r.Context(), id, businessID)
+	if err != nil {
+		writeBundleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(b)
+}
+
+func (c *MenuController) CreateBundle(w http.ResponseWriter, r *http.Request) {
+	var b menu.BundleCreate
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.menuService.CreateBundle(r.Context(), b, businessID)
+	if err != nil {
+		writeBundleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (c *MenuController) UpdateBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid bundle ID", http.StatusBadRequest)
+		return
+	}
+	var b menu.BundleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.menuService.UpdateBundle(r.Context(), id, b, businessID)
+	if err != nil {
+		writeBundleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *MenuController) DeleteBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid bundle ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.menuService.DeleteBundle(r.Context(), id, businessID); err != nil {
+		writeBundleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeBundleError(w http.ResponseWriter, err error) {
+	switch err {
+	case menu.ErrBundleNotFound:
+		http.Error(w, "Bundle not found", http.StatusNotFound)
+	case menu.ErrInvalidMenuData:
+		http.Error(w, "Invalid bundle data", http.StatusBadRequest)
+	case menu.ErrInvalidBundle:
+		http.Error(w, "Invalid bundle slots, each slot needs a name and a category or dishes", http.StatusBadRequest)
+	case menu.ErrCategoryNotFound, menu.ErrMenuItemNotFound:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
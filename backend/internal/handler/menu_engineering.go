@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+	"strings"
+)
+
+// GetMenuEngineering classifies the dishes sold between ?from and ?to (YYYY-MM-DD, inclusive)
+// as stars, plowhorses, puzzles and dogs. ?by=category classifies within each category
+// instead of across the whole menu; ?format=csv downloads the per-dish figures.
+func (c *MenuController) GetMenuEngineering(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	format := strings.ToLower(q.Get("format"))
+	if format == "" {
+		format = "json"
+	}
+	if format != "csv" && format != "json" {
+		http.Error(w, "Unsupported format, expected csv or json", http.StatusBadRequest)
+		return
+	}
+
+	query := menu.MenuEngineeringQuery{
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+		ByCategory: q.Get("by") == "category",
+	}
+	report, err := c.menuService.GetMenuEngineering(r.Context(), query, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrInvalidReportPeriod:
+			http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="menu-engineering.csv"`)
+		writeMenuEngineeringCSV(w, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeMenuEngineeringCSV(w http.ResponseWriter, report *menu.MenuEngineeringReport) {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"category", "dish", "price", "average_price", "prices_charged", "cost", "cost_source", "sold", "revenue",
+		"menu_mix", "popularity_index", "contribution_margin", "total_contribution_margin",
+		"food_cost_percent", "class",
+	})
+
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, dish := range report.Dishes {
+		cost, margin, totalMargin, foodCost := "", "", "", ""
+		if dish.Cost != nil {
+			cost = money(*dish.Cost)
+			margin = money(dish.ContributionMargin)
+			totalMargin = money(dish.TotalContributionMargin)
+			foodCost = money(dish.FoodCostPercent)
+		}
+		// e.g. "12.00x30|13.50x12": portions sold at each price
+		charged := make([]string, 0, len(dish.Prices))
+		for _, p := range dish.Prices {
+			charged = append(charged, money(p.Price)+"x"+strconv.Itoa(p.Sold))
+		}
+		writer.Write([]string{
+			dish.Category,
+			dish.Name,
+			money(dish.Price),
+			money(dish.AveragePrice),
+			strings.Join(charged, "|"),
+			cost,
+			dish.CostSource,
+			strconv.Itoa(dish.Sold),
+			money(dish.Revenue),
+			money(dish.MenuMix),
+			money(dish.PopularityIndex),
+			margin,
+			totalMargin,
+			foodCost,
+			dish.Class,
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// UploadMenuItemImage stores the "image" field of a multipart form as the dish photo
+func (c *MenuController) UploadMenuItemImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	part, err := imageUpload(r)
+	if err != nil {
+		if !writeImageUploadError(w, err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	defer part.Close()
+
+	updated, err := c.menuService.UploadMenuItemImage(r.Context(), id, businessID, part)
+	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
+		switch err {
+		case menu.ErrMenuItemNotFound:
+			http.Error(w, "Menu item not found", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
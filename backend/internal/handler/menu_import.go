@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxMenuImportSize caps the size of an uploaded menu file
+const maxMenuImportSize = 10 << 20
+
+// ExportMenu downloads all categories and dishes as ?format=csv or ?format=json (default)
+func (c *MenuController) ExportMenu(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	format := menu.ImportFormat(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		format = menu.ImportFormatJSON
+	}
+
+	if format != menu.ImportFormatCSV && format != menu.ImportFormatJSON {
+		http.Error(w, "Unsupported format, expected csv or json", http.StatusBadRequest)
+		return
+	}
+
+	contentType := "application/json"
+	if format == menu.ImportFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+
+	filename := fmt.Sprintf("menu-%s.%s", time.Now().Format("2006-01-02"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := c.menuService.ExportMenu(r.Context(), w, format, businessID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ImportMenu accepts a CSV or JSON menu either as the raw request body or as the
+// "file" field of a multipart form. Query parameters: format, mode=create|upsert, dry_run.
+func (c *MenuController) ImportMenu(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxMenuImportSize)
+
+	var body io.Reader = r.Body
+	filename := ""
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "Missing file field in multipart form", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+		filename = header.Filename
+	}
+
+	opts := menu.MenuImportOptions{
+		Format: detectMenuImportFormat(r, filename),
+		Mode:   menu.ImportMode(strings.ToLower(r.URL.Query().Get("mode"))),
+	}
+	if dryRun := r.URL.Query().Get("dry_run"); dryRun != "" {
+		parsed, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			http.Error(w, "Invalid dry_run query parameter", http.StatusBadRequest)
+			return
+		}
+		opts.DryRun = parsed
+	}
+
+	result, err := c.menuService.ImportMenu(r.Context(), body, opts, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrUnsupportedImportFormat:
+			http.Error(w, "Unsupported format, expected csv or json", http.StatusBadRequest)
+		case menu.ErrInvalidImportFile:
+			http.Error(w, "Could not parse menu file", http.StatusBadRequest)
+		case menu.ErrInvalidMenuData:
+			http.Error(w, "Invalid import mode, expected create or upsert", http.StatusBadRequest)
+		case menu.ErrMenuItemAlreadyExists:
+			http.Error(w, "Menu changed during import, please retry", http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+// detectMenuImportFormat picks the format from ?format, the file extension or the content type
+func detectMenuImportFormat(r *http.Request, filename string) menu.ImportFormat {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return menu.ImportFormat(strings.ToLower(format))
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return menu.ImportFormatCSV
+	case ".json":
+		return menu.ImportFormatJSON
+	}
+	if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+		return menu.ImportFormatCSV
+	}
+	return menu.ImportFormatJSON
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+)
+
+func (c *MenuController) GetMenuLayout(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	includeHidden := r.URL.Query().Get("include_hidden") == "true"
+
+	layout, err := c.menuService.GetMenuLayout(r.Context(), businessID, includeHidden)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(layout)
+}
+
+func (c *MenuController) SetMenuLayout(w http.ResponseWriter, r *http.Request) {
+	var layout menu.MenuLayout
+	if err := json.NewDecoder(r.Body).Decode(&layout); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.menuService.SetMenuLayout(r.Context(), layout, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrInvalidMenuLayout:
+			http.Error(w, "Layout must list every category once and only dishes of this business", http.StatusBadRequest)
+		case menu.ErrInvalidCategoryParent:
+			http.Error(w, "Invalid parent category", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetPriceHistory lists the applied and scheduled prices of a dish
+func (c *MenuController) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	history, err := c.menuService.GetPriceHistory(r.Context(), id, businessID)
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// SchedulePriceChange sets a new price for a dish, from effective_from or right away
+func (c *MenuController) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	var input menu.PriceChangeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	change, err := c.menuService.SchedulePriceChange(r.Context(), id, input, businessID)
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(change)
+}
+
+// CancelPriceChange removes a price change that has not taken effect yet
+func (c *MenuController) CancelPriceChange(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	changeID, err := strconv.Atoi(vars["priceId"])
+	if err != nil {
+		http.Error(w, "Invalid price change ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.menuService.CancelPriceChange(r.Context(), id, changeID, businessID); err != nil {
+		writePriceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BulkChangePrices changes the prices of a category or the whole menu.
+// ?dry_run=true previews the new prices without changing anything.
+func (c *MenuController) BulkChangePrices(w http.ResponseWriter, r *http.Request) {
+	var change menu.BulkPriceChange
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if dryRun := r.URL.Query().Get("dry_run"); dryRun != "" {
+		parsed, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			http.Error(w, "Invalid dry_run query parameter", http.StatusBadRequest)
+			return
+		}
+		change.DryRun = parsed
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.menuService.BulkChangePrices(r.Context(), change, businessID)
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func writePriceError(w http.ResponseWriter, err error) {
+	switch err {
+	case menu.ErrMenuItemNotFound:
+		http.Error(w, "Menu item not found", http.StatusNotFound)
+	case menu.ErrCategoryNotFound:
+		http.Error(w, "Category not found", http.StatusNotFound)
+	case menu.ErrPriceChangeNotFound:
+		http.Error(w, "Scheduled price change not found", http.StatusNotFound)
+	case menu.ErrInvalidPriceChange:
+		http.Error(w, "Invalid price change, expected a positive price (or one of percent and amount) and effective_from as YYYY-MM-DDTHH:MM or RFC 3339", http.StatusBadRequest)
+	case menu.ErrInvalidMenuData:
+		http.Error(w, "Invalid price change data", http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *MenuController) GetRecipe(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	ingredients, err := c.menuService.GetRecipe(r.Context(), id, businessID)
+	if err != nil {
+		writeRecipeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ingredients)
+}
+
+// SetRecipe replaces the ingredients of a dish with the JSON array in the body
+func (c *MenuController) SetRecipe(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	var ingredients []menu.RecipeIngredientInput
+	if err := json.NewDecoder(r.Body).Decode(&ingredients); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := c.menuService.SetRecipe(r.Context(), id, ingredients, businessID)
+	if err != nil {
+		writeRecipeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recipe)
+}
+
+func writeRecipeError(w http.ResponseWriter, err error) {
+	switch err {
+	case menu.ErrMenuItemNotFound:
+		http.Error(w, "Menu item not found", http.StatusNotFound)
+	case menu.ErrIngredientNotFound:
+		http.Error(w, "Ingredient not found in inventory", http.StatusBadRequest)
+	case menu.ErrInvalidMenuData:
+		http.Error(w, "Invalid recipe data", http.StatusBadRequest)
+	case menu.ErrIncompatibleUnit:
+		http.Error(w, "Ingredient unit does not convert to the inventory unit", http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
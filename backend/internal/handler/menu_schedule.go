@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *MenuController) GetMenus(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	menus, err := c.menuService.GetMenus(r.Context(), businessID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if menus == nil {
+		menus = []menu.Menu{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(menus)
+}
+
+func (c *MenuController) GetMenu(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	m, err := c.menuService.GetMenuByID(r.Context(), id, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrMenuNotFound:
+			http.Error(w, "Menu not found", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+func (c *MenuController) CreateMenu(w http.ResponseWriter, r *http.Request) {
+	var m menu.MenuCreate
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.menuService.CreateMenu(r.Context(), m, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrInvalidMenuData:
+			http.Error(w, "Invalid menu data", http.StatusBadRequest)
+		case menu.ErrInvalidMenuSchedule:
+			http.Error(w, "Invalid menu schedule", http.StatusBadRequest)
+		case menu.ErrCategoryNotFound, menu.ErrMenuItemNotFound:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (c *MenuController) UpdateMenu(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu ID", http.StatusBadRequest)
+		return
+	}
+	var m menu.MenuUpdate
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.menuService.UpdateMenu(r.Context(), id, m, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrMenuNotFound:
+			http.Error(w, "Menu not found", http.StatusNotFound)
+		case menu.ErrInvalidMenuData:
+			http.Error(w, "Invalid menu data", http.StatusBadRequest)
+		case menu.ErrInvalidMenuSchedule:
+			http.Error(w, "Invalid menu schedule", http.StatusBadRequest)
+		case menu.ErrCategoryNotFound, menu.ErrMenuItemNotFound:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *MenuController) DeleteMenu(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid menu ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.menuService.DeleteMenu(r.Context(), id, businessID); err != nil {
+		switch err {
+		case menu.ErrMenuNotFound:
+			http.Error(w, "Menu not found", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewMenu shows what is on offer at ?at=YYYY-MM-DDTHH:MM (business local time) or now
+func (c *MenuController) PreviewMenu(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := c.menuService.PreviewMenu(r.Context(), businessID, r.URL.Query().Get("at"))
+	if err != nil {
+		switch err {
+		case menu.ErrInvalidMenuSchedule:
+			http.Error(w, "Invalid at query parameter, expected YYYY-MM-DDTHH:MM", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"strconv"
+)
+
+func (c *MenuController) SearchMenuItems(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			http.Error(w, "Invalid limit query parameter", http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	items, err := c.menuService.SearchMenuItems(r.Context(), r.URL.Query().Get("q"), limit, businessID)
+	if err != nil {
+		switch err {
+		case menu.ErrInvalidSearchQuery:
+			http.Error(w, "Query parameter q must contain a word to search for", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
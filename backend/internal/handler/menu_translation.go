@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/middleware"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// withLanguage translates staff menu reads only when asked with an explicit ?lang parameter.
+// The browser's Accept-Language is ignored here: the edit forms load the same routes and
+// must get the base texts, or saving them would overwrite the base with a translation.
+func (c *MenuController) withLanguage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := strings.TrimSpace(r.URL.Query().Get("lang"))
+		businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+		if r.Method != http.MethodGet || lang == "" || !ok || businessID == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lang, defaultLang, err := c.menuService.ResolveLanguage(r.Context(), businessID, []string{lang})
+		if err != nil {
+			log.Printf("Error resolving menu language for business %d: %v", businessID, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Language", lang)
+		if lang != defaultLang {
+			r = r.WithContext(menu.WithLanguage(r.Context(), lang))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestedLanguages lists the ?lang parameter followed by the Accept-Language tags in
+// order of preference
+func requestedLanguages(r *http.Request) []string {
+	var langs []string
+	if lang := strings.TrimSpace(r.URL.Query().Get("lang")); lang != "" {
+		langs = append(langs, lang)
+	}
+
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var accepted []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			accepted = append(accepted, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].q > accepted[j].q })
+
+	for _, a := range accepted {
+		langs = append(langs, a.tag)
+	}
+	return langs
+}
+
+// GetTranslations lists all translations, optionally filtered with ?language=
+func (c *MenuController) GetTranslations(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := c.menuService.GetTranslations(r.Context(), r.URL.Query().Get("language"), businessID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translations)
+}
+
+func (c *MenuController) GetMenuItemTranslations(w http.ResponseWriter, r *http.Request) {
+	c.getEntityTranslations(w, r, menu.TranslationEntityDish)
+}
+
+func (c *MenuController) SetMenuItemTranslation(w http.ResponseWriter, r *http.Request) {
+	c.setTranslation(w, r, menu.TranslationEntityDish)
+}
+
+func (c *MenuController) DeleteMenuItemTranslation(w http.ResponseWriter, r *http.Request) {
+	c.deleteTranslation(w, r, menu.TranslationEntityDish)
+}
+
+func (c *MenuController) GetCategoryTranslations(w http.ResponseWriter, r *http.Request) {
+	c.getEntityTranslations(w, r, menu.TranslationEntityCategory)
+}
+
+func (c *MenuController) SetCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	c.setTranslation(w, r, menu.TranslationEntityCategory)
+}
+
+func (c *MenuController) DeleteCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	c.deleteTranslation(w, r, menu.TranslationEntityCategory)
+}
+
+func (c *MenuController) getEntityTranslations(w http.ResponseWriter, r *http.Request, entityType string) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := c.menuService.GetEntityTranslations(r.Context(), entityType, id, businessID)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translations)
+}
+
+func (c *MenuController) setTranslation(w http.ResponseWriter, r *http.Request, entityType string) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var input menu.TranslationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	translation, err := c.menuService.SetTranslation(r.Context(), entityType, id, vars["lang"], input, businessID)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+func (c *MenuController) deleteTranslation(w http.ResponseWriter, r *http.Request, entityType string) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.menuService.DeleteTranslation(r.Context(), entityType, id, vars["lang"], businessID); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCatalogueTranslations lists the allergen and dietary tag names set by the business,
+// optionally filtered with ?language=
+func (c *MenuController) GetCatalogueTranslations(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := c.menuService.GetCatalogueTranslations(r.Context(), r.URL.Query().Get("language"), businessID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translations)
+}
+
+// SetCatalogueTranslation names an allergen or dietary tag code in a business language
+func (c *MenuController) SetCatalogueTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var input menu.TranslationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	translation, err := c.menuService.SetCatalogueTranslation(r.Context(), vars["code"], vars["lang"], input, businessID)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+func (c *MenuController) DeleteCatalogueTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.menuService.DeleteCatalogueTranslation(r.Context(), vars["code"], vars["lang"], businessID); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTranslationError(w http.ResponseWriter, err error) {
+	switch err {
+	case menu.ErrMenuItemNotFound:
+		http.Error(w, "Menu item not found", http.StatusNotFound)
+	case menu.ErrCategoryNotFound:
+		http.Error(w, "Category not found", http.StatusNotFound)
+	case menu.ErrTranslationNotFound:
+		http.Error(w, "Translation not found", http.StatusNotFound)
+	case menu.ErrUnknownCatalogueEntry:
+		http.Error(w, "Unknown allergen or dietary tag", http.StatusNotFound)
+	case menu.ErrUnsupportedLanguage:
+		http.Error(w, "Language is not a supported non-default language of the business", http.StatusBadRequest)
+	case menu.ErrInvalidMenuData:
+		http.Error(w, "Invalid translation data", http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
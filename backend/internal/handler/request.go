@@ -141,3 +141,33 @@ func (c *RequestController) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// Approve turns a draft request, such as one created from reorder suggestions, into a pending one
+func (c *RequestController) Approve(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	businessID, exists := middleware.GetBusinessIDFromContext(r.Context())
+	if !exists {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	approvedRequest, err := c.requestService.Approve(r.Context(), id, businessID)
+	if err == request.ErrNotDraft {
+		http.Error(w, "Only draft requests can be approved", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("Error approving request %d: %v", id, err)
+		http.Error(w, "Request not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(approvedRequest)
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/reservation"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ReservationController handles table reservations and the reservation book
+type ReservationController struct {
+	reservationService reservation.Service
+}
+
+func NewReservationController(reservationService reservation.Service) *ReservationController {
+	return &ReservationController{reservationService: reservationService}
+}
+
+// RegisterRoutes registers the reservation routes used by hosts, waiters and managers
+func (c *ReservationController) RegisterRoutes(r *mux.Router) {
+	reservations := r.PathPrefix("/reservations").Subrouter()
+	reservations.HandleFunc("", c.GetBook).Methods("GET")
+	reservations.HandleFunc("", c.CreateReservation).Methods("POST")
+	reservations.HandleFunc("/{id:[0-9]+}", c.GetReservation).Methods("GET")
+	reservations.HandleFunc("/{id:[0-9]+}", c.UpdateReservation).Methods("PUT")
+	reservations.HandleFunc("/{id:[0-9]+}/status", c.UpdateStatus).Methods("PUT")
+	reservations.HandleFunc("/opening-hours", c.GetOpeningHours).Methods("GET")
+	reservations.HandleFunc("/opening-hours", c.SetOpeningHours).Methods("PUT")
+}
+
+// GetBook returns the reservation book of ?date=YYYY-MM-DD, today by default
+func (c *ReservationController) GetBook(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	book, err := c.reservationService.GetBook(r.Context(), r.URL.Query().Get("date"), businessID)
+	if err != nil {
+		switch err {
+		case reservation.ErrInvalidReservation:
+			http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		default:
+			writeReservationError(w, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(book)
+}
+
+func (c *ReservationController) GetReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	res, err := c.reservationService.GetReservation(r.Context(), id, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (c *ReservationController) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	var req reservation.ReservationCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.reservationService.CreateReservation(r.Context(), req, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (c *ReservationController) UpdateReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+	var req reservation.ReservationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.reservationService.UpdateReservation(r.Context(), id, req, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *ReservationController) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+	var req reservation.StatusUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	updated, err := c.reservationService.UpdateStatus(r.Context(), id, req, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *ReservationController) GetOpeningHours(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	hours, err := c.reservationService.GetOpeningHours(r.Context(), businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hours)
+}
+
+func (c *ReservationController) SetOpeningHours(w http.ResponseWriter, r *http.Request) {
+	var hours []reservation.OpeningHours
+	if err := json.NewDecoder(r.Body).Decode(&hours); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.reservationService.SetOpeningHours(r.Context(), hours, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func writeReservationError(w http.ResponseWriter, err error) {
+	switch err {
+	case reservation.ErrReservationNotFound:
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+	case reservation.ErrWaitlistEntryNotFound:
+		http.Error(w, "Waitlist entry not found", http.StatusNotFound)
+	case reservation.ErrInvalidWaitlistEntry:
+		http.Error(w, "Invalid waitlist entry: guest name, party size and tables to seat at are required", http.StatusBadRequest)
+	case reservation.ErrTableNotFound:
+		http.Error(w, "Table not found", http.StatusBadRequest)
+	case reservation.ErrInvalidReservation:
+		http.Error(w, "Invalid reservation: guest name, phone, party size and a future start time are required", http.StatusBadRequest)
+	case reservation.ErrNotEnoughSeats:
+		http.Error(w, "The assigned tables do not seat the whole party", http.StatusBadRequest)
+	case reservation.ErrOutsideOpeningHours:
+		http.Error(w, "The reservation is outside opening hours", http.StatusBadRequest)
+	case reservation.ErrInvalidOpeningHours:
+		http.Error(w, "Invalid opening hours: day_of_week 0-6 and HH:MM times are required", http.StatusBadRequest)
+	case reservation.ErrReservationConflict:
+		http.Error(w, "A table is already booked for that time", http.StatusConflict)
+	case reservation.ErrInvalidStatus:
+		http.Error(w, "Invalid reservation status change", http.StatusConflict)
+	default:
+		log.Printf("Error handling reservation: %v", err)
+		http.Error(w, "Failed to process reservation", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/reservation"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterWaitlistRoutes registers the walk-in waitlist routes
+func (c *ReservationController) RegisterWaitlistRoutes(r *mux.Router) {
+	waitlist := r.PathPrefix("/waitlist").Subrouter()
+	waitlist.HandleFunc("", c.GetWaitlist).Methods("GET")
+	waitlist.HandleFunc("", c.AddToWaitlist).Methods("POST")
+	waitlist.HandleFunc("/{id:[0-9]+}/notify", c.NotifyWaitlistEntry).Methods("POST")
+	waitlist.HandleFunc("/{id:[0-9]+}/seat", c.SeatWaitlistEntry).Methods("POST")
+	waitlist.HandleFunc("/{id:[0-9]+}/no-show", c.MarkWaitlistNoShow).Methods("POST")
+	waitlist.HandleFunc("/{id:[0-9]+}", c.RemoveFromWaitlist).Methods("DELETE")
+}
+
+func (c *ReservationController) GetWaitlist(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := c.reservationService.GetWaitlist(r.Context(), businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (c *ReservationController) AddToWaitlist(w http.ResponseWriter, r *http.Request) {
+	var req reservation.WaitlistCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := c.reservationService.AddToWaitlist(r.Context(), req, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (c *ReservationController) NotifyWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid waitlist entry ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := c.reservationService.NotifyWaitlistEntry(r.Context(), id, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (c *ReservationController) SeatWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid waitlist entry ID", http.StatusBadRequest)
+		return
+	}
+	var req reservation.SeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	entry, err := c.reservationService.SeatWaitlistEntry(r.Context(), id, req, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (c *ReservationController) MarkWaitlistNoShow(w http.ResponseWriter, r *http.Request) {
+	c.closeWaitlistEntry(w, r, reservation.WaitlistNoShow)
+}
+
+// RemoveFromWaitlist takes off a party that gave up waiting
+func (c *ReservationController) RemoveFromWaitlist(w http.ResponseWriter, r *http.Request) {
+	c.closeWaitlistEntry(w, r, reservation.WaitlistLeft)
+}
+
+func (c *ReservationController) closeWaitlistEntry(w http.ResponseWriter, r *http.Request, status string) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid waitlist entry ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := c.reservationService.CloseWaitlistEntry(r.Context(), id, status, businessID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/table"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// TableController handles table management and the floor plan
+type TableController struct {
+	tableService table.Service
+}
+
+func NewTableController(tableService table.Service) *TableController {
+	return &TableController{tableService: tableService}
+}
+
+// RegisterManagerRoutes registers the table and floor plan management routes
+func (c *TableController) RegisterManagerRoutes(r *mux.Router) {
+	r.HandleFunc("/tables", c.GetTables).Methods("GET")
+	r.HandleFunc("/tables", c.CreateTable).Methods("POST")
+	r.HandleFunc("/tables/{id:[0-9]+}", c.UpdateTable).Methods("PUT")
+	r.HandleFunc("/tables/{id:[0-9]+}", c.DeleteTable).Methods("DELETE")
+	r.HandleFunc("/zones", c.GetZones).Methods("GET")
+	r.HandleFunc("/zones", c.CreateZone).Methods("POST")
+	r.HandleFunc("/zones/{id:[0-9]+}", c.UpdateZone).Methods("PUT")
+	r.HandleFunc("/zones/{id:[0-9]+}", c.DeleteZone).Methods("DELETE")
+	r.HandleFunc("/floor-plan", c.GetFloorPlan).Methods("GET")
+	r.HandleFunc("/tables/history", c.GetStatusHistory).Methods("GET")
+	r.HandleFunc("/tables/{id:[0-9]+}/history", c.GetStatusHistory).Methods("GET")
+	r.HandleFunc("/tables/turn-times", c.GetTurnTimeReport).Methods("GET")
+}
+
+func (c *TableController) GetTables(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	tables, err := c.tableService.GetTables(r.Context(), businessID)
+	if err != nil {
+		log.Printf("Error getting tables: %v", err)
+		http.Error(w, "Failed to fetch tables", http.StatusInternalServerError)
+		return
+	}
+	if tables == nil {
+		tables = []table.Table{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tables)
+}
+
+func (c *TableController) CreateTable(w http.ResponseWriter, r *http.Request) {
+	var t table.TableCreate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.tableService.CreateTable(r.Context(), t, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (c *TableController) UpdateTable(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid table ID", http.StatusBadRequest)
+		return
+	}
+	var t table.TableUpdate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.tableService.UpdateTable(r.Context(), id, t, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *TableController) DeleteTable(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid table ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.tableService.DeleteTable(r.Context(), id, businessID); err != nil {
+		writeTableError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *TableController) GetZones(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	zones, err := c.tableService.GetZones(r.Context(), businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(zones)
+}
+
+func (c *TableController) CreateZone(w http.ResponseWriter, r *http.Request) {
+	var z table.ZoneCreate
+	if err := json.NewDecoder(r.Body).Decode(&z); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.tableService.CreateZone(r.Context(), z, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (c *TableController) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid zone ID", http.StatusBadRequest)
+		return
+	}
+	var z table.ZoneUpdate
+	if err := json.NewDecoder(r.Body).Decode(&z); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.tableService.UpdateZone(r.Context(), id, z, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (c *TableController) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid zone ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.tableService.DeleteZone(r.Context(), id, businessID); err != nil {
+		writeTableError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFloorPlan exports the whole floor plan as JSON for the waiter screen to draw
+func (c *TableController) GetFloorPlan(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := c.tableService.GetFloorPlan(r.Context(), businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
+
+func writeTableError(w http.ResponseWriter, err error) {
+	switch err {
+	case table.ErrTableNotFound:
+		http.Error(w, "Table not found", http.StatusNotFound)
+	case table.ErrZoneNotFound:
+		http.Error(w, "Zone not found", http.StatusNotFound)
+	case table.ErrTableHasActiveOrders:
+		http.Error(w, "Table has active orders", http.StatusConflict)
+	case table.ErrTableNumberTaken:
+		http.Error(w, "Another table already has this number", http.StatusConflict)
+	case table.ErrInvalidTableData:
+		http.Error(w, "Invalid table data", http.StatusBadRequest)
+	case table.ErrInvalidZoneData:
+		http.Error(w, "Invalid zone data", http.StatusBadRequest)
+	case table.ErrInvalidReportPeriod:
+		http.Error(w, "Invalid from/to query parameters, expected YYYY-MM-DD with from not after to", http.StatusBadRequest)
+	default:
+		log.Printf("Error managing tables: %v", err)
+		http.Error(w, "Failed to process table request", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"restaurant-management/internal/domain/table"
+	"restaurant-management/internal/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetStatusHistory lists table status changes, newest first (?from=&to=&limit=).
+// Under /tables/{id}/history only that table is listed.
+func (c *TableController) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := table.StatusHistoryQuery{From: q.Get("from"), To: q.Get("to")}
+	if id, ok := mux.Vars(r)["id"]; ok {
+		tableID, err := strconv.Atoi(id)
+		if err != nil {
+			http.Error(w, "Invalid table ID", http.StatusBadRequest)
+			return
+		}
+		query.TableID = tableID
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	history, err := c.tableService.GetStatusHistory(r.Context(), query, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// GetTurnTimeReport reports turn times, dwell times, utilisation and revenue per seat-hour (?from=&to=)
+func (c *TableController) GetTurnTimeReport(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	report, err := c.tableService.GetTurnTimeReport(r.Context(), table.TurnTimeQuery{From: q.Get("from"), To: q.Get("to")}, businessID)
+	if err != nil {
+		writeTableError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
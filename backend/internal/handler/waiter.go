@@ -2,6 +2,8 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"restaurant-management/internal/domain/order"
@@ -30,6 +32,8 @@ func NewWaiterController(orderService order.Service, tableService table.Service,
 	}
 }
 
+// GetTables lists the tables in the waiter's current section, or the whole
+// floor when they have no section or ask for ?all=true
 func (c *WaiterController) GetTables(w http.ResponseWriter, r *http.Request) {
 	businessID, exists := middleware.GetBusinessIDFromContext(r.Context())
 	if !exists {
@@ -44,6 +48,20 @@ func (c *WaiterController) GetTables(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sectionOnly := false
+	if userID, ok := middleware.GetUserIDFromContext(r.Context()); ok && r.URL.Query().Get("all") != "true" {
+		if section := c.sectionTableIDs(r, userID, businessID); section != nil {
+			sectionOnly = true
+			mine := make([]table.Table, 0, len(section))
+			for _, t := range tables {
+				if section[t.ID] {
+					mine = append(mine, t)
+				}
+			}
+			tables = mine
+		}
+	}
+
 	stats, err := c.tableService.GetTableStats(r.Context(), businessID)
 	if err != nil {
 		log.Printf("Error getting table stats: %v", err)
@@ -52,11 +70,13 @@ func (c *WaiterController) GetTables(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := struct {
-		Tables []table.Table     `json:"tables"`
-		Stats  *table.TableStats `json:"stats"`
+		Tables      []table.Table     `json:"tables"`
+		Stats       *table.TableStats `json:"stats"`
+		SectionOnly bool              `json:"section_only"`
 	}{
-		Tables: tables,
-		Stats:  stats,
+		Tables:      tables,
+		Stats:       stats,
+		SectionOnly: sectionOnly,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -82,6 +102,7 @@ func (c *WaiterController) UpdateTableStatus(w http.ResponseWriter, r *http.Requ
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	statusUpdate.ChangedBy, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.tableService.UpdateTableStatus(r.Context(), tableID, statusUpdate, businessID); err != nil {
 		log.Printf("Error updating table status: %v", err)
@@ -169,10 +190,34 @@ func (c *WaiterController) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	createdOrder, err := c.orderService.CreateOrder(r.Context(), orderRequest, userID, businessID)
 	if err != nil {
 		log.Printf("Error creating order: %v", err)
-		http.Error(w, "Failed to create order", http.StatusInternalServerError)
+		var conflict *order.AllergenConflictError
+		if errors.As(err, &conflict) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":            conflict.Error(),
+				"allergy_warnings": conflict.Warnings,
+			})
+			return
+		}
+		switch err {
+		case order.ErrDishNotOnMenu, order.ErrDishNotAvailable, order.ErrUnknownAllergy, order.ErrInvalidOrderData, order.ErrInvalidTableID:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case order.ErrBundleNotFound, order.ErrBundleNotAvailable, order.ErrInvalidBundleChoice:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to create order", http.StatusInternalServerError)
+		}
 		return
 	}
 
+	owner, err := c.waiterService.CheckTableSection(r.Context(), createdOrder.TableID, userID, businessID)
+	if err != nil {
+		log.Printf("Warning: could not check section of table %d: %v", createdOrder.TableID, err)
+	} else if owner != nil {
+		createdOrder.SectionWarning = fmt.Sprintf("Table is in %s's section", owner.WaiterName)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(createdOrder)
@@ -197,6 +242,7 @@ func (c *WaiterController) UpdateOrderStatus(w http.ResponseWriter, r *http.Requ
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	statusUpdate.WaiterID, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.orderService.UpdateOrderStatus(r.Context(), orderID, statusUpdate, businessID); err != nil {
 		log.Printf("Error updating order status: %v", err)
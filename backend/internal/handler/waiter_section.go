@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"restaurant-management/internal/domain/waiter"
+	"restaurant-management/internal/middleware"
+	"strconv"
+)
+
+// GetSections lists the waiter sections of a shift (?shift_id=)
+func (c *WaiterController) GetSections(w http.ResponseWriter, r *http.Request) {
+	shiftID, err := strconv.Atoi(r.URL.Query().Get("shift_id"))
+	if err != nil {
+		http.Error(w, "Invalid shift ID", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	sections, err := c.waiterService.GetSections(r.Context(), shiftID, businessID)
+	if err != nil {
+		writeSectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sections)
+}
+
+// SetSection replaces the tables and zones of one waiter for a shift
+func (c *WaiterController) SetSection(w http.ResponseWriter, r *http.Request) {
+	var section waiter.Section
+	if err := json.NewDecoder(r.Body).Decode(&section); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := c.waiterService.SetSection(r.Context(), section, businessID)
+	if err != nil {
+		writeSectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// Handoff moves one waiter's section and open orders to a colleague
+func (c *WaiterController) Handoff(w http.ResponseWriter, r *http.Request) {
+	var req waiter.HandoffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	c.handoff(w, r, req)
+}
+
+// HandoffOwn lets the signed-in waiter pass their section to a colleague ({"to_waiter_id"})
+func (c *WaiterController) HandoffOwn(w http.ResponseWriter, r *http.Request) {
+	var req waiter.HandoffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "user_id not found in context", http.StatusBadRequest)
+		return
+	}
+	req.FromWaiterID = userID
+	c.handoff(w, r, req)
+}
+
+func (c *WaiterController) handoff(w http.ResponseWriter, r *http.Request, req waiter.HandoffRequest) {
+	businessID, ok := middleware.GetBusinessIDFromContext(r.Context())
+	if !ok || businessID == 0 {
+		http.Error(w, "business_id not found in context", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.waiterService.Handoff(r.Context(), req, businessID)
+	if err != nil {
+		writeSectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// sectionTableIDs returns the tables in the waiter's current section,
+// or nil when the waiter has no section and should see the whole floor
+func (c *WaiterController) sectionTableIDs(r *http.Request, waiterID, businessID int) map[int]bool {
+	assigned, err := c.waiterService.GetTablesAssignedToWaiter(r.Context(), waiterID, businessID)
+	if err != nil {
+		log.Printf("Error getting section of waiter %d: %v", waiterID, err)
+		return nil
+	}
+	if len(assigned) == 0 {
+		return nil
+	}
+
+	ids := make(map[int]bool, len(assigned))
+	for _, t := range assigned {
+		ids[t.ID] = true
+	}
+	return ids
+}
+
+func writeSectionError(w http.ResponseWriter, err error) {
+	switch err {
+	case waiter.ErrInvalidSection:
+		http.Error(w, "Invalid section", http.StatusBadRequest)
+	case waiter.ErrWaiterNotOnShift:
+		http.Error(w, "Waiter is not on this shift", http.StatusBadRequest)
+	case waiter.ErrSectionConflict:
+		http.Error(w, "Table or zone already assigned to another waiter", http.StatusConflict)
+	case waiter.ErrInvalidHandoff:
+		http.Error(w, "Invalid handoff", http.StatusBadRequest)
+	default:
+		log.Printf("Error managing waiter sections: %v", err)
+		http.Error(w, "Failed to process section request", http.StatusInternalServerError)
+	}
+}
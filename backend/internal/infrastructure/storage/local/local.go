@@ -0,0 +1,96 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"restaurant-management/configs"
+	"restaurant-management/internal/domain/media"
+	"strings"
+)
+
+// Storage keeps uploaded files on the local filesystem. The directory is served by the
+// HTTP server under the configured public URL prefix.
+type Storage struct {
+	root    string
+	baseURL string
+}
+
+func NewStorage(config *configs.StorageConfig) (media.Storage, error) {
+	root, err := filepath.Abs(config.LocalDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving upload directory: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("creating upload directory: %w", err)
+	}
+	return &Storage{root: root, baseURL: strings.TrimSuffix(config.PublicURL, "/")}, nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, contentType string, body io.Reader, size int64) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial upload
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *Storage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *Storage) Key(url string) (string, bool) {
+	if !strings.HasPrefix(url, s.baseURL+"/") {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, s.baseURL+"/")
+	if _, err := s.path(key); err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it
+func (s *Storage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"restaurant-management/internal/domain/business"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type BusinessRepository struct {
@@ -19,8 +21,9 @@ func NewBusinessRepository(db *DB) business.Repository {
 // CreateBusiness creates a new business in the database
 func (r *BusinessRepository) CreateBusiness(ctx context.Context, b *business.Business) error {
 	query := `
-		INSERT INTO businesses (name, description, address, phone, email, website, logo, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		INSERT INTO businesses (name, description, address, phone, email, website, logo, timezone,
+		                        default_language, supported_languages, allergen_policy, table_auto_free_minutes, costing_method, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $14, $15, $12, $13, $13)
 		RETURNING id, created_at, updated_at`
 
 	now := time.Now()
@@ -32,6 +35,7 @@ func (r *BusinessRepository) CreateBusiness(ctx context.Context, b *business.Bus
 	email := sql.NullString{String: b.Email, Valid: b.Email != ""}
 	website := sql.NullString{String: b.Website, Valid: b.Website != ""}
 	logo := sql.NullString{String: b.Logo, Valid: b.Logo != ""}
+	timezone := sql.NullString{String: b.Timezone, Valid: b.Timezone != ""}
 
 	err := r.db.QueryRowContext(ctx, query,
 		b.Name,
@@ -41,8 +45,14 @@ func (r *BusinessRepository) CreateBusiness(ctx context.Context, b *business.Bus
 		email,
 		website,
 		logo,
+		timezone,
+		b.DefaultLanguage,
+		pq.Array(b.SupportedLanguages),
+		b.AllergenPolicy,
 		b.Status,
 		now,
+		autoFreeMinutes(b),
+		b.CostingMethod,
 	).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
 
 	if err != nil {
@@ -56,14 +66,15 @@ func (r *BusinessRepository) CreateBusiness(ctx context.Context, b *business.Bus
 // GetBusinessByID retrieves a business by its ID
 func (r *BusinessRepository) GetBusinessByID(ctx context.Context, id int) (*business.Business, error) {
 	query := `
-		SELECT id, name, description, address, phone, email, website, logo, status, created_at, updated_at
+		SELECT id, name, description, address, phone, email, website, logo, timezone,
+		       default_language, supported_languages, COALESCE(allergen_policy, ''), table_auto_free_minutes, COALESCE(costing_method, ''), status, created_at, updated_at
 		FROM businesses
 		WHERE id = $1`
 
 	b := &business.Business{}
 
 	// Use NullString for potentially NULL string columns
-	var description, address, phone, email, website, logo sql.NullString
+	var description, address, phone, email, website, logo, timezone, defaultLanguage sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&b.ID,
@@ -74,6 +85,12 @@ func (r *BusinessRepository) GetBusinessByID(ctx context.Context, id int) (*busi
 		&email,
 		&website,
 		&logo,
+		&timezone,
+		&defaultLanguage,
+		pq.Array(&b.SupportedLanguages),
+		&b.AllergenPolicy,
+		&b.TableAutoFreeMinutes,
+		&b.CostingMethod,
 		&b.Status,
 		&b.CreatedAt,
 		&b.UpdatedAt,
@@ -103,6 +120,12 @@ func (r *BusinessRepository) GetBusinessByID(ctx context.Context, id int) (*busi
 	if logo.Valid {
 		b.Logo = logo.String
 	}
+	if timezone.Valid {
+		b.Timezone = timezone.String
+	}
+	if defaultLanguage.Valid {
+		b.DefaultLanguage = defaultLanguage.String
+	}
 
 	return b, nil
 }
@@ -110,7 +133,8 @@ func (r *BusinessRepository) GetBusinessByID(ctx context.Context, id int) (*busi
 // GetAllBusinesses retrieves all businesses from the database
 func (r *BusinessRepository) GetAllBusinesses(ctx context.Context) ([]business.Business, error) {
 	query := `
-		SELECT id, name, description, address, phone, email, website, logo, status, created_at, updated_at
+		SELECT id, name, description, address, phone, email, website, logo, timezone,
+		       default_language, supported_languages, COALESCE(allergen_policy, ''), table_auto_free_minutes, COALESCE(costing_method, ''), status, created_at, updated_at
 		FROM businesses
 		ORDER BY name`
 
@@ -127,7 +151,7 @@ func (r *BusinessRepository) GetAllBusinesses(ctx context.Context) ([]business.B
 		var b business.Business
 
 		// Use NullString for potentially NULL string columns
-		var description, address, phone, email, website, logo sql.NullString
+		var description, address, phone, email, website, logo, timezone, defaultLanguage sql.NullString
 
 		err := rows.Scan(
 			&b.ID,
@@ -138,6 +162,12 @@ func (r *BusinessRepository) GetAllBusinesses(ctx context.Context) ([]business.B
 			&email,
 			&website,
 			&logo,
+			&timezone,
+			&defaultLanguage,
+			pq.Array(&b.SupportedLanguages),
+			&b.AllergenPolicy,
+			&b.TableAutoFreeMinutes,
+			&b.CostingMethod,
 			&b.Status,
 			&b.CreatedAt,
 			&b.UpdatedAt,
@@ -167,6 +197,12 @@ func (r *BusinessRepository) GetAllBusinesses(ctx context.Context) ([]business.B
 		if logo.Valid {
 			b.Logo = logo.String
 		}
+		if timezone.Valid {
+			b.Timezone = timezone.String
+		}
+		if defaultLanguage.Valid {
+			b.DefaultLanguage = defaultLanguage.String
+		}
 
 		businesses = append(businesses, b)
 	}
@@ -184,8 +220,10 @@ func (r *BusinessRepository) UpdateBusiness(ctx context.Context, b *business.Bus
 	query := `
 		UPDATE businesses
 		SET name = $1, description = $2, address = $3, phone = $4, 
-		    email = $5, website = $6, logo = $7, status = $8, updated_at = $9
-		WHERE id = $10`
+		    email = $5, website = $6, logo = $7, timezone = $8, default_language = $9,
+		    supported_languages = $10, allergen_policy = $11, status = $12, updated_at = $13,
+		    table_auto_free_minutes = $15, costing_method = $16
+		WHERE id = $14`
 
 	now := time.Now()
 
@@ -196,6 +234,7 @@ func (r *BusinessRepository) UpdateBusiness(ctx context.Context, b *business.Bus
 	email := sql.NullString{String: b.Email, Valid: b.Email != ""}
 	website := sql.NullString{String: b.Website, Valid: b.Website != ""}
 	logo := sql.NullString{String: b.Logo, Valid: b.Logo != ""}
+	timezone := sql.NullString{String: b.Timezone, Valid: b.Timezone != ""}
 
 	_, err := r.db.ExecContext(ctx, query,
 		b.Name,
@@ -205,9 +244,15 @@ func (r *BusinessRepository) UpdateBusiness(ctx context.Context, b *business.Bus
 		email,
 		website,
 		logo,
+		timezone,
+		b.DefaultLanguage,
+		pq.Array(b.SupportedLanguages),
+		b.AllergenPolicy,
 		b.Status,
 		now,
 		b.ID,
+		autoFreeMinutes(b),
+		b.CostingMethod,
 	)
 
 	if err != nil {
@@ -260,3 +305,11 @@ func (r *BusinessRepository) GetBusinessStats(ctx context.Context) (*business.Bu
 
 	return stats, nil
 }
+
+// autoFreeMinutes returns the table auto-free delay to store, 0 when switched off
+func autoFreeMinutes(b *business.Business) int {
+	if b.TableAutoFreeMinutes == nil {
+		return 0
+	}
+	return *b.TableAutoFreeMinutes
+}
@@ -7,6 +7,8 @@ import (
 	"log"
 	"restaurant-management/internal/domain/inventory"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type InventoryRepository struct {
@@ -19,7 +21,7 @@ func NewInventoryRepository(db *DB) inventory.Repository {
 
 func (r *InventoryRepository) GetAllInventory(ctx context.Context, businessID int) ([]inventory.Inventory, error) {
 	query := `
-		SELECT id, name, category, quantity, unit, min_quantity, business_id, created_at, updated_at
+		SELECT id, name, category, quantity, unit, min_quantity, COALESCE(min_unit, ''), par_quantity, allergens, unit_cost, business_id, created_at, updated_at
 		FROM inventory 
 		WHERE business_id = $1 OR business_id IS NULL
 		ORDER BY name ASC`
@@ -41,6 +43,10 @@ func (r *InventoryRepository) GetAllInventory(ctx context.Context, businessID in
 			&item.Quantity,
 			&item.Unit,
 			&item.MinQuantity,
+			&item.MinUnit,
+			&item.ParQuantity,
+			pq.Array(&item.Allergens),
+			&item.UnitCost,
 			&item.BusinessID,
 			&item.CreatedAt,
 			&item.UpdatedAt,
@@ -62,7 +68,7 @@ func (r *InventoryRepository) GetAllInventory(ctx context.Context, businessID in
 
 func (r *InventoryRepository) GetInventoryByID(ctx context.Context, id int, businessID int) (*inventory.Inventory, error) {
 	query := `
-		SELECT id, name, category, quantity, unit, min_quantity, business_id, created_at, updated_at
+		SELECT id, name, category, quantity, unit, min_quantity, COALESCE(min_unit, ''), par_quantity, allergens, unit_cost, business_id, created_at, updated_at
 		FROM inventory 
 		WHERE id = $1 AND (business_id = $2 OR business_id IS NULL)`
 
@@ -74,6 +80,10 @@ func (r *InventoryRepository) GetInventoryByID(ctx context.Context, id int, busi
 		&item.Quantity,
 		&item.Unit,
 		&item.MinQuantity,
+		&item.MinUnit,
+		&item.ParQuantity,
+		pq.Array(&item.Allergens),
+		&item.UnitCost,
 		&item.BusinessID,
 		&item.CreatedAt,
 		&item.UpdatedAt,
@@ -92,8 +102,8 @@ func (r *InventoryRepository) GetInventoryByID(ctx context.Context, id int, busi
 
 func (r *InventoryRepository) CreateInventory(ctx context.Context, item *inventory.Inventory) error {
 	query := `
-		INSERT INTO inventory (name, category, quantity, unit, min_quantity, business_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO inventory (name, category, quantity, unit, min_quantity, min_unit, par_quantity, allergens, unit_cost, business_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at`
 
 	now := time.Now()
@@ -106,6 +116,10 @@ func (r *InventoryRepository) CreateInventory(ctx context.Context, item *invento
 		item.Quantity,
 		item.Unit,
 		item.MinQuantity,
+		item.MinUnit,
+		item.ParQuantity,
+		pq.Array(item.Allergens),
+		item.UnitCost,
 		item.BusinessID,
 		item.CreatedAt,
 		item.UpdatedAt,
@@ -119,41 +133,81 @@ func (r *InventoryRepository) CreateInventory(ctx context.Context, item *invento
 	return nil
 }
 
-func (r *InventoryRepository) UpdateInventory(ctx context.Context, item *inventory.Inventory) error {
+// UpdateInventory saves an item, rescales its stock after a unit change and replaces its
+// conversions in a single transaction
+func (r *InventoryRepository) UpdateInventory(ctx context.Context, item *inventory.Inventory, factor float64, conversions []inventory.Conversion, adjustment *inventory.Movement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE inventory 
-		SET name = $1, category = $2, quantity = $3, unit = $4, min_quantity = $5, updated_at = $6
-		WHERE id = $7 AND (business_id = $8 OR business_id IS NULL)`
+		SET name = $1, category = $2, unit = $3, min_quantity = $4, min_unit = NULLIF($5, ''), par_quantity = $6, allergens = $7, unit_cost = $8, updated_at = $9
+		WHERE id = $10 AND (business_id = $11 OR business_id IS NULL)`
 
 	item.UpdatedAt = time.Now()
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		item.Name,
 		item.Category,
-		item.Quantity,
 		item.Unit,
 		item.MinQuantity,
+		item.MinUnit,
+		item.ParQuantity,
+		pq.Array(item.Allergens),
+		item.UnitCost,
 		item.UpdatedAt,
 		item.ID,
 		item.BusinessID,
 	)
 
 	if err != nil {
+		tx.Rollback()
 		log.Printf("Error updating inventory item ID %d: %v", item.ID, err)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		log.Printf("Error getting affected rows for inventory item %d: %v", item.ID, err)
 		return err
 	}
 
 	if rowsAffected == 0 {
+		tx.Rollback()
 		return fmt.Errorf("inventory item with ID %d not found", item.ID)
 	}
 
-	return nil
+	if factor != 1 {
+		if err := rescaleStock(ctx, tx, item.ID, factor, item.BusinessID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if conversions != nil {
+		if err := setConversions(ctx, tx, item.ID, conversions, item.BusinessID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if item.Suppliers != nil {
+		if err := setItemSuppliers(ctx, tx, item.ID, item.Suppliers, item.BusinessID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if adjustment != nil {
+		recorded, err := recordMovementsTx(ctx, tx, []inventory.Movement{*adjustment}, false)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		item.Quantity = recorded[0].BalanceAfter
+	}
+
+	return tx.Commit()
 }
 
 func (r *InventoryRepository) DeleteInventory(ctx context.Context, id int, businessID int) error {
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+	"time"
+)
+
+// GetCostLayers sums the FIFO layers still holding stock by item
+func (r *InventoryRepository) GetCostLayers(ctx context.Context, businessID int) ([]inventory.CostLayerTotal, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.inventory_id, SUM(l.remaining),
+			COALESCE(SUM(l.remaining * l.unit_cost), 0),
+			COALESCE(SUM(l.remaining) FILTER (WHERE l.unit_cost IS NULL), 0)
+		FROM inventory_cost_layers l
+		JOIN inventory i ON i.id = l.inventory_id
+		WHERE (i.business_id = $1 OR i.business_id IS NULL) AND l.remaining > 0
+		GROUP BY l.inventory_id`,
+		businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying cost layers: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []inventory.CostLayerTotal
+	for rows.Next() {
+		var t inventory.CostLayerTotal
+		if err := rows.Scan(&t.InventoryID, &t.Remaining, &t.Value, &t.Uncosted); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// GetCostLines sums the consumption and waste movements recorded in [from, to) by item,
+// unit and type. Movements from before costing have neither cost and count as uncosted.
+func (r *InventoryRepository) GetCostLines(ctx context.Context, from, to time.Time, businessID int) ([]inventory.CostLine, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.inventory_id, i.name, COALESCE(m.unit, ''), m.type, SUM(-m.quantity),
+			COALESCE(SUM(-m.quantity * m.unit_cost), 0),
+			COALESCE(SUM(-m.quantity * m.fifo_cost), 0),
+			BOOL_OR(m.unit_cost IS NULL), BOOL_OR(m.fifo_cost IS NULL)
+		FROM inventory_movements m
+		JOIN inventory i ON i.id = m.inventory_id
+		WHERE m.business_id = $1 AND m.type IN ('consumption', 'waste')
+		  AND m.created_at >= $2 AND m.created_at < $3
+		GROUP BY m.inventory_id, i.name, m.unit, m.type
+		ORDER BY i.name, m.inventory_id`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying cost lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []inventory.CostLine
+	for rows.Next() {
+		var l inventory.CostLine
+		if err := rows.Scan(&l.InventoryID, &l.Name, &l.Unit, &l.Type, &l.Quantity,
+			&l.AverageValue, &l.FIFOValue, &l.Uncosted, &l.FIFOUncosted); err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	return lines, rows.Err()
+}
+
+// GetSalesTotal sums the completed orders created in [from, to)
+func (r *InventoryRepository) GetSalesTotal(ctx context.Context, from, to time.Time, businessID int) (float64, error) {
+	var total float64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(total_amount), 0)
+		FROM orders
+		WHERE business_id = $1 AND status = 'completed'
+		  AND created_at >= $2 AND created_at < $3`,
+		businessID, from, to).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("summing sales: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+
+	"github.com/lib/pq"
+)
+
+const lotColumns = `l.id, l.inventory_id, l.business_id, i.name, COALESCE(i.unit, ''), COALESCE(l.lot_number, ''),
+	l.supplier_id, COALESCE(s.name, ''), l.quantity, l.remaining, COALESCE(TO_CHAR(l.expires_on, 'YYYY-MM-DD'), ''),
+	l.received_at`
+
+const lotJoins = `
+	FROM inventory_lots l
+	JOIN inventory i ON i.id = l.inventory_id
+	LEFT JOIN suppliers s ON s.id = l.supplier_id`
+
+func scanLot(row rowScanner, l *inventory.Lot) error {
+	return row.Scan(
+		&l.ID,
+		&l.InventoryID,
+		&l.BusinessID,
+		&l.Name,
+		&l.Unit,
+		&l.LotNumber,
+		&l.SupplierID,
+		&l.SupplierName,
+		&l.Quantity,
+		&l.Remaining,
+		&l.ExpiresOn,
+		&l.ReceivedAt,
+	)
+}
+
+// GetLots lists the lots of a business, first expiring first
+func (r *InventoryRepository) GetLots(ctx context.Context, filter inventory.LotFilter, businessID int) ([]inventory.Lot, error) {
+	query := `SELECT ` + lotColumns + lotJoins + `
+		WHERE l.business_id = $1`
+	args := []interface{}{businessID}
+	if filter.InventoryID > 0 {
+		args = append(args, filter.InventoryID)
+		query += fmt.Sprintf(" AND l.inventory_id = $%d", len(args))
+	}
+	if filter.Open {
+		query += " AND l.remaining > 0"
+	}
+	if filter.ExpiresBefore != "" {
+		args = append(args, filter.ExpiresBefore)
+		query += fmt.Sprintf(" AND l.expires_on < $%d::date", len(args))
+	}
+	if filter.Unalerted {
+		query += " AND l.expiry_alerted_at IS NULL"
+	}
+	query += " ORDER BY l.expires_on NULLS LAST, i.name, l.id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []inventory.Lot
+	for rows.Next() {
+		var l inventory.Lot
+		if err := scanLot(rows, &l); err != nil {
+			return nil, err
+		}
+		lots = append(lots, l)
+	}
+	return lots, rows.Err()
+}
+
+func (r *InventoryRepository) GetLot(ctx context.Context, id int, businessID int) (*inventory.Lot, error) {
+	var l inventory.Lot
+	err := scanLot(r.db.QueryRowContext(ctx, `SELECT `+lotColumns+lotJoins+`
+		WHERE l.id = $1 AND l.business_id = $2`,
+		id, businessID), &l)
+	if err == sql.ErrNoRows {
+		return nil, inventory.ErrLotNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting lot %d: %w", id, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.id, m.type, d.quantity, COALESCE(m.reason, ''), m.created_at
+		FROM inventory_lot_draws d
+		JOIN inventory_movements m ON m.id = d.movement_id
+		WHERE d.lot_id = $1
+		ORDER BY m.id`,
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("querying draws of lot %d: %w", id, err)
+	}
+	defer rows.Close()
+
+	l.Draws = []inventory.LotDraw{}
+	for rows.Next() {
+		var d inventory.LotDraw
+		if err := rows.Scan(&d.MovementID, &d.Type, &d.Quantity, &d.Reason, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		l.Draws = append(l.Draws, d)
+	}
+	return &l, rows.Err()
+}
+
+func (r *InventoryRepository) MarkExpiryAlerted(ctx context.Context, lotIDs []int, businessID int) error {
+	if len(lotIDs) == 0 {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE inventory_lots SET expiry_alerted_at = NOW()
+		WHERE id = ANY($1) AND business_id = $2`,
+		pq.Array(lotIDs), businessID)
+	if err != nil {
+		return fmt.Errorf("marking expiry alerts: %w", err)
+	}
+	return nil
+}
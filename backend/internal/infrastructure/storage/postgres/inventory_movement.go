@@ -0,0 +1,457 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"sort"
+
+	"github.com/lib/pq"
+)
+
+const movementColumns = `m.id, m.inventory_id, m.business_id, m.type, m.quantity, m.balance_after, COALESCE(m.unit, ''), m.fifo_cost,
+	COALESCE(m.user_id, 0), COALESCE(u.name, u.username, ''), COALESCE(m.reason, ''),
+	m.order_id, m.request_id, m.transfer_item_id, m.waste_id, m.unit_cost, m.lot_id, m.created_at`
+
+func scanMovement(row rowScanner, m *inventory.Movement) error {
+	return row.Scan(
+		&m.ID,
+		&m.InventoryID,
+		&m.BusinessID,
+		&m.Type,
+		&m.Quantity,
+		&m.BalanceAfter,
+		&m.Unit,
+		&m.FIFOCost,
+		&m.UserID,
+		&m.UserName,
+		&m.Reason,
+		&m.OrderID,
+		&m.RequestID,
+		&m.TransferItemID,
+		&m.WasteID,
+		&m.UnitCost,
+		&m.LotID,
+		&m.CreatedAt,
+	)
+}
+
+// RecordMovements appends the movements to the ledger and keeps inventory.quantity,
+// the weighted average unit_cost, the FIFO cost layers and the lots in sync. The items
+// are locked in ID order so concurrent batches cannot deadlock.
+func (r *InventoryRepository) RecordMovements(ctx context.Context, movements []inventory.Movement, allowNegative bool) ([]inventory.Movement, error) {
+	if len(movements) == 0 {
+		return []inventory.Movement{}, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := recordMovementsTx(ctx, tx, movements, allowNegative)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// recordMovementsTx does the work of RecordMovements inside the caller's transaction
+func recordMovementsTx(ctx context.Context, tx *sql.Tx, movements []inventory.Movement, allowNegative bool) ([]inventory.Movement, error) {
+	if len(movements) == 0 {
+		return []inventory.Movement{}, nil
+	}
+	businessID := movements[0].BusinessID
+
+	ids := make([]int, 0, len(movements))
+	seen := make(map[int]bool, len(movements))
+	for _, m := range movements {
+		if !seen[m.InventoryID] {
+			seen[m.InventoryID] = true
+			ids = append(ids, m.InventoryID)
+		}
+	}
+	sort.Ints(ids)
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, quantity, COALESCE(unit, ''), unit_cost FROM inventory
+		WHERE id = ANY($1) AND (business_id = $2 OR business_id IS NULL)
+		ORDER BY id
+		FOR UPDATE`,
+		pq.Array(ids), businessID)
+	if err != nil {
+		return nil, fmt.Errorf("locking inventory items: %w", err)
+	}
+	balances := make(map[int]float64, len(ids))
+	units := make(map[int]string, len(ids))
+	costs := make(map[int]*float64, len(ids))
+	for rows.Next() {
+		var id int
+		var quantity float64
+		var unit string
+		var unitCost *float64
+		if err := rows.Scan(&id, &quantity, &unit, &unitCost); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		balances[id] = quantity
+		units[id] = unit
+		costs[id] = unitCost
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(balances) != len(ids) {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+
+	recorded := make([]inventory.Movement, 0, len(movements))
+	for _, m := range movements {
+		before := balances[m.InventoryID]
+		m.BalanceAfter = before + m.Quantity
+		if m.BalanceAfter < 0 && !allowNegative {
+			return nil, inventory.ErrInsufficientStock
+		}
+		balances[m.InventoryID] = m.BalanceAfter
+		m.Unit = units[m.InventoryID]
+
+		if m.Quantity < 0 {
+			// Stock leaves at the average cost and at the cost of the oldest layers
+			m.UnitCost = costs[m.InventoryID]
+			fifoCost, err := consumeCostLayers(ctx, tx, m.InventoryID, -m.Quantity, m.UnitCost)
+			if err != nil {
+				return nil, err
+			}
+			m.FIFOCost = fifoCost
+		} else {
+			// The incoming side of a transfer carries the value of the outgoing side
+			if m.TransferItemID != nil && m.UnitCost == nil {
+				transferCosts(&m, recorded)
+			}
+			if m.UnitCost != nil {
+				costs[m.InventoryID] = blendCost(before, costs[m.InventoryID], m.Quantity, *m.UnitCost)
+			} else {
+				m.UnitCost = costs[m.InventoryID]
+			}
+			if m.FIFOCost == nil {
+				m.FIFOCost = m.UnitCost
+			}
+		}
+
+		if m.Type == inventory.MovementReceipt {
+			lotID, err := openLot(ctx, tx, m)
+			if err != nil {
+				return nil, err
+			}
+			m.LotID = &lotID
+		}
+
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO inventory_movements
+				(inventory_id, business_id, type, quantity, balance_after, unit, unit_cost, fifo_cost, user_id, reason,
+				 order_id, request_id, transfer_item_id, waste_id, lot_id)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14, $15)
+			RETURNING id, created_at`,
+			m.InventoryID, m.BusinessID, m.Type, m.Quantity, m.BalanceAfter, m.Unit, m.UnitCost, m.FIFOCost,
+			nilOrVal(m.UserID), m.Reason, m.OrderID, m.RequestID, m.TransferItemID, m.WasteID, m.LotID,
+		).Scan(&m.ID, &m.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("recording %s movement of item %d: %w", m.Type, m.InventoryID, err)
+		}
+
+		if m.Quantity < 0 {
+			if err := drawLots(ctx, tx, m); err != nil {
+				return nil, err
+			}
+		}
+
+		// Stock above zero opens a FIFO layer; incoming stock first fills a negative balance
+		if m.Quantity > 0 && m.BalanceAfter > 0 {
+			layer := math.Min(m.Quantity, m.BalanceAfter)
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO inventory_cost_layers (inventory_id, movement_id, quantity, remaining, unit_cost)
+				VALUES ($1, $2, $3, $3, $4)`,
+				m.InventoryID, m.ID, layer, m.FIFOCost); err != nil {
+				return nil, fmt.Errorf("opening cost layer of item %d: %w", m.InventoryID, err)
+			}
+		}
+		recorded = append(recorded, m)
+	}
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE inventory SET quantity = $1, unit_cost = $2, updated_at = NOW() WHERE id = $3`,
+			balances[id], costs[id], id); err != nil {
+			return nil, fmt.Errorf("updating quantity of item %d: %w", id, err)
+		}
+	}
+	return recorded, nil
+}
+
+// consumeCostLayers takes quantity out of the oldest FIFO layers of an item and returns
+// the cost of one unit of it. Stock beyond the layers is costed at fallback. The cost is
+// nil when part of the quantity has no known cost.
+func consumeCostLayers(ctx context.Context, tx *sql.Tx, inventoryID int, quantity float64, fallback *float64) (*float64, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, remaining, unit_cost
+		FROM inventory_cost_layers
+		WHERE inventory_id = $1 AND remaining > 0
+		ORDER BY id
+		FOR UPDATE`,
+		inventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("locking cost layers of item %d: %w", inventoryID, err)
+	}
+	type layer struct {
+		id        int
+		remaining float64
+		cost      *float64
+	}
+	var layers []layer
+	for rows.Next() {
+		var l layer
+		if err := rows.Scan(&l.id, &l.remaining, &l.cost); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		layers = append(layers, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	left, value, costed := quantity, 0.0, true
+	for _, l := range layers {
+		if left <= 0 {
+			break
+		}
+		take := math.Min(l.remaining, left)
+		if _, err := tx.ExecContext(ctx, `UPDATE inventory_cost_layers SET remaining = remaining - $1 WHERE id = $2`, take, l.id); err != nil {
+			return nil, fmt.Errorf("consuming cost layer %d: %w", l.id, err)
+		}
+		left -= take
+		if l.cost == nil {
+			costed = false
+			continue
+		}
+		value += take * *l.cost
+	}
+	if left > 0 {
+		if fallback == nil {
+			costed = false
+		} else {
+			value += left * *fallback
+		}
+	}
+
+	if !costed {
+		return nil, nil
+	}
+	cost := value / quantity
+	return &cost, nil
+}
+
+// transferCosts values the incoming side of a transfer like the outgoing side recorded
+// earlier in the batch, spreading it over the incoming quantity as units may differ
+func transferCosts(in *inventory.Movement, recorded []inventory.Movement) {
+	for _, out := range recorded {
+		if out.InventoryID != *in.TransferItemID || out.Quantity >= 0 {
+			continue
+		}
+		if out.UnitCost != nil {
+			cost := -out.Quantity * *out.UnitCost / in.Quantity
+			in.UnitCost = &cost
+		}
+		if out.FIFOCost != nil {
+			cost := -out.Quantity * *out.FIFOCost / in.Quantity
+			in.FIFOCost = &cost
+		}
+		return
+	}
+}
+
+// blendCost returns the weighted average cost after quantity arrives at cost. Stock at or
+// below zero has no weight.
+func blendCost(before float64, average *float64, quantity float64, cost float64) *float64 {
+	base := math.Max(before, 0)
+	if average == nil || base == 0 {
+		return &cost
+	}
+	blended := (base**average + quantity*cost) / (base + quantity)
+	return &blended
+}
+
+func (r *InventoryRepository) GetMovements(ctx context.Context, inventoryID int, filter inventory.MovementFilter, businessID int) ([]inventory.Movement, error) {
+	var from, to interface{}
+	if !filter.From.IsZero() {
+		from = filter.From
+	}
+	if !filter.To.IsZero() {
+		to = filter.To
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+movementColumns+`
+		FROM inventory_movements m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.inventory_id = $1 AND m.business_id = $2
+		  AND ($3 = '' OR m.type = $3)
+		  AND ($4::timestamptz IS NULL OR m.created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR m.created_at < $5)
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $6`,
+		inventoryID, businessID, string(filter.Type), from, to, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []inventory.Movement{}
+	for rows.Next() {
+		var m inventory.Movement
+		if err := scanMovement(rows, &m); err != nil {
+			return nil, err
+		}
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+// GetOrderConsumption multiplies the ordered portions by the dish recipes. The
+// returned movements are not recorded yet.
+func (r *InventoryRepository) GetOrderConsumption(ctx context.Context, orderID int, businessID int) ([]inventory.Movement, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT di.inventory_id, SUM(oi.quantity * di.quantity)
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		JOIN dish_ingredients di ON di.dish_id = oi.dish_id
+		WHERE oi.order_id = $1 AND o.business_id = $2
+		GROUP BY di.inventory_id
+		ORDER BY di.inventory_id`,
+		orderID, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying order consumption: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []inventory.Movement
+	for rows.Next() {
+		m := inventory.Movement{BusinessID: businessID, Type: inventory.MovementConsumption, OrderID: &orderID}
+		var used float64
+		if err := rows.Scan(&m.InventoryID, &used); err != nil {
+			return nil, err
+		}
+		if used <= 0 {
+			continue
+		}
+		m.Quantity = -used
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+func (r *InventoryRepository) HasOrderConsumption(ctx context.Context, orderID int, businessID int) (bool, error) {
+	var consumed bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM inventory_movements
+			WHERE order_id = $1 AND business_id = $2 AND type = $3
+		)`,
+		orderID, businessID, inventory.MovementConsumption,
+	).Scan(&consumed)
+	return consumed, err
+}
+
+// openLot stores the lot a receipt brings in. Stock that only fills a negative balance
+// leaves the lot empty.
+func openLot(ctx context.Context, tx *sql.Tx, m inventory.Movement) (int, error) {
+	lot := m.Lot
+	if lot == nil {
+		lot = &inventory.Lot{}
+	}
+	if lot.SupplierID != nil {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM suppliers WHERE id = $1 AND business_id = $2)`,
+			*lot.SupplierID, m.BusinessID).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, inventory.ErrInvalidLot
+		}
+	}
+
+	var id int
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_lots (inventory_id, business_id, lot_number, supplier_id, quantity, remaining, expires_on)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, NULLIF($7, '')::date)
+		RETURNING id`,
+		m.InventoryID, m.BusinessID, lot.LotNumber, lot.SupplierID, m.Quantity,
+		math.Max(math.Min(m.Quantity, m.BalanceAfter), 0), lot.ExpiresOn,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("opening lot of item %d: %w", m.InventoryID, err)
+	}
+	return id, nil
+}
+
+// drawLots takes an outgoing movement out of the lots of its item, the lot it names
+// first and then the one expiring first. Stock beyond the lots is not drawn.
+func drawLots(ctx context.Context, tx *sql.Tx, m inventory.Movement) error {
+	first := 0
+	if m.LotID != nil {
+		first = *m.LotID
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, remaining
+		FROM inventory_lots
+		WHERE inventory_id = $1 AND remaining > 0
+		ORDER BY (id = $2) DESC, expires_on NULLS LAST, id
+		FOR UPDATE`,
+		m.InventoryID, first)
+	if err != nil {
+		return fmt.Errorf("locking lots of item %d: %w", m.InventoryID, err)
+	}
+	type lot struct {
+		id        int
+		remaining float64
+	}
+	var lots []lot
+	for rows.Next() {
+		var l lot
+		if err := rows.Scan(&l.id, &l.remaining); err != nil {
+			rows.Close()
+			return err
+		}
+		lots = append(lots, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	left := -m.Quantity
+	for _, l := range lots {
+		if left <= 0 {
+			break
+		}
+		take := math.Min(l.remaining, left)
+		if _, err := tx.ExecContext(ctx, `UPDATE inventory_lots SET remaining = remaining - $1 WHERE id = $2`, take, l.id); err != nil {
+			return fmt.Errorf("drawing from lot %d: %w", l.id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO inventory_lot_draws (movement_id, lot_id, quantity) VALUES ($1, $2, $3)`,
+			m.ID, l.id, take); err != nil {
+			return fmt.Errorf("recording draw from lot %d: %w", l.id, err)
+		}
+		left -= take
+	}
+	return nil
+}
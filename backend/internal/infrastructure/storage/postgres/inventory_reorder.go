@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// GetItemSuppliers returns the suppliers linked to an item, or to every item of the
+// business when inventoryID is 0, preferred first
+func (r *InventoryRepository) GetItemSuppliers(ctx context.Context, inventoryID int, businessID int) (map[int][]inventory.ItemSupplier, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.inventory_id, l.supplier_id, s.name, l.preferred
+		FROM inventory_suppliers l
+		JOIN suppliers s ON s.id = l.supplier_id
+		WHERE s.business_id = $1 AND ($2 = 0 OR l.inventory_id = $2)
+		ORDER BY l.inventory_id, l.preferred DESC, s.name`,
+		businessID, inventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("querying item suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	suppliers := make(map[int][]inventory.ItemSupplier)
+	for rows.Next() {
+		var id int
+		var s inventory.ItemSupplier
+		if err := rows.Scan(&id, &s.SupplierID, &s.Name, &s.Preferred); err != nil {
+			return nil, err
+		}
+		suppliers[id] = append(suppliers[id], s)
+	}
+	return suppliers, rows.Err()
+}
+
+// SetItemSuppliers replaces the suppliers linked to an item. It returns
+// ErrInvalidSupplier when one of them is not a supplier of the business.
+func (r *InventoryRepository) SetItemSuppliers(ctx context.Context, inventoryID int, suppliers []inventory.ItemSupplier, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := setItemSuppliers(ctx, tx, inventoryID, suppliers, businessID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// setItemSuppliers does the work of SetItemSuppliers inside the caller's transaction
+func setItemSuppliers(ctx context.Context, tx *sql.Tx, inventoryID int, suppliers []inventory.ItemSupplier, businessID int) error {
+	ids := make([]int, len(suppliers))
+	for i, s := range suppliers {
+		ids[i] = s.SupplierID
+	}
+
+	var known int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM suppliers WHERE id = ANY($1) AND business_id = $2`,
+		pq.Array(ids), businessID).Scan(&known); err != nil {
+		return err
+	}
+	if known != len(ids) {
+		return inventory.ErrInvalidSupplier
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM inventory_suppliers WHERE inventory_id = $1`, inventoryID); err != nil {
+		return fmt.Errorf("clearing suppliers of item %d: %w", inventoryID, err)
+	}
+	for _, s := range suppliers {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO inventory_suppliers (inventory_id, supplier_id, preferred) VALUES ($1, $2, $3)`,
+			inventoryID, s.SupplierID, s.Preferred); err != nil {
+			return fmt.Errorf("linking supplier %d to item %d: %w", s.SupplierID, inventoryID, err)
+		}
+	}
+	return nil
+}
+
+// GetActiveSuppliers returns the active suppliers of a business with their categories
+func (r *InventoryRepository) GetActiveSuppliers(ctx context.Context, businessID int) ([]inventory.SupplierInfo, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, categories
+		FROM suppliers
+		WHERE business_id = $1 AND status = 'active'
+		ORDER BY id`,
+		businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []inventory.SupplierInfo
+	for rows.Next() {
+		var s inventory.SupplierInfo
+		if err := rows.Scan(&s.ID, &s.Name, pq.Array(&s.Categories)); err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+	return suppliers, rows.Err()
+}
+
+// GetConsumption sums the stock consumed in [from, to) by item
+func (r *InventoryRepository) GetConsumption(ctx context.Context, from, to time.Time, businessID int) (map[int]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT inventory_id, SUM(-quantity)
+		FROM inventory_movements
+		WHERE business_id = $1 AND type = 'consumption' AND created_at >= $2 AND created_at < $3
+		GROUP BY inventory_id`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying consumption: %w", err)
+	}
+	defer rows.Close()
+
+	used := make(map[int]float64)
+	for rows.Next() {
+		var id int
+		var quantity float64
+		if err := rows.Scan(&id, &quantity); err != nil {
+			return nil, err
+		}
+		used[id] = quantity
+	}
+	return used, rows.Err()
+}
+
+// GetOnOrder sums the quantities on draft and pending requests by item
+func (r *InventoryRepository) GetOnOrder(ctx context.Context, businessID int) (map[int]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.inventory_id, SUM(l.quantity)
+		FROM request_lines l
+		JOIN requests q ON q.id = l.request_id
+		WHERE q.business_id = $1 AND q.status IN ('draft', 'pending')
+		GROUP BY l.inventory_id`,
+		businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock on order: %w", err)
+	}
+	defer rows.Close()
+
+	onOrder := make(map[int]float64)
+	for rows.Next() {
+		var id int
+		var quantity float64
+		if err := rows.Scan(&id, &quantity); err != nil {
+			return nil, err
+		}
+		onOrder[id] = quantity
+	}
+	return onOrder, rows.Err()
+}
+
+// CreateReorderRequests stores a draft request for every group with a supplier, with
+// its lines, in a single transaction and sets RequestID on the groups
+func (r *InventoryRepository) CreateReorderRequests(ctx context.Context, groups []inventory.ReorderGroup, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range groups {
+		g := &groups[i]
+		if g.SupplierID == nil || len(g.Lines) == 0 {
+			continue
+		}
+
+		items := make([]string, len(g.Lines))
+		priority := "normal"
+		for j, line := range g.Lines {
+			items[j] = fmt.Sprintf("%s: %g %s", line.Name, line.OrderQuantity, line.Unit)
+			if line.Quantity <= 0 {
+				priority = "high"
+			}
+		}
+
+		var id int
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO requests (supplier_id, items, priority, comment, status, business_id, created_at)
+			VALUES ($1, $2, $3, $4, 'draft', $5, NOW())
+			RETURNING id`,
+			*g.SupplierID, pq.Array(items), priority, "Reorder of low stock", businessID,
+		).Scan(&id)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("creating reorder request for supplier %d: %w", *g.SupplierID, err)
+		}
+		for _, line := range g.Lines {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO request_lines (request_id, inventory_id, quantity) VALUES ($1, $2, $3)`,
+				id, line.InventoryID, line.OrderQuantity); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("adding item %d to request %d: %w", line.InventoryID, id, err)
+			}
+		}
+		g.RequestID = &id
+	}
+	return tx.Commit()
+}
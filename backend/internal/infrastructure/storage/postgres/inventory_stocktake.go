@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+	"time"
+)
+
+const stocktakeColumns = `s.id, s.business_id, COALESCE(s.category, ''), s.status, COALESCE(s.note, ''),
+	COALESCE(s.opened_by, 0), COALESCE(o.name, o.username, ''),
+	COALESCE(s.approved_by, 0), COALESCE(a.name, a.username, ''), s.created_at, s.closed_at`
+
+const stocktakeJoins = `
+	FROM stocktakes s
+	LEFT JOIN users o ON o.id = s.opened_by
+	LEFT JOIN users a ON a.id = s.approved_by`
+
+func scanStocktake(row rowScanner, st *inventory.Stocktake) error {
+	return row.Scan(
+		&st.ID,
+		&st.BusinessID,
+		&st.Category,
+		&st.Status,
+		&st.Note,
+		&st.OpenedBy,
+		&st.OpenedByName,
+		&st.ApprovedBy,
+		&st.ApprovedByName,
+		&st.CreatedAt,
+		&st.ClosedAt,
+	)
+}
+
+// CreateStocktake opens a session and takes the system quantity of every item in it.
+// The business row is locked so two sessions cannot open at once.
+func (r *InventoryRepository) CreateStocktake(ctx context.Context, st *inventory.Stocktake) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM businesses WHERE id = $1 FOR UPDATE`, st.BusinessID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("locking business: %w", err)
+	}
+	var open bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM stocktakes WHERE business_id = $1 AND status = $2)`,
+		st.BusinessID, inventory.StocktakeOpen,
+	).Scan(&open); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if open {
+		tx.Rollback()
+		return inventory.ErrStocktakeInProgress
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO stocktakes (business_id, category, status, note, opened_by)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at`,
+		st.BusinessID, st.Category, inventory.StocktakeOpen, st.Note, nilOrVal(st.OpenedBy),
+	).Scan(&st.ID, &st.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("creating stocktake: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO stocktake_lines (stocktake_id, inventory_id, system_quantity, unit)
+		SELECT $1, id, quantity, COALESCE(unit, '')
+		FROM inventory
+		WHERE (business_id = $2 OR business_id IS NULL) AND ($3 = '' OR category = $3)`,
+		st.ID, st.BusinessID, st.Category)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("taking system quantities: %w", err)
+	}
+	if lines, err := result.RowsAffected(); err != nil || lines == 0 {
+		tx.Rollback()
+		return inventory.ErrInvalidStocktake
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	st.Status = inventory.StocktakeOpen
+	return nil
+}
+
+func (r *InventoryRepository) GetStocktakes(ctx context.Context, status inventory.StocktakeStatus, businessID int) ([]inventory.Stocktake, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+stocktakeColumns+stocktakeJoins+`
+		WHERE s.business_id = $1 AND ($2 = '' OR s.status = $2)
+		ORDER BY s.created_at DESC, s.id DESC`,
+		businessID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("querying stocktakes: %w", err)
+	}
+	defer rows.Close()
+
+	stocktakes := []inventory.Stocktake{}
+	for rows.Next() {
+		var st inventory.Stocktake
+		if err := scanStocktake(rows, &st); err != nil {
+			return nil, err
+		}
+		stocktakes = append(stocktakes, st)
+	}
+	return stocktakes, rows.Err()
+}
+
+// GetStocktake returns a session with its lines and counts. Lines of an approved
+// session carry the unit cost of the approval, open ones the current cost.
+func (r *InventoryRepository) GetStocktake(ctx context.Context, id int, businessID int) (*inventory.Stocktake, error) {
+	var st inventory.Stocktake
+	err := scanStocktake(r.db.QueryRowContext(ctx, `
+		SELECT `+stocktakeColumns+stocktakeJoins+`
+		WHERE s.id = $1 AND s.business_id = $2`,
+		id, businessID), &st)
+	if err == sql.ErrNoRows {
+		return nil, inventory.ErrStocktakeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying stocktake %d: %w", id, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.inventory_id, i.name, COALESCE(i.category, ''), l.unit, l.system_quantity,
+			CASE WHEN $2 THEN l.unit_cost ELSE i.unit_cost END
+		FROM stocktake_lines l
+		JOIN inventory i ON i.id = l.inventory_id
+		WHERE l.stocktake_id = $1
+		ORDER BY i.category, i.name, l.inventory_id`,
+		id, st.Status == inventory.StocktakeApproved)
+	if err != nil {
+		return nil, fmt.Errorf("querying stocktake lines: %w", err)
+	}
+	byItem := make(map[int]int)
+	for rows.Next() {
+		var line inventory.StocktakeLine
+		if err := rows.Scan(&line.InventoryID, &line.Name, &line.Category, &line.Unit, &line.SystemQuantity, &line.UnitCost); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		byItem[line.InventoryID] = len(st.Lines)
+		st.Lines = append(st.Lines, line)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT c.inventory_id, COALESCE(c.user_id, 0), COALESCE(u.name, u.username, ''), c.quantity, c.counted_at
+		FROM stocktake_counts c
+		LEFT JOIN users u ON u.id = c.user_id
+		WHERE c.stocktake_id = $1
+		ORDER BY c.counted_at, c.id`,
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("querying stocktake counts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c inventory.StocktakeCount
+		if err := rows.Scan(&c.InventoryID, &c.UserID, &c.UserName, &c.Quantity, &c.CountedAt); err != nil {
+			return nil, err
+		}
+		if i, ok := byItem[c.InventoryID]; ok {
+			st.Lines[i].Counts = append(st.Lines[i].Counts, c)
+		}
+	}
+	return &st, rows.Err()
+}
+
+// SaveCounts stores the counts of one counter, replacing the counter's earlier count
+// of the same items. The session must still be open and list every item.
+func (r *InventoryRepository) SaveCounts(ctx context.Context, stocktakeID int, userID int, counts []inventory.StocktakeCount, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := lockOpenStocktake(ctx, tx, stocktakeID, businessID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, c := range counts {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO stocktake_counts (stocktake_id, inventory_id, user_id, quantity)
+			SELECT stocktake_id, inventory_id, $3, $4
+			FROM stocktake_lines
+			WHERE stocktake_id = $1 AND inventory_id = $2
+			ON CONFLICT (stocktake_id, inventory_id, user_id)
+			DO UPDATE SET quantity = EXCLUDED.quantity, counted_at = NOW()`,
+			stocktakeID, c.InventoryID, nilOrVal(userID), c.Quantity)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("saving count of item %d: %w", c.InventoryID, err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			tx.Rollback()
+			return inventory.ErrInvalidCount
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ApproveStocktake closes an open session, keeps the unit costs of its lines for the
+// variance report and posts the adjustments, all in one transaction
+func (r *InventoryRepository) ApproveStocktake(ctx context.Context, id int, userID int, adjustments []inventory.Movement, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := lockOpenStocktake(ctx, tx, id, businessID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stocktakes SET status = $1, approved_by = $2, closed_at = NOW()
+		WHERE id = $3`,
+		inventory.StocktakeApproved, nilOrVal(userID), id); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("approving stocktake %d: %w", id, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stocktake_lines l SET unit_cost = i.unit_cost
+		FROM inventory i
+		WHERE i.id = l.inventory_id AND l.stocktake_id = $1`,
+		id); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("keeping stocktake unit costs: %w", err)
+	}
+
+	if _, err := recordMovementsTx(ctx, tx, adjustments, true); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *InventoryRepository) CancelStocktake(ctx context.Context, id int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE stocktakes SET status = $1, closed_at = NOW()
+		WHERE id = $2 AND business_id = $3 AND status = $4`,
+		inventory.StocktakeCancelled, id, businessID, inventory.StocktakeOpen)
+	if err != nil {
+		return fmt.Errorf("cancelling stocktake %d: %w", id, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return inventory.ErrStocktakeClosed
+	}
+	return nil
+}
+
+// GetStocktakeVariances returns the counted lines of the sessions approved in
+// [from, to), grouped by item with one entry per session
+func (r *InventoryRepository) GetStocktakeVariances(ctx context.Context, from, to time.Time, inventoryID int, businessID int) ([]inventory.ItemVariance, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.inventory_id, i.name, l.unit, s.id, s.closed_at,
+			SUM(c.quantity) - l.system_quantity, l.unit_cost
+		FROM stocktakes s
+		JOIN stocktake_lines l ON l.stocktake_id = s.id
+		JOIN stocktake_counts c ON c.stocktake_id = l.stocktake_id AND c.inventory_id = l.inventory_id
+		JOIN inventory i ON i.id = l.inventory_id
+		WHERE s.business_id = $1 AND s.status = $2
+		  AND s.closed_at >= $3 AND s.closed_at < $4
+		  AND ($5 = 0 OR l.inventory_id = $5)
+		GROUP BY l.inventory_id, i.name, l.unit, s.id, s.closed_at, l.system_quantity, l.unit_cost
+		ORDER BY l.inventory_id, s.closed_at`,
+		businessID, inventory.StocktakeApproved, from, to, inventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("querying stocktake variances: %w", err)
+	}
+	defer rows.Close()
+
+	var items []inventory.ItemVariance
+	for rows.Next() {
+		var item inventory.ItemVariance
+		var session inventory.SessionVariance
+		var unitCost *float64
+		if err := rows.Scan(&item.InventoryID, &item.Name, &item.Unit, &session.StocktakeID, &session.ApprovedAt,
+			&session.Quantity, &unitCost); err != nil {
+			return nil, err
+		}
+		if unitCost != nil {
+			value := session.Quantity * *unitCost
+			session.Value = &value
+		}
+		if n := len(items); n > 0 && items[n-1].InventoryID == item.InventoryID {
+			items[n-1].Sessions = append(items[n-1].Sessions, session)
+			continue
+		}
+		item.Sessions = []inventory.SessionVariance{session}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// lockOpenStocktake locks a session row and checks it is still open
+func lockOpenStocktake(ctx context.Context, tx *sql.Tx, id int, businessID int) error {
+	var status inventory.StocktakeStatus
+	err := tx.QueryRowContext(ctx, `
+		SELECT status FROM stocktakes WHERE id = $1 AND business_id = $2 FOR UPDATE`,
+		id, businessID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return inventory.ErrStocktakeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != inventory.StocktakeOpen {
+		return inventory.ErrStocktakeClosed
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+
+	"github.com/lib/pq"
+)
+
+func (r *InventoryRepository) GetUnits(ctx context.Context) ([]inventory.Unit, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT code, name, dimension, factor, aliases
+		FROM units
+		ORDER BY dimension, factor`)
+	if err != nil {
+		return nil, fmt.Errorf("querying units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []inventory.Unit{}
+	for rows.Next() {
+		var u inventory.Unit
+		if err := rows.Scan(&u.Code, &u.Name, &u.Dimension, &u.Factor, pq.Array(&u.Aliases)); err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+func (r *InventoryRepository) GetConversions(ctx context.Context, inventoryID int, businessID int) (map[int][]inventory.Conversion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.inventory_id, c.from_unit, c.to_unit, c.factor
+		FROM inventory_unit_conversions c
+		JOIN inventory i ON i.id = c.inventory_id
+		WHERE ($1 = 0 OR c.inventory_id = $1) AND (i.business_id = $2 OR i.business_id IS NULL)
+		ORDER BY c.inventory_id, c.id`,
+		inventoryID, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying unit conversions: %w", err)
+	}
+	defer rows.Close()
+
+	conversions := make(map[int][]inventory.Conversion)
+	for rows.Next() {
+		var id int
+		var c inventory.Conversion
+		if err := rows.Scan(&id, &c.FromUnit, &c.ToUnit, &c.Factor); err != nil {
+			return nil, err
+		}
+		conversions[id] = append(conversions[id], c)
+	}
+	return conversions, rows.Err()
+}
+
+// SetConversions replaces the conversions of an item in a single transaction
+func (r *InventoryRepository) SetConversions(ctx context.Context, inventoryID int, conversions []inventory.Conversion, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := setConversions(ctx, tx, inventoryID, conversions, businessID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func setConversions(ctx context.Context, tx *sql.Tx, inventoryID int, conversions []inventory.Conversion, businessID int) error {
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM inventory_unit_conversions c
+		USING inventory i
+		WHERE i.id = c.inventory_id AND c.inventory_id = $1 AND (i.business_id = $2 OR i.business_id IS NULL)`,
+		inventoryID, businessID); err != nil {
+		return fmt.Errorf("clearing unit conversions: %w", err)
+	}
+
+	for _, c := range conversions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO inventory_unit_conversions (inventory_id, from_unit, to_unit, factor)
+			VALUES ($1, $2, $3, $4)`,
+			inventoryID, c.FromUnit, c.ToUnit, c.Factor); err != nil {
+			return fmt.Errorf("saving unit conversion %s -> %s: %w", c.FromUnit, c.ToUnit, err)
+		}
+	}
+	return nil
+}
+
+// rescaleStock multiplies the stock, the recipe quantities, the FIFO cost layers, the
+// lots and the requested quantities of an item. The ledger keeps the old figures together
+// with the unit they were in.
+func rescaleStock(ctx context.Context, tx *sql.Tx, inventoryID int, factor float64, businessID int) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE inventory
+		SET quantity = quantity * $1, updated_at = NOW()
+		WHERE id = $2 AND (business_id = $3 OR business_id IS NULL)`,
+		factor, inventoryID, businessID)
+	if err != nil {
+		return fmt.Errorf("rescaling stock of item %d: %w", inventoryID, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return fmt.Errorf("inventory item with ID %d not found", inventoryID)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE dish_ingredients SET quantity = quantity * $1 WHERE inventory_id = $2`, factor, inventoryID); err != nil {
+		return fmt.Errorf("rescaling recipes using item %d: %w", inventoryID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE inventory_cost_layers
+		SET quantity = quantity * $1, remaining = remaining * $1, unit_cost = unit_cost / $1
+		WHERE inventory_id = $2`,
+		factor, inventoryID); err != nil {
+		return fmt.Errorf("rescaling cost layers of item %d: %w", inventoryID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE inventory_lots SET quantity = quantity * $1, remaining = remaining * $1 WHERE inventory_id = $2`,
+		factor, inventoryID); err != nil {
+		return fmt.Errorf("rescaling lots of item %d: %w", inventoryID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE inventory_lot_draws d SET quantity = d.quantity * $1
+		FROM inventory_lots l
+		WHERE l.id = d.lot_id AND l.inventory_id = $2`,
+		factor, inventoryID); err != nil {
+		return fmt.Errorf("rescaling lot draws of item %d: %w", inventoryID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE request_lines SET quantity = quantity * $1 WHERE inventory_id = $2`, factor, inventoryID); err != nil {
+		return fmt.Errorf("rescaling requested quantities of item %d: %w", inventoryID, err)
+	}
+	return nil
+}
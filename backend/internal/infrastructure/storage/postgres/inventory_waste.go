@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/inventory"
+	"time"
+)
+
+// wasteColumns reads a waste entry; its value is the cost of its movements, or NULL
+// when one of them has no unit cost
+const wasteColumns = `w.id, w.business_id, w.inventory_id, w.dish_id, COALESCE(i.name, d.name, ''),
+	w.quantity, COALESCE(w.unit, ''), w.reason, COALESCE(w.note, ''), COALESCE(w.photo_url, ''),
+	COALESCE(w.user_id, 0), COALESCE(u.name, u.username, ''), w.shift_id,
+	(SELECT CASE WHEN COUNT(*) > 0 AND COUNT(*) = COUNT(m.unit_cost) THEN SUM(-m.quantity * m.unit_cost) END
+	 FROM inventory_movements m WHERE m.waste_id = w.id),
+	w.created_at`
+
+const wasteJoins = `
+	FROM waste_entries w
+	LEFT JOIN inventory i ON i.id = w.inventory_id
+	LEFT JOIN dishes d ON d.id = w.dish_id
+	LEFT JOIN users u ON u.id = w.user_id`
+
+func scanWaste(row rowScanner, w *inventory.Waste) error {
+	return row.Scan(
+		&w.ID,
+		&w.BusinessID,
+		&w.InventoryID,
+		&w.DishID,
+		&w.Name,
+		&w.Quantity,
+		&w.Unit,
+		&w.Reason,
+		&w.Note,
+		&w.PhotoURL,
+		&w.UserID,
+		&w.UserName,
+		&w.ShiftID,
+		&w.Value,
+		&w.CreatedAt,
+	)
+}
+
+func (r *InventoryRepository) GetDishUsage(ctx context.Context, dishID int, portions float64, businessID int) ([]inventory.Movement, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM dishes WHERE id = $1 AND business_id = $2)`,
+		dishID, businessID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, inventory.ErrInvalidWaste
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT inventory_id, quantity * $3
+		FROM dish_ingredients
+		WHERE dish_id = $1 AND business_id = $2
+		ORDER BY inventory_id`,
+		dishID, businessID, portions)
+	if err != nil {
+		return nil, fmt.Errorf("querying dish recipe: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []inventory.Movement
+	for rows.Next() {
+		m := inventory.Movement{BusinessID: businessID, Type: inventory.MovementWaste}
+		var used float64
+		if err := rows.Scan(&m.InventoryID, &used); err != nil {
+			return nil, err
+		}
+		if used <= 0 {
+			continue
+		}
+		m.Quantity = -used
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+// CreateWaste stores the entry against the shift running now, preferring a shift the
+// user works, and records its movements in the same transaction
+func (r *InventoryRepository) CreateWaste(ctx context.Context, w *inventory.Waste, movements []inventory.Movement, allowNegative bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := createWasteTx(ctx, tx, w, movements, allowNegative)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	w.Movements = recorded
+	return nil
+}
+
+// CreateWastes stores the entries like CreateWaste, each recording the movements it
+// carries, and fails as a whole when one of them runs out of stock
+func (r *InventoryRepository) CreateWastes(ctx context.Context, entries []*inventory.Waste) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	recorded := make([][]inventory.Movement, len(entries))
+	for i, w := range entries {
+		if recorded[i], err = createWasteTx(ctx, tx, w, w.Movements, false); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for i, w := range entries {
+		w.Movements = recorded[i]
+	}
+	return nil
+}
+
+func createWasteTx(ctx context.Context, tx *sql.Tx, w *inventory.Waste, movements []inventory.Movement, allowNegative bool) ([]inventory.Movement, error) {
+	currentDate, currentTime := shiftClock(ctx, tx, w.BusinessID)
+
+	var shiftID int
+	err := tx.QueryRowContext(ctx, `
+		SELECT s.id
+		FROM shifts s
+		LEFT JOIN shift_employees se ON se.shift_id = s.id AND se.employee_id = $2
+		WHERE s.business_id = $1 AND s.date = $3::date AND $4::time BETWEEN s.start_time AND s.end_time
+		ORDER BY (se.id IS NOT NULL) DESC, s.id
+		LIMIT 1`,
+		w.BusinessID, w.UserID, currentDate, currentTime).Scan(&shiftID)
+	switch {
+	case err == sql.ErrNoRows:
+		w.ShiftID = nil
+	case err != nil:
+		return nil, fmt.Errorf("finding current shift: %w", err)
+	default:
+		w.ShiftID = &shiftID
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO waste_entries (business_id, inventory_id, dish_id, quantity, unit, reason, note, user_id, shift_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9)
+		RETURNING id, created_at`,
+		w.BusinessID, w.InventoryID, w.DishID, w.Quantity, w.Unit, w.Reason, w.Note, nilOrVal(w.UserID), w.ShiftID,
+	).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("creating waste entry: %w", err)
+	}
+
+	for i := range movements {
+		movements[i].WasteID = &w.ID
+	}
+	return recordMovementsTx(ctx, tx, movements, allowNegative)
+}
+
+func (r *InventoryRepository) GetWaste(ctx context.Context, id int, businessID int) (*inventory.Waste, error) {
+	var w inventory.Waste
+	err := scanWaste(r.db.QueryRowContext(ctx, `
+		SELECT `+wasteColumns+wasteJoins+`
+		WHERE w.id = $1 AND w.business_id = $2`,
+		id, businessID), &w)
+	if err == sql.ErrNoRows {
+		return nil, inventory.ErrWasteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying waste entry %d: %w", id, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+movementColumns+`
+		FROM inventory_movements m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.waste_id = $1
+		ORDER BY m.id`,
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("querying waste movements: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m inventory.Movement
+		if err := scanMovement(rows, &m); err != nil {
+			return nil, err
+		}
+		w.Movements = append(w.Movements, m)
+	}
+	return &w, rows.Err()
+}
+
+func (r *InventoryRepository) GetWasteEntries(ctx context.Context, from, to time.Time, reason inventory.WasteReason, businessID int) ([]inventory.Waste, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+wasteColumns+wasteJoins+`
+		WHERE w.business_id = $1 AND w.created_at >= $2 AND w.created_at < $3
+		  AND ($4 = '' OR w.reason = $4)
+		ORDER BY w.created_at DESC, w.id DESC`,
+		businessID, from, to, string(reason))
+	if err != nil {
+		return nil, fmt.Errorf("querying waste entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []inventory.Waste{}
+	for rows.Next() {
+		var w inventory.Waste
+		if err := scanWaste(rows, &w); err != nil {
+			return nil, err
+		}
+		entries = append(entries, w)
+	}
+	return entries, rows.Err()
+}
+
+func (r *InventoryRepository) SetWastePhoto(ctx context.Context, id int, photoURL string, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE waste_entries SET photo_url = NULLIF($1, '')
+		WHERE id = $2 AND business_id = $3`,
+		photoURL, id, businessID)
+	if err != nil {
+		return fmt.Errorf("saving waste photo: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return inventory.ErrWasteNotFound
+	}
+	return nil
+}
+
+// GetWasteLines returns one line per item taken out of stock by the waste logged in
+// [from, to). Entries that moved no stock, like a dish without a recipe, come back
+// once with InventoryID 0.
+func (r *InventoryRepository) GetWasteLines(ctx context.Context, from, to time.Time, businessID int) ([]inventory.WasteLine, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT w.id, w.reason, w.shift_id,
+			COALESCE(TO_CHAR(s.date, 'YYYY-MM-DD') || ' ' || TO_CHAR(s.start_time, 'HH24:MI') || '-' || TO_CHAR(s.end_time, 'HH24:MI'), ''),
+			COALESCE(m.inventory_id, 0), COALESCE(i.name, ''), COALESCE(m.unit, ''), COALESCE(-m.quantity, 0), m.unit_cost
+		FROM waste_entries w
+		LEFT JOIN inventory_movements m ON m.waste_id = w.id
+		LEFT JOIN inventory i ON i.id = m.inventory_id
+		LEFT JOIN shifts s ON s.id = w.shift_id
+		WHERE w.business_id = $1 AND w.created_at >= $2 AND w.created_at < $3
+		ORDER BY w.id, m.id`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying waste lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []inventory.WasteLine
+	for rows.Next() {
+		var l inventory.WasteLine
+		if err := rows.Scan(&l.WasteID, &l.Reason, &l.ShiftID, &l.ShiftLabel,
+			&l.InventoryID, &l.Name, &l.Unit, &l.Quantity, &l.UnitCost); err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	return lines, rows.Err()
+}
@@ -37,7 +37,7 @@ func (r *MenuRepository) GetMenuItems(ctx context.Context, categoryID *int, busi
 	// Build the query dynamically based on column existence
 	query := `
 		SELECT id, name, price, category_id, image_url, is_available, 
-		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, `
+		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, dietary_tags, cost, sort_order, is_visible, `
 
 	if hasDescriptionColumn {
 		query += `COALESCE(description, ''), `
@@ -45,10 +45,10 @@ func (r *MenuRepository) GetMenuItems(ctx context.Context, categoryID *int, busi
 
 	query += `COALESCE(business_id, 0), created_at, updated_at
 		FROM dishes
-		WHERE ($1::int IS NULL OR category_id = $1)
-		ORDER BY category_id, name`
+		WHERE ($1::int IS NULL OR category_id = $1) AND business_id = $2
+		ORDER BY category_id, sort_order, name`
 
-	rows, err := r.db.QueryContext(ctx, query, categoryID)
+	rows, err := r.db.QueryContext(ctx, query, categoryID, businessID)
 	if err != nil {
 		return nil, fmt.Errorf("querying menu items: %w", err)
 	}
@@ -69,6 +69,10 @@ func (r *MenuRepository) GetMenuItems(ctx context.Context, categoryID *int, busi
 			&item.PreparationTime,
 			&item.Calories,
 			pq.Array(&item.Allergens),
+			pq.Array(&item.DietaryTags),
+			&item.Cost,
+			&item.SortOrder,
+			&item.IsVisible,
 		}
 
 		// Add description to scan destinations only if column exists
@@ -113,7 +117,7 @@ func (r *MenuRepository) GetMenuItemByID(ctx context.Context, id int, businessID
 	// Build the query dynamically based on column existence
 	query := `
 		SELECT id, name, price, category_id, image_url, is_available, 
-		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, `
+		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, dietary_tags, cost, sort_order, is_visible, `
 
 	if hasDescriptionColumn {
 		query += `COALESCE(description, ''), `
@@ -135,6 +139,10 @@ func (r *MenuRepository) GetMenuItemByID(ctx context.Context, id int, businessID
 		&item.PreparationTime,
 		&item.Calories,
 		pq.Array(&item.Allergens),
+		pq.Array(&item.DietaryTags),
+		&item.Cost,
+		&item.SortOrder,
+		&item.IsVisible,
 	}
 
 	// Add description to scan destinations only if column exists
@@ -175,7 +183,7 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 
 	// Build the query dynamically based on column existence
 	query := `
-		INSERT INTO dishes (name, price, category_id, image_url, is_available, preparation_time, calories, allergens, `
+		INSERT INTO dishes (name, price, category_id, image_url, is_available, preparation_time, calories, allergens, dietary_tags, cost, sort_order, is_visible, `
 
 	if hasDescriptionColumn {
 		query += `description, `
@@ -194,6 +202,10 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 		nilOrVal(item.PreparationTime),
 		nilOrVal(item.Calories),
 		pq.Array(item.Allergens),
+		pq.Array(item.DietaryTags),
+		item.Cost,
+		item.SortOrder,
+		item.IsVisible == nil || *item.IsVisible,
 	}
 
 	// Start with $1
@@ -207,8 +219,14 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 		fmt.Sprintf("$%d", paramIndex+5), // preparation_time
 		fmt.Sprintf("$%d", paramIndex+6), // calories
 		fmt.Sprintf("$%d", paramIndex+7), // allergens
+		fmt.Sprintf("$%d", paramIndex+8), // dietary_tags
+		fmt.Sprintf("$%d", paramIndex+9), // cost
+		// sort_order, by default after the last dish of the category
+		fmt.Sprintf("COALESCE($%d, (SELECT COALESCE(MAX(sort_order) + 1, 0) FROM dishes WHERE category_id = $%d))",
+			paramIndex+10, paramIndex+2),
+		fmt.Sprintf("$%d", paramIndex+11), // is_visible
 	}
-	paramIndex += 8
+	paramIndex += 12
 
 	// Add description placeholder only if column exists
 	if hasDescriptionColumn {
@@ -228,7 +246,7 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 	// Complete the query
 	query += strings.Join(placeholders, ", ") + `)
 		RETURNING id, name, price, category_id, image_url, is_available, 
-		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, `
+		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, dietary_tags, cost, sort_order, is_visible, `
 
 	if hasDescriptionColumn {
 		query += `COALESCE(description, ''), `
@@ -248,6 +266,10 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 		&created.PreparationTime,
 		&created.Calories,
 		pq.Array(&created.Allergens),
+		pq.Array(&created.DietaryTags),
+		&created.Cost,
+		&created.SortOrder,
+		&created.IsVisible,
 	}
 
 	// Add description to scan destinations only if column exists
@@ -261,12 +283,26 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item menu.MenuItemC
 		&created.CreatedAt,
 		&created.UpdatedAt)
 
+	// The dish and its first price history entry are written together
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+
 	// Execute the query
-	err = r.db.QueryRowContext(ctx, query, params...).Scan(scanDest...)
+	err = tx.QueryRowContext(ctx, query, params...).Scan(scanDest...)
 
 	if err != nil {
+		tx.Rollback()
 		return nil, fmt.Errorf("creating menu item: %w", err)
 	}
+	if err := recordPrice(ctx, tx, created.ID, item.BusinessID, created.Price); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing menu item: %w", err)
+	}
 	return &created, nil
 }
 
@@ -332,12 +368,38 @@ func (r *MenuRepository) UpdateMenuItem(ctx context.Context, id int, item menu.M
 		paramCounter++
 	}
 
-	if len(item.Allergens) > 0 {
+	// A non-nil empty list clears the allergens
+	if item.Allergens != nil {
 		setClauses = append(setClauses, fmt.Sprintf("allergens = $%d", paramCounter))
 		params = append(params, pq.Array(item.Allergens))
 		paramCounter++
 	}
 
+	if item.DietaryTags != nil {
+		setClauses = append(setClauses, fmt.Sprintf("dietary_tags = $%d", paramCounter))
+		params = append(params, pq.Array(item.DietaryTags))
+		paramCounter++
+	}
+
+	// A zero cost clears the manual cost so the recipe cost applies again
+	if item.Cost != nil {
+		setClauses = append(setClauses, fmt.Sprintf("cost = NULLIF($%d, 0)", paramCounter))
+		params = append(params, *item.Cost)
+		paramCounter++
+	}
+
+	if item.SortOrder != nil {
+		setClauses = append(setClauses, fmt.Sprintf("sort_order = $%d", paramCounter))
+		params = append(params, *item.SortOrder)
+		paramCounter++
+	}
+
+	if item.IsVisible != nil {
+		setClauses = append(setClauses, fmt.Sprintf("is_visible = $%d", paramCounter))
+		params = append(params, *item.IsVisible)
+		paramCounter++
+	}
+
 	// Add description only if the column exists
 	if hasDescriptionColumn && item.Description != "" {
 		setClauses = append(setClauses, fmt.Sprintf("description = $%d", paramCounter))
@@ -366,7 +428,7 @@ func (r *MenuRepository) UpdateMenuItem(ctx context.Context, id int, item menu.M
 		SET %s
 		WHERE id = $%d
 		RETURNING id, name, price, category_id, image_url, is_available, 
-		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens`,
+		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, dietary_tags, cost, sort_order, is_visible`,
 		strings.Join(setClauses, ", "),
 		paramCounter)
 
@@ -392,6 +454,10 @@ func (r *MenuRepository) UpdateMenuItem(ctx context.Context, id int, item menu.M
 		&updated.PreparationTime,
 		&updated.Calories,
 		pq.Array(&updated.Allergens),
+		pq.Array(&updated.DietaryTags),
+		&updated.Cost,
+		&updated.SortOrder,
+		&updated.IsVisible,
 	}
 
 	// Add description to scan destinations only if column exists
@@ -405,15 +471,32 @@ func (r *MenuRepository) UpdateMenuItem(ctx context.Context, id int, item menu.M
 		&updated.CreatedAt,
 		&updated.UpdatedAt)
 
+	// A price change is written to the history in the same transaction
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+
 	// Execute the query
-	err = r.db.QueryRowContext(ctx, query, params...).Scan(scanDest...)
+	err = tx.QueryRowContext(ctx, query, params...).Scan(scanDest...)
 
 	if err == sql.ErrNoRows {
+		tx.Rollback()
 		return nil, nil
 	}
 	if err != nil {
+		tx.Rollback()
 		return nil, fmt.Errorf("updating menu item: %w - SQL: %s, Params: %v", err, query, params)
 	}
+	if item.Price > 0 {
+		if err := recordPrice(ctx, tx, updated.ID, item.BusinessID, updated.Price); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing menu item: %w", err)
+	}
 	return &updated, nil
 }
 
@@ -438,15 +521,35 @@ func (r *MenuRepository) DeleteMenuItem(ctx context.Context, id int, businessID
 	if rows == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+	return r.deleteEntityTranslations(ctx, menu.TranslationEntityDish, id, businessID)
+}
+
+// categoryColumns are the columns scanned by scanCategory
+const categoryColumns = `id, name, COALESCE(parent_id, 0), sort_order, is_visible, business_id, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCategory(row rowScanner, category *menu.Category) error {
+	return row.Scan(
+		&category.ID,
+		&category.Name,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.IsVisible,
+		&category.BusinessID,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
 }
 
 func (r *MenuRepository) GetCategories(ctx context.Context, businessID int) ([]menu.Category, error) {
 	query := `
-		SELECT id, name, business_id, created_at, updated_at
+		SELECT ` + categoryColumns + `
 		FROM categories
 		WHERE business_id = $1
-		ORDER BY name`
+		ORDER BY sort_order, name`
 
 	rows, err := r.db.QueryContext(ctx, query, businessID)
 	if err != nil {
@@ -457,13 +560,7 @@ func (r *MenuRepository) GetCategories(ctx context.Context, businessID int) ([]m
 	var categories []menu.Category
 	for rows.Next() {
 		var category menu.Category
-		if err := rows.Scan(
-			&category.ID,
-			&category.Name,
-			&category.BusinessID,
-			&category.CreatedAt,
-			&category.UpdatedAt,
-		); err != nil {
+		if err := scanCategory(rows, &category); err != nil {
 			return nil, err
 		}
 		categories = append(categories, category)
@@ -473,18 +570,12 @@ func (r *MenuRepository) GetCategories(ctx context.Context, businessID int) ([]m
 
 func (r *MenuRepository) GetCategoryByID(ctx context.Context, id int, businessID int) (*menu.Category, error) {
 	query := `
-		SELECT id, name, business_id, created_at, updated_at
+		SELECT ` + categoryColumns + `
 		FROM categories
 		WHERE id = $1 AND business_id = $2`
 
 	var category menu.Category
-	err := r.db.QueryRowContext(ctx, query, id, businessID).Scan(
-		&category.ID,
-		&category.Name,
-		&category.BusinessID,
-		&category.CreatedAt,
-		&category.UpdatedAt,
-	)
+	err := scanCategory(r.db.QueryRowContext(ctx, query, id, businessID), &category)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -495,22 +586,25 @@ func (r *MenuRepository) GetCategoryByID(ctx context.Context, id int, businessID
 }
 
 func (r *MenuRepository) CreateCategory(ctx context.Context, category menu.CategoryCreate) (*menu.Category, error) {
+	// By default a new category goes after its last sibling
 	query := `
-		INSERT INTO categories (name, business_id, created_at, updated_at)
-		VALUES ($1, $2, NOW(), NOW())
-		RETURNING id, name, business_id, created_at, updated_at`
+		INSERT INTO categories (name, parent_id, sort_order, is_visible, business_id, created_at, updated_at)
+		VALUES ($1, $2, COALESCE($3, (
+			SELECT COALESCE(MAX(sort_order) + 1, 0) FROM categories
+			WHERE business_id = $5 AND parent_id IS NOT DISTINCT FROM $2::int
+		)), $4, $5, NOW(), NOW())
+		RETURNING ` + categoryColumns
+
+	isVisible := category.IsVisible == nil || *category.IsVisible
 
 	var created menu.Category
-	err := r.db.QueryRowContext(ctx, query,
+	err := scanCategory(r.db.QueryRowContext(ctx, query,
 		category.Name,
+		nilOrVal(category.ParentID),
+		category.SortOrder,
+		isVisible,
 		category.BusinessID,
-	).Scan(
-		&created.ID,
-		&created.Name,
-		&created.BusinessID,
-		&created.CreatedAt,
-		&created.UpdatedAt,
-	)
+	), &created)
 	if err != nil {
 		log.Println("Error creating category:", err)
 		log.Println("Query:", query)
@@ -525,22 +619,28 @@ func (r *MenuRepository) UpdateCategory(ctx context.Context, id int, category me
 		UPDATE categories
 		SET name = COALESCE($1, name),
 			business_id = COALESCE($2, business_id),
+			parent_id = CASE WHEN $4 THEN NULLIF($5, 0) ELSE parent_id END,
+			sort_order = COALESCE($6, sort_order),
+			is_visible = COALESCE($7, is_visible),
 			updated_at = NOW()
 		WHERE id = $3
-		RETURNING id, name, business_id, created_at, updated_at`
+		RETURNING ` + categoryColumns
+
+	var parentID int
+	if category.ParentID != nil {
+		parentID = *category.ParentID
+	}
 
 	var updated menu.Category
-	err := r.db.QueryRowContext(ctx, query,
+	err := scanCategory(r.db.QueryRowContext(ctx, query,
 		category.Name,
 		nilOrVal(category.BusinessID),
 		id,
-	).Scan(
-		&updated.ID,
-		&updated.Name,
-		&updated.BusinessID,
-		&updated.CreatedAt,
-		&updated.UpdatedAt,
-	)
+		category.ParentID != nil,
+		parentID,
+		category.SortOrder,
+		category.IsVisible,
+	), &updated)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -563,7 +663,7 @@ func (r *MenuRepository) DeleteCategory(ctx context.Context, id int, businessID
 	if rows == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+	return r.deleteEntityTranslations(ctx, menu.TranslationEntityCategory, id, businessID)
 }
 
 // GetDishByID retrieves a specific dish by its ID
@@ -584,7 +684,7 @@ func (r *MenuRepository) GetDishByID(ctx context.Context, id int) (*menu.MenuIte
 
 	query := `
 		SELECT id, name, price, category_id, image_url, is_available, 
-		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens`
+		       COALESCE(preparation_time, 0), COALESCE(calories, 0), allergens, dietary_tags, cost, sort_order, is_visible`
 
 	// Add description to query only if column exists
 	if hasDescriptionColumn {
@@ -606,6 +706,10 @@ func (r *MenuRepository) GetDishByID(ctx context.Context, id int) (*menu.MenuIte
 		&item.PreparationTime,
 		&item.Calories,
 		pq.Array(&item.Allergens),
+		pq.Array(&item.DietaryTags),
+		&item.Cost,
+		&item.SortOrder,
+		&item.IsVisible,
 	}
 
 	// Add description to scan destinations only if column exists
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+
+	"github.com/lib/pq"
+)
+
+// GetBundles retrieves all bundles of a business with their slots
+func (r *MenuRepository) GetBundles(ctx context.Context, businessID int) ([]menu.Bundle, error) {
+	query := `
+		SELECT id, name, COALESCE(description, ''), price, is_available, business_id, created_at, updated_at
+		FROM bundles
+		WHERE business_id = $1
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying bundles: %w", err)
+	}
+	defer rows.Close()
+
+	bundles := []menu.Bundle{}
+	for rows.Next() {
+		var b menu.Bundle
+		if err := rows.Scan(&b.ID, &b.Name, &b.Description, &b.Price, &b.IsAvailable, &b.BusinessID,
+			&b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning bundle: %w", err)
+		}
+		bundles = append(bundles, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating bundle rows: %w", err)
+	}
+
+	for i := range bundles {
+		if err := r.loadBundleSlots(ctx, &bundles[i]); err != nil {
+			return nil, err
+		}
+	}
+	return bundles, nil
+}
+
+// GetBundleByID retrieves a bundle by its ID
+func (r *MenuRepository) GetBundleByID(ctx context.Context, id int, businessID int) (*menu.Bundle, error) {
+	query := `
+		SELECT id, name, COALESCE(description, ''), price, is_available, business_id, created_at, updated_at
+		FROM bundles
+		WHERE id = $1 AND business_id = $2`
+
+	var b menu.Bundle
+	err := r.db.QueryRowContext(ctx, query, id, businessID).Scan(
+		&b.ID, &b.Name, &b.Description, &b.Price, &b.IsAvailable, &b.BusinessID, &b.CreatedAt, &b.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning bundle by ID: %w", err)
+	}
+
+	if err := r.loadBundleSlots(ctx, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// CreateBundle creates a bundle with its slots in a transaction
+func (r *MenuRepository) CreateBundle(ctx context.Context, b menu.BundleCreate) (*menu.Bundle, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for creating bundle: %v", err)
+		return nil, err
+	}
+
+	isAvailable := true
+	if b.IsAvailable != nil {
+		isAvailable = *b.IsAvailable
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bundles (name, description, price, is_available, business_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id`,
+		b.Name, b.Description, b.Price, isAvailable, b.BusinessID).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("creating bundle: %w", err)
+	}
+
+	if err := replaceBundleSlots(ctx, tx, id, b.Slots); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for creating bundle: %v", err)
+		return nil, err
+	}
+
+	return r.GetBundleByID(ctx, id, b.BusinessID)
+}
+
+// UpdateBundle updates a bundle. Slots are replaced only when provided.
+func (r *MenuRepository) UpdateBundle(ctx context.Context, id int, b menu.BundleUpdate) (*menu.Bundle, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for updating bundle: %v", err)
+		return nil, err
+	}
+
+	var name, price interface{}
+	if b.Name != "" {
+		name = b.Name
+	}
+	if b.Price > 0 {
+		price = b.Price
+	}
+
+	query := `
+		UPDATE bundles
+		SET name = COALESCE($1, name),
+			description = COALESCE($2, description),
+			price = COALESCE($3, price),
+			is_available = COALESCE($4, is_available),
+			updated_at = NOW()
+		WHERE id = $5 AND business_id = $6`
+
+	result, err := tx.ExecContext(ctx, query, name, b.Description, price, b.IsAvailable, id, b.BusinessID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("updating bundle: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	if b.Slots != nil {
+		if err := replaceBundleSlots(ctx, tx, id, b.Slots); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for updating bundle: %v", err)
+		return nil, err
+	}
+
+	return r.GetBundleByID(ctx, id, b.BusinessID)
+}
+
+// DeleteBundle deletes a bundle; its slots are removed by cascade and past
+// orders keep their bundle lines
+func (r *MenuRepository) DeleteBundle(ctx context.Context, id int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM bundles WHERE id = $1 AND business_id = $2`, id, businessID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// loadBundleSlots fills in the slots of a bundle in their display order
+func (r *MenuRepository) loadBundleSlots(ctx context.Context, b *menu.Bundle) error {
+	b.Slots = []menu.BundleSlot{}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, quantity, COALESCE(category_id, 0), dish_ids
+		FROM bundle_slots
+		WHERE bundle_id = $1
+		ORDER BY position, id`, b.ID)
+	if err != nil {
+		return fmt.Errorf("querying bundle slots: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s menu.BundleSlot
+		var dishIDs pq.Int64Array
+		if err := rows.Scan(&s.ID, &s.Name, &s.Quantity, &s.CategoryID, &dishIDs); err != nil {
+			return fmt.Errorf("scanning bundle slot: %w", err)
+		}
+		for _, id := range dishIDs {
+			s.DishIDs = append(s.DishIDs, int(id))
+		}
+		b.Slots = append(b.Slots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating bundle slots: %w", err)
+	}
+	return nil
+}
+
+func replaceBundleSlots(ctx context.Context, tx *sql.Tx, bundleID int, slots []menu.BundleSlot) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM bundle_slots WHERE bundle_id = $1`, bundleID); err != nil {
+		return fmt.Errorf("clearing bundle slots: %w", err)
+	}
+	query := `
+		INSERT INTO bundle_slots (bundle_id, name, position, quantity, category_id, dish_ids)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	for i, s := range slots {
+		if _, err := tx.ExecContext(ctx, query,
+			bundleID, s.Name, i, s.Quantity, nilOrVal(s.CategoryID), pq.Array(s.DishIDs)); err != nil {
+			return fmt.Errorf("inserting bundle slot %q: %w", s.Name, err)
+		}
+	}
+	return nil
+}
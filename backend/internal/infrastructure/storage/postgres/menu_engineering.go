@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-management/internal/domain/menu"
+	"time"
+)
+
+// GetDishSales sums the portions and revenue of each dish and price on orders created in [from, to).
+// Cancelled orders are not sales.
+func (r *MenuRepository) GetDishSales(ctx context.Context, from, to time.Time, businessID int) ([]menu.DishSales, error) {
+	query := `
+		SELECT oi.dish_id, oi.price, SUM(oi.quantity), SUM(oi.quantity * oi.price)
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.business_id = $1 AND o.status <> 'cancelled'
+		  AND o.created_at >= $2 AND o.created_at < $3
+		GROUP BY oi.dish_id, oi.price
+		ORDER BY oi.dish_id, oi.price`
+
+	rows, err := r.db.QueryContext(ctx, query, businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying dish sales: %w", err)
+	}
+	defer rows.Close()
+
+	sales := []menu.DishSales{}
+	for rows.Next() {
+		var s menu.DishSales
+		if err := rows.Scan(&s.DishID, &s.Price, &s.Quantity, &s.Revenue); err != nil {
+			return nil, fmt.Errorf("scanning dish sales: %w", err)
+		}
+		sales = append(sales, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating dish sales rows: %w", err)
+	}
+	return sales, nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ImportMenu creates missing categories and creates or updates dishes matched by
+// name (case-insensitive) in a single transaction. Updated dishes keep the stored value of
+// every optional field left empty in the file. Any failure rolls back the whole import.
+func (r *MenuRepository) ImportMenu(ctx context.Context, data menu.MenuExport, mode menu.ImportMode, businessID int) (*menu.MenuImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for menu import: %v", err)
+		return nil, err
+	}
+
+	result := &menu.MenuImportResult{
+		Mode:      mode,
+		TotalRows: len(data.Dishes),
+		Errors:    []menu.MenuImportRowError{},
+	}
+
+	// Check if description column exists
+	var hasDescriptionColumn bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 
+			FROM information_schema.columns 
+			WHERE table_name = 'dishes' AND column_name = 'description'
+		)`).Scan(&hasDescriptionColumn); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("checking for description column: %w", err)
+	}
+
+	categoryIDs, err := lookupNames(ctx, tx, `SELECT id, name FROM categories WHERE business_id = $1`, businessID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("loading categories for import: %w", err)
+	}
+	dishIDs, err := lookupNames(ctx, tx, `SELECT id, name FROM dishes WHERE business_id = $1`, businessID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("loading dishes for import: %w", err)
+	}
+
+	ensureCategory := func(name string) (int, error) {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if id, ok := categoryIDs[key]; ok {
+			return id, nil
+		}
+		var id int
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO categories (name, business_id, created_at, updated_at)
+			VALUES ($1, $2, NOW(), NOW())
+			RETURNING id`, strings.TrimSpace(name), businessID).Scan(&id)
+		if err != nil {
+			return 0, fmt.Errorf("creating category %q: %w", name, err)
+		}
+		categoryIDs[key] = id
+		result.CategoriesCreated++
+		return id, nil
+	}
+
+	for _, category := range data.Categories {
+		if _, err := ensureCategory(category.Name); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	for _, dish := range data.Dishes {
+		categoryID, err := ensureCategory(dish.Category)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		key := strings.ToLower(strings.TrimSpace(dish.Name))
+		if id, exists := dishIDs[key]; exists {
+			if mode != menu.ImportModeUpsert {
+				tx.Rollback()
+				return nil, menu.ErrMenuItemAlreadyExists
+			}
+			// Fields left empty in the file keep their stored values
+			query := `
+				UPDATE dishes
+				SET category_id = $1, price = $2, allergens = COALESCE($3, allergens), dietary_tags = COALESCE($4, dietary_tags),
+				    preparation_time = COALESCE($5, preparation_time), calories = COALESCE($6, calories),
+				    is_available = COALESCE($7, is_available), updated_at = NOW()`
+			args := []interface{}{
+				categoryID, dish.Price, pq.Array(dish.Allergens), pq.Array(dish.DietaryTags),
+				nilOrVal(dish.PreparationTime), nilOrVal(dish.Calories), dish.IsAvailable, id, businessID,
+			}
+			if hasDescriptionColumn {
+				query += `, description = COALESCE(NULLIF($10, ''), description)`
+				args = append(args, dish.Description)
+			}
+			query += `
+				WHERE id = $8 AND business_id = $9`
+			_, err = tx.ExecContext(ctx, query, args...)
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("updating dish %q: %w", dish.Name, err)
+			}
+			if err := recordPrice(ctx, tx, id, businessID, dish.Price); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			result.ItemsUpdated++
+			continue
+		}
+
+		isAvailable := true
+		if dish.IsAvailable != nil {
+			isAvailable = *dish.IsAvailable
+		}
+
+		columns := `name, category_id, price, allergens, dietary_tags, preparation_time, calories, is_available, business_id`
+		values := `$1, $2, $3, $4, $5, $6, $7, $8, $9`
+		args := []interface{}{
+			strings.TrimSpace(dish.Name), categoryID, dish.Price, pq.Array(dish.Allergens), pq.Array(dish.DietaryTags),
+			nilOrVal(dish.PreparationTime), nilOrVal(dish.Calories), isAvailable, businessID,
+		}
+		if hasDescriptionColumn {
+			columns += `, description`
+			values += `, $10`
+			args = append(args, dish.Description)
+		}
+
+		var id int
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO dishes (`+columns+`, created_at, updated_at)
+			VALUES (`+values+`, NOW(), NOW())
+			RETURNING id`, args...).Scan(&id)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("creating dish %q: %w", dish.Name, err)
+		}
+		if err := recordPrice(ctx, tx, id, businessID, dish.Price); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		dishIDs[key] = id
+		result.ItemsCreated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for menu import: %v", err)
+		return nil, err
+	}
+
+	result.Applied = true
+	return result, nil
+}
+
+// lookupNames maps lower-cased names to IDs for an (id, name) query
+func lookupNames(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (map[string]int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int)
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		ids[strings.ToLower(strings.TrimSpace(name))] = id
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+)
+
+// SetMenuLayout applies a full menu layout in a single transaction. Categories take their
+// position in the layout as sort order, dishes their position within their category.
+func (r *MenuRepository) SetMenuLayout(ctx context.Context, layout menu.MenuLayout, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for menu layout: %v", err)
+		return err
+	}
+
+	for position, category := range layout.Categories {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE categories
+			SET parent_id = $1, sort_order = $2, updated_at = NOW()
+			WHERE id = $3 AND business_id = $4`,
+			nilOrVal(category.ParentID), position, category.ID, businessID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("placing category %d: %w", category.ID, err)
+		}
+
+		for itemPosition, itemID := range category.ItemIDs {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE dishes
+				SET category_id = $1, sort_order = $2, updated_at = NOW()
+				WHERE id = $3 AND business_id = $4`,
+				category.ID, itemPosition, itemID, businessID)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("placing dish %d: %w", itemID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for menu layout: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+	"time"
+)
+
+// execer is satisfied by both the database and a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// recordPrice adds a dish price to its history when it differs from the current one.
+// Direct edits and imports take effect immediately, so the entry is applied right away.
+// The entry belongs to the business making the change, also for shared legacy dishes.
+func recordPrice(ctx context.Context, db execer, dishID, businessID int, price float64) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO dish_prices (dish_id, business_id, price, effective_from, applied_at)
+		SELECT $1, $2, $3::numeric, NOW(), NOW()
+		WHERE $3::numeric IS DISTINCT FROM (
+			SELECT price FROM dish_prices
+			WHERE dish_id = $1 AND applied_at IS NOT NULL
+			ORDER BY applied_at DESC, id DESC
+			LIMIT 1
+		)`,
+		dishID, nilOrVal(businessID), price)
+	if err != nil {
+		return fmt.Errorf("recording price of dish %d: %w", dishID, err)
+	}
+	return nil
+}
+
+func (r *MenuRepository) GetPriceHistory(ctx context.Context, dishID int, businessID int) ([]menu.PriceChange, error) {
+	query := `
+		SELECT id, dish_id, price, effective_from, applied_at, COALESCE(note, ''),
+		       COALESCE(business_id, 0), created_at
+		FROM dish_prices
+		WHERE dish_id = $1 AND (business_id = $2 OR business_id IS NULL)
+		ORDER BY effective_from DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, dishID, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying price history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []menu.PriceChange{}
+	for rows.Next() {
+		var change menu.PriceChange
+		if err := rows.Scan(&change.ID, &change.DishID, &change.Price, &change.EffectiveFrom,
+			&change.AppliedAt, &change.Note, &change.BusinessID, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning price change: %w", err)
+		}
+		change.Status = menu.PriceStatusScheduled
+		if change.AppliedAt != nil {
+			change.Status = menu.PriceStatusApplied
+		}
+		history = append(history, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating price history rows: %w", err)
+	}
+	return history, nil
+}
+
+// SchedulePriceChanges stores pending price changes in a single transaction.
+// They take effect when ApplyDuePriceChanges runs at or after their EffectiveFrom.
+func (r *MenuRepository) SchedulePriceChanges(ctx context.Context, changes []menu.PriceChange) ([]menu.PriceChange, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	scheduled := make([]menu.PriceChange, 0, len(changes))
+	for _, change := range changes {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO dish_prices (dish_id, business_id, price, effective_from, note)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+			RETURNING id, created_at`,
+			change.DishID, change.BusinessID, change.Price, change.EffectiveFrom, change.Note).
+			Scan(&change.ID, &change.CreatedAt)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("scheduling price of dish %d: %w", change.DishID, err)
+		}
+		change.Status = menu.PriceStatusScheduled
+		scheduled = append(scheduled, change)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for price changes: %v", err)
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// DeletePriceChange cancels a price change that has not been applied yet
+func (r *MenuRepository) DeletePriceChange(ctx context.Context, id int, dishID int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM dish_prices
+		WHERE id = $1 AND dish_id = $2 AND business_id = $3 AND applied_at IS NULL`,
+		id, dishID, businessID)
+	if err != nil {
+		return fmt.Errorf("deleting price change: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking deleted price change: %w", err)
+	}
+	if affected == 0 {
+		return menu.ErrPriceChangeNotFound
+	}
+	return nil
+}
+
+// ApplyDuePriceChanges marks every pending change effective at or before the given moment as
+// applied and sets each dish to the latest of its due prices. It returns the number of dishes repriced.
+func (r *MenuRepository) ApplyDuePriceChanges(ctx context.Context, at time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		WITH due AS (
+			UPDATE dish_prices
+			SET applied_at = $1
+			WHERE applied_at IS NULL AND effective_from <= $1
+			RETURNING id, dish_id, price, effective_from
+		), latest AS (
+			SELECT DISTINCT ON (dish_id) dish_id, price
+			FROM due
+			ORDER BY dish_id, effective_from DESC, id DESC
+		)
+		UPDATE dishes d
+		SET price = latest.price, updated_at = NOW()
+		FROM latest
+		WHERE d.id = latest.dish_id`,
+		at)
+	if err != nil {
+		return 0, fmt.Errorf("applying due price changes: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking applied price changes: %w", err)
+	}
+	return int(affected), nil
+}
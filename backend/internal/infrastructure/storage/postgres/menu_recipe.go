@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+
+	"github.com/lib/pq"
+)
+
+// GetRecipe retrieves the ingredients of a dish with their inventory names, units and allergens
+func (r *MenuRepository) GetRecipe(ctx context.Context, itemID int, businessID int) ([]menu.RecipeIngredient, error) {
+	query := `
+		SELECT di.inventory_id, i.name, di.quantity, i.unit, i.allergens, i.unit_cost
+		FROM dish_ingredients di
+		JOIN inventory i ON i.id = di.inventory_id
+		WHERE di.dish_id = $1 AND di.business_id = $2
+		ORDER BY i.name`
+
+	rows, err := r.db.QueryContext(ctx, query, itemID, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying recipe: %w", err)
+	}
+	defer rows.Close()
+
+	ingredients := []menu.RecipeIngredient{}
+	for rows.Next() {
+		var ingredient menu.RecipeIngredient
+		if err := rows.Scan(&ingredient.InventoryID, &ingredient.Name, &ingredient.Quantity, &ingredient.Unit,
+			pq.Array(&ingredient.Allergens), &ingredient.UnitCost); err != nil {
+			return nil, fmt.Errorf("scanning recipe ingredient: %w", err)
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating recipe rows: %w", err)
+	}
+	return ingredients, nil
+}
+
+// SetRecipe replaces the ingredients of a dish in a single transaction. It returns
+// sql.ErrNoRows when an ingredient is not an inventory item of the business.
+func (r *MenuRepository) SetRecipe(ctx context.Context, itemID int, ingredients []menu.RecipeIngredientInput, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for recipe of dish %d: %v", itemID, err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dish_ingredients WHERE dish_id = $1 AND business_id = $2`, itemID, businessID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("clearing recipe: %w", err)
+	}
+
+	for _, ingredient := range ingredients {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO dish_ingredients (dish_id, inventory_id, quantity, business_id)
+			SELECT $1, id, $3, $4
+			FROM inventory
+			WHERE id = $2 AND (business_id = $4 OR business_id IS NULL)`,
+			itemID, ingredient.InventoryID, ingredient.Quantity, businessID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("saving recipe ingredient: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rows == 0 {
+			tx.Rollback()
+			return sql.ErrNoRows
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing recipe of dish %d: %v", itemID, err)
+		return err
+	}
+	return nil
+}
+
+// GetRecipeCosts sums recipe quantities times inventory unit costs. Dishes with an
+// uncosted ingredient are left out rather than reported too cheap.
+func (r *MenuRepository) GetRecipeCosts(ctx context.Context, businessID int) (map[int]float64, error) {
+	query := `
+		SELECT di.dish_id, SUM(di.quantity * i.unit_cost)
+		FROM dish_ingredients di
+		JOIN inventory i ON i.id = di.inventory_id
+		WHERE di.business_id = $1
+		GROUP BY di.dish_id
+		HAVING COUNT(i.unit_cost) = COUNT(*)`
+
+	rows, err := r.db.QueryContext(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying recipe costs: %w", err)
+	}
+	defer rows.Close()
+
+	costs := make(map[int]float64)
+	for rows.Next() {
+		var dishID int
+		var cost float64
+		if err := rows.Scan(&dishID, &cost); err != nil {
+			return nil, fmt.Errorf("scanning recipe cost: %w", err)
+		}
+		costs[dishID] = cost
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating recipe cost rows: %w", err)
+	}
+	return costs, nil
+}
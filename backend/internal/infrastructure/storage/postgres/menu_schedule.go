@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/menu"
+
+	"github.com/lib/pq"
+)
+
+// GetMenus retrieves all scheduled menus of a business with their categories, items and schedules
+func (r *MenuRepository) GetMenus(ctx context.Context, businessID int) ([]menu.Menu, error) {
+	query := `
+		SELECT id, name, COALESCE(description, ''), is_active, business_id, created_at, updated_at
+		FROM menus
+		WHERE business_id = $1
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying menus: %w", err)
+	}
+	defer rows.Close()
+
+	var menus []menu.Menu
+	for rows.Next() {
+		var m menu.Menu
+		if err := rows.Scan(&m.ID, &m.Name, &m.Description, &m.IsActive, &m.BusinessID, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning menu: %w", err)
+		}
+		menus = append(menus, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating menu rows: %w", err)
+	}
+
+	for i := range menus {
+		if err := r.loadMenuRelations(ctx, &menus[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return menus, nil
+}
+
+// GetMenuByID retrieves a scheduled menu by its ID
+func (r *MenuRepository) GetMenuByID(ctx context.Context, id int, businessID int) (*menu.Menu, error) {
+	query := `
+		SELECT id, name, COALESCE(description, ''), is_active, business_id, created_at, updated_at
+		FROM menus
+		WHERE id = $1 AND business_id = $2`
+
+	var m menu.Menu
+	err := r.db.QueryRowContext(ctx, query, id, businessID).Scan(
+		&m.ID, &m.Name, &m.Description, &m.IsActive, &m.BusinessID, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning menu by ID: %w", err)
+	}
+
+	if err := r.loadMenuRelations(ctx, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateMenu creates a scheduled menu with its categories, items and schedules in a transaction
+func (r *MenuRepository) CreateMenu(ctx context.Context, m menu.MenuCreate) (*menu.Menu, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for creating menu: %v", err)
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO menus (name, description, is_active, business_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id`
+
+	var id int
+	if err := tx.QueryRowContext(ctx, query, m.Name, m.Description, m.IsActive, m.BusinessID).Scan(&id); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("creating menu: %w", err)
+	}
+
+	if err := replaceMenuRelations(ctx, tx, id, m.CategoryIDs, m.ItemIDs, m.Schedules); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for creating menu: %v", err)
+		return nil, err
+	}
+
+	return r.GetMenuByID(ctx, id, m.BusinessID)
+}
+
+// UpdateMenu updates a scheduled menu. Relations are replaced only when provided.
+func (r *MenuRepository) UpdateMenu(ctx context.Context, id int, m menu.MenuUpdate) (*menu.Menu, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for updating menu: %v", err)
+		return nil, err
+	}
+
+	var name interface{}
+	if m.Name != "" {
+		name = m.Name
+	}
+
+	query := `
+		UPDATE menus
+		SET name = COALESCE($1, name),
+			description = COALESCE($2, description),
+			is_active = COALESCE($3, is_active),
+			updated_at = NOW()
+		WHERE id = $4 AND business_id = $5`
+
+	result, err := tx.ExecContext(ctx, query, name, m.Description, m.IsActive, id, m.BusinessID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("updating menu: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	if m.CategoryIDs != nil {
+		if err := replaceMenuCategories(ctx, tx, id, m.CategoryIDs); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if m.ItemIDs != nil {
+		if err := replaceMenuDishes(ctx, tx, id, m.ItemIDs); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if m.Schedules != nil {
+		if err := replaceMenuSchedules(ctx, tx, id, m.Schedules); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for updating menu: %v", err)
+		return nil, err
+	}
+
+	return r.GetMenuByID(ctx, id, m.BusinessID)
+}
+
+// DeleteMenu deletes a scheduled menu; its relations are removed by cascade
+func (r *MenuRepository) DeleteMenu(ctx context.Context, id int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM menus WHERE id = $1 AND business_id = $2`, id, businessID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// loadMenuRelations fills in the categories, items and schedules of a menu
+func (r *MenuRepository) loadMenuRelations(ctx context.Context, m *menu.Menu) error {
+	m.CategoryIDs = []int{}
+	m.ItemIDs = []int{}
+	m.Schedules = []menu.MenuSchedule{}
+
+	categoryRows, err := r.db.QueryContext(ctx, `SELECT category_id FROM menu_categories WHERE menu_id = $1 ORDER BY category_id`, m.ID)
+	if err != nil {
+		return fmt.Errorf("querying menu categories: %w", err)
+	}
+	defer categoryRows.Close()
+	for categoryRows.Next() {
+		var categoryID int
+		if err := categoryRows.Scan(&categoryID); err != nil {
+			return fmt.Errorf("scanning menu category: %w", err)
+		}
+		m.CategoryIDs = append(m.CategoryIDs, categoryID)
+	}
+	if err := categoryRows.Err(); err != nil {
+		return fmt.Errorf("iterating menu categories: %w", err)
+	}
+
+	dishRows, err := r.db.QueryContext(ctx, `SELECT dish_id FROM menu_dishes WHERE menu_id = $1 ORDER BY dish_id`, m.ID)
+	if err != nil {
+		return fmt.Errorf("querying menu dishes: %w", err)
+	}
+	defer dishRows.Close()
+	for dishRows.Next() {
+		var dishID int
+		if err := dishRows.Scan(&dishID); err != nil {
+			return fmt.Errorf("scanning menu dish: %w", err)
+		}
+		m.ItemIDs = append(m.ItemIDs, dishID)
+	}
+	if err := dishRows.Err(); err != nil {
+		return fmt.Errorf("iterating menu dishes: %w", err)
+	}
+
+	scheduleRows, err := r.db.QueryContext(ctx, `
+		SELECT id, days_of_week,
+		       COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''),
+		       COALESCE(to_char(start_date, 'YYYY-MM-DD'), ''), COALESCE(to_char(end_date, 'YYYY-MM-DD'), '')
+		FROM menu_schedules
+		WHERE menu_id = $1
+		ORDER BY id`, m.ID)
+	if err != nil {
+		return fmt.Errorf("querying menu schedules: %w", err)
+	}
+	defer scheduleRows.Close()
+	for scheduleRows.Next() {
+		var s menu.MenuSchedule
+		var days pq.Int64Array
+		if err := scheduleRows.Scan(&s.ID, &days, &s.StartTime, &s.EndTime, &s.StartDate, &s.EndDate); err != nil {
+			return fmt.Errorf("scanning menu schedule: %w", err)
+		}
+		for _, d := range days {
+			s.DaysOfWeek = append(s.DaysOfWeek, int(d))
+		}
+		m.Schedules = append(m.Schedules, s)
+	}
+	if err := scheduleRows.Err(); err != nil {
+		return fmt.Errorf("iterating menu schedules: %w", err)
+	}
+
+	return nil
+}
+
+func replaceMenuRelations(ctx context.Context, tx *sql.Tx, menuID int, categoryIDs, itemIDs []int, schedules []menu.MenuSchedule) error {
+	if err := replaceMenuCategories(ctx, tx, menuID, categoryIDs); err != nil {
+		return err
+	}
+	if err := replaceMenuDishes(ctx, tx, menuID, itemIDs); err != nil {
+		return err
+	}
+	return replaceMenuSchedules(ctx, tx, menuID, schedules)
+}
+
+func replaceMenuCategories(ctx context.Context, tx *sql.Tx, menuID int, categoryIDs []int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_categories WHERE menu_id = $1`, menuID); err != nil {
+		return fmt.Errorf("clearing menu categories: %w", err)
+	}
+	for _, categoryID := range categoryIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO menu_categories (menu_id, category_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			menuID, categoryID); err != nil {
+			return fmt.Errorf("inserting menu category %d: %w", categoryID, err)
+		}
+	}
+	return nil
+}
+
+func replaceMenuDishes(ctx context.Context, tx *sql.Tx, menuID int, dishIDs []int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_dishes WHERE menu_id = $1`, menuID); err != nil {
+		return fmt.Errorf("clearing menu dishes: %w", err)
+	}
+	for _, dishID := range dishIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO menu_dishes (menu_id, dish_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			menuID, dishID); err != nil {
+			return fmt.Errorf("inserting menu dish %d: %w", dishID, err)
+		}
+	}
+	return nil
+}
+
+func replaceMenuSchedules(ctx context.Context, tx *sql.Tx, menuID int, schedules []menu.MenuSchedule) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_schedules WHERE menu_id = $1`, menuID); err != nil {
+		return fmt.Errorf("clearing menu schedules: %w", err)
+	}
+	query := `
+		INSERT INTO menu_schedules (menu_id, days_of_week, start_time, end_time, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	for _, s := range schedules {
+		if _, err := tx.ExecContext(ctx, query,
+			menuID,
+			pq.Array(s.DaysOfWeek),
+			nilOrString(s.StartTime),
+			nilOrString(s.EndTime),
+			nilOrString(s.StartDate),
+			nilOrString(s.EndDate),
+		); err != nil {
+			return fmt.Errorf("inserting menu schedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// Helper function to handle empty string values
+func nilOrString(val string) interface{} {
+	if val == "" {
+		return nil
+	}
+	return val
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// searchSimilarity is the minimum trigram word similarity between the query and a dish name
+// for the dish to match without a full-text hit
+const searchSimilarity = 0.3
+
+// SearchMenuItems ranks dishes by full-text rank over the search vector, kept up to date by the
+// add_menu_search migration, plus the trigram similarity of the query to the dish name.
+// Hidden dishes and dishes in a hidden category, or below one, are left out.
+func (r *MenuRepository) SearchMenuItems(ctx context.Context, search menu.MenuSearch, businessID int) ([]menu.MenuItem, error) {
+	// Check if description column exists
+	var hasDescriptionColumn bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 
+			FROM information_schema.columns 
+			WHERE table_name = 'dishes' AND column_name = 'description'
+		)`).Scan(&hasDescriptionColumn)
+
+	if err != nil {
+		return nil, fmt.Errorf("checking for description column: %w", err)
+	}
+
+	prefixes := make([]string, len(search.Terms))
+	for i, term := range search.Terms {
+		prefixes[i] = term + ":*"
+	}
+
+	query := `
+		WITH RECURSIVE hidden_categories AS (
+			SELECT id FROM categories WHERE business_id = $3 AND NOT is_visible
+			UNION
+			SELECT c.id FROM categories c JOIN hidden_categories h ON c.parent_id = h.id
+		)
+		SELECT d.id, d.name, d.price, d.category_id, d.image_url, d.is_available,
+		       COALESCE(d.preparation_time, 0), COALESCE(d.calories, 0), d.allergens, d.dietary_tags, d.cost,
+		       d.sort_order, d.is_visible, `
+
+	if hasDescriptionColumn {
+		query += `COALESCE(d.description, ''), `
+	}
+
+	query += `COALESCE(d.business_id, 0), d.created_at, d.updated_at
+		FROM dishes d, to_tsquery('simple', $1) q
+		WHERE d.business_id = $3 AND d.is_visible
+		  AND d.category_id NOT IN (SELECT id FROM hidden_categories)
+		  AND (d.search_vector @@ q OR word_similarity($2, d.name) >= $4)
+		ORDER BY ts_rank(d.search_vector, q) + word_similarity($2, d.name) DESC, d.name
+		LIMIT $5`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		strings.Join(prefixes, " & "), search.Query, businessID, searchSimilarity, search.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching menu items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []menu.MenuItem{}
+	for rows.Next() {
+		var item menu.MenuItem
+		scanDest := []interface{}{
+			&item.ID, &item.Name, &item.Price, &item.CategoryID, &item.ImageURL, &item.IsAvailable,
+			&item.PreparationTime, &item.Calories, pq.Array(&item.Allergens), pq.Array(&item.DietaryTags), &item.Cost,
+			&item.SortOrder, &item.IsVisible,
+		}
+		if hasDescriptionColumn {
+			scanDest = append(scanDest, &item.Description)
+		}
+		scanDest = append(scanDest, &item.BusinessID, &item.CreatedAt, &item.UpdatedAt)
+		if err := rows.Scan(scanDest...); err != nil {
+			return nil, fmt.Errorf("scanning menu search result: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating menu search results: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/menu"
+)
+
+// GetTranslations retrieves all translations of a business into one language, or into
+// every language when language is empty
+func (r *MenuRepository) GetTranslations(ctx context.Context, language string, businessID int) ([]menu.Translation, error) {
+	query := `
+		SELECT id, entity_type, entity_id, language, COALESCE(name, ''), COALESCE(description, ''),
+		       business_id, updated_at
+		FROM menu_translations
+		WHERE business_id = $1 AND ($2 = '' OR language = $2)
+		ORDER BY entity_type, entity_id, language`
+
+	return r.queryTranslations(ctx, query, businessID, language)
+}
+
+// GetEntityTranslations retrieves every translation of one category or dish
+func (r *MenuRepository) GetEntityTranslations(ctx context.Context, entityType string, entityID int, businessID int) ([]menu.Translation, error) {
+	query := `
+		SELECT id, entity_type, entity_id, language, COALESCE(name, ''), COALESCE(description, ''),
+		       business_id, updated_at
+		FROM menu_translations
+		WHERE entity_type = $1 AND entity_id = $2 AND business_id = $3
+		ORDER BY language`
+
+	return r.queryTranslations(ctx, query, entityType, entityID, businessID)
+}
+
+// UpsertTranslation creates a translation or replaces the existing one for the same entity and language
+func (r *MenuRepository) UpsertTranslation(ctx context.Context, t menu.Translation) (*menu.Translation, error) {
+	query := `
+		INSERT INTO menu_translations (entity_type, entity_id, language, name, description, business_id, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (entity_type, entity_id, language)
+		DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = NOW()
+		RETURNING id, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		t.EntityType, t.EntityID, t.Language, nilOrString(t.Name), nilOrString(t.Description), t.BusinessID,
+	).Scan(&t.ID, &t.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("saving translation: %w", err)
+	}
+	return &t, nil
+}
+
+// DeleteTranslation removes the translation of an entity into one language
+func (r *MenuRepository) DeleteTranslation(ctx context.Context, entityType string, entityID int, language string, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM menu_translations
+		WHERE entity_type = $1 AND entity_id = $2 AND language = $3 AND business_id = $4`,
+		entityType, entityID, language, businessID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetCatalogueTranslations retrieves the allergen and dietary tag names of a business in
+// one language, or in all languages when language is empty
+func (r *MenuRepository) GetCatalogueTranslations(ctx context.Context, language string, businessID int) ([]menu.CatalogueTranslation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT code, language, name, business_id, updated_at
+		FROM catalogue_translations
+		WHERE business_id = $1 AND ($2 = '' OR language = $2)
+		ORDER BY code, language`,
+		businessID, language)
+	if err != nil {
+		return nil, fmt.Errorf("querying catalogue translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []menu.CatalogueTranslation{}
+	for rows.Next() {
+		var t menu.CatalogueTranslation
+		if err := rows.Scan(&t.Code, &t.Language, &t.Name, &t.BusinessID, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning catalogue translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating catalogue translation rows: %w", err)
+	}
+	return translations, nil
+}
+
+// UpsertCatalogueTranslation names a catalogue code in a language, replacing an earlier name
+func (r *MenuRepository) UpsertCatalogueTranslation(ctx context.Context, t menu.CatalogueTranslation) (*menu.CatalogueTranslation, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO catalogue_translations (business_id, code, language, name, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (business_id, code, language)
+		DO UPDATE SET name = EXCLUDED.name, updated_at = NOW()
+		RETURNING updated_at`,
+		t.BusinessID, t.Code, t.Language, t.Name,
+	).Scan(&t.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("saving catalogue translation: %w", err)
+	}
+	return &t, nil
+}
+
+// DeleteCatalogueTranslation removes the name of a catalogue code in one language
+func (r *MenuRepository) DeleteCatalogueTranslation(ctx context.Context, code string, language string, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM catalogue_translations
+		WHERE business_id = $1 AND code = $2 AND language = $3`,
+		businessID, code, language)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// deleteEntityTranslations removes the translations of a deleted category or dish
+func (r *MenuRepository) deleteEntityTranslations(ctx context.Context, entityType string, entityID int, businessID int) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM menu_translations
+		WHERE entity_type = $1 AND entity_id = $2 AND business_id = $3`,
+		entityType, entityID, businessID)
+	return err
+}
+
+func (r *MenuRepository) queryTranslations(ctx context.Context, query string, args ...interface{}) ([]menu.Translation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []menu.Translation{}
+	for rows.Next() {
+		var t menu.Translation
+		if err := rows.Scan(&t.ID, &t.EntityType, &t.EntityID, &t.Language, &t.Name, &t.Description,
+			&t.BusinessID, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating translation rows: %w", err)
+	}
+	return translations, nil
+}
@@ -21,11 +21,25 @@ func NewOrderRepository(db *DB) order.Repository {
 	return &OrderRepository{db: db}
 }
 
-// GetDishByID retrieves a specific dish by its ID.
+// GetDishByID retrieves a specific dish by its ID. The dish counts as hidden when it or
+// any category above it is hidden, as on the menu screens.
 func (r *OrderRepository) GetDishByID(ctx context.Context, id int) (*order.Dish, error) {
+	query := `
+		SELECT d.id, d.name, d.category_id, d.price, d.is_available,
+		       d.is_visible AND NOT EXISTS (
+		           WITH RECURSIVE ancestors AS (
+		               SELECT id, parent_id, is_visible FROM categories WHERE id = d.category_id
+		               UNION
+		               SELECT c.id, c.parent_id, c.is_visible FROM categories c JOIN ancestors a ON c.id = a.parent_id
+		           )
+		           SELECT 1 FROM ancestors WHERE NOT is_visible
+		       ),
+		       COALESCE(d.business_id, 0)
+		FROM dishes d
+		WHERE d.id = $1`
 	dish := &order.Dish{}
-	err := r.db.QueryRowContext(ctx, "SELECT id, name, category_id, price, is_available FROM dishes WHERE id = $1", id).
-		Scan(&dish.ID, &dish.Name, &dish.CategoryID, &dish.Price, &dish.IsAvailable)
+	err := r.db.QueryRowContext(ctx, query, id).
+		Scan(&dish.ID, &dish.Name, &dish.CategoryID, &dish.Price, &dish.IsAvailable, &dish.IsVisible, &dish.BusinessID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("dish with ID %d not found", id)
@@ -39,8 +53,8 @@ func (r *OrderRepository) GetDishByID(ctx context.Context, id int) (*order.Dish,
 // GetActiveOrdersWithItems retrieves all active orders along with their items.
 func (r *OrderRepository) GetActiveOrdersWithItems(ctx context.Context, businessID int) ([]order.Order, error) {
 	query := `
-        SELECT o.id, o.table_id, o.waiter_id, o.status, o.comment, o.total_amount, 
-               o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+        SELECT o.id, COALESCE(o.table_id, 0), COALESCE(o.waiter_id, 0), o.status, o.comment, o.total_amount, 
+               o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.allergies, o.seat_allergies, o.source,
                COALESCE(
                    json_agg(
                        json_build_object(
@@ -50,10 +64,13 @@ func (r *OrderRepository) GetActiveOrdersWithItems(ctx context.Context, business
                            'quantity', oi.quantity,
                            'price', oi.price,
                            'total', (oi.quantity * oi.price),
-                           'notes', oi.notes
+                           'notes', oi.notes,
+                           'seat', oi.seat,
+                           'order_bundle_id', oi.order_bundle_id,
+                           'allergen_conflicts', oi.allergen_conflicts
                        )
                    ) FILTER (WHERE oi.id IS NOT NULL), '[]'::json
-               ) as items
+               ) as items, ` + orderBundlesColumn + `
         FROM orders o
         LEFT JOIN order_items oi ON o.id = oi.order_id
         LEFT JOIN dishes d ON oi.dish_id = d.id
@@ -73,12 +90,15 @@ func (r *OrderRepository) GetActiveOrdersWithItems(ctx context.Context, business
 	for rows.Next() {
 		var o order.Order
 		var itemsJSON []byte
+		var seatAllergiesJSON []byte
+		var bundlesJSON []byte
 		var completedAt pq.NullTime
 		var cancelledAt pq.NullTime
 
 		err := rows.Scan(
 			&o.ID, &o.TableID, &o.WaiterID, &o.Status, &o.Comment, &o.TotalAmount,
-			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt, &itemsJSON,
+			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt,
+			pq.Array(&o.Allergies), &seatAllergiesJSON, &o.Source, &itemsJSON, &bundlesJSON,
 		)
 		if err != nil {
 			log.Printf("Error scanning active order row: %v", err)
@@ -96,6 +116,13 @@ func (r *OrderRepository) GetActiveOrdersWithItems(ctx context.Context, business
 			log.Printf("Error unmarshalling order items for order %d: %v", o.ID, err)
 			return nil, err
 		}
+		if err := unmarshalSeatAllergies(seatAllergiesJSON, &o); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bundlesJSON, &o.Bundles); err != nil {
+			log.Printf("Error unmarshalling bundles for order %d: %v", o.ID, err)
+			return nil, err
+		}
 		orders = append(orders, o)
 	}
 	if err = rows.Err(); err != nil {
@@ -117,8 +144,8 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int, businessID .
 	}
 
 	query := `
-        SELECT o.id, o.table_id, o.waiter_id, o.status, o.comment, o.total_amount, 
-               o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+        SELECT o.id, COALESCE(o.table_id, 0), COALESCE(o.waiter_id, 0), o.status, o.comment, o.total_amount, 
+               o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.allergies, o.seat_allergies, o.source,
                COALESCE(
                    json_agg(
                        json_build_object(
@@ -128,10 +155,13 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int, businessID .
                            'quantity', oi.quantity,
                            'price', oi.price,
                            'total', (oi.quantity * oi.price),
-						   'notes', oi.notes
+						   'notes', oi.notes,
+                           'seat', oi.seat,
+                           'order_bundle_id', oi.order_bundle_id,
+                           'allergen_conflicts', oi.allergen_conflicts
                        )
                    ) FILTER (WHERE oi.id IS NOT NULL), '[]'::json
-               ) as items
+               ) as items, ` + orderBundlesColumn + `
         FROM orders o
         LEFT JOIN order_items oi ON o.id = oi.order_id
         LEFT JOIN dishes d ON oi.dish_id = d.id
@@ -140,11 +170,14 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int, businessID .
 
 	var o order.Order
 	var itemsJSON []byte
+	var seatAllergiesJSON []byte
+	var bundlesJSON []byte
 	var completedAt, cancelledAt pq.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&o.ID, &o.TableID, &o.WaiterID, &o.Status, &o.Comment, &o.TotalAmount,
-		&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt, &itemsJSON,
+		&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt,
+		pq.Array(&o.Allergies), &seatAllergiesJSON, &o.Source, &itemsJSON, &bundlesJSON,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -165,6 +198,13 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int, businessID .
 		log.Printf("Error unmarshalling items for order %d: %v", o.ID, err)
 		return nil, err
 	}
+	if err := unmarshalSeatAllergies(seatAllergiesJSON, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bundlesJSON, &o.Bundles); err != nil {
+		log.Printf("Error unmarshalling bundles for order %d: %v", o.ID, err)
+		return nil, err
+	}
 	return &o, nil
 }
 
@@ -181,20 +221,48 @@ func (r *OrderRepository) CreateOrderAndItems(ctx context.Context, o *order.Orde
 	o.CreatedAt = now
 	o.UpdatedAt = now
 
-	orderSQL := `INSERT INTO orders (table_id, waiter_id, status, comment, total_amount, created_at, updated_at, business_id)
-                 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`
-	err = tx.QueryRowContext(ctx, orderSQL, o.TableID, o.WaiterID, o.Status, o.Comment, o.TotalAmount, o.CreatedAt, o.UpdatedAt, businessID).Scan(&o.ID, &o.CreatedAt, &o.UpdatedAt)
+	seatAllergies, err := marshalSeatAllergies(o.SeatAllergies)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	orderSQL := `INSERT INTO orders (table_id, waiter_id, status, comment, total_amount, created_at, updated_at, business_id,
+                                     allergies, seat_allergies, source)
+                 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at`
+	err = tx.QueryRowContext(ctx, orderSQL, o.TableID, nullableWaiterID(o.WaiterID), o.Status, o.Comment, o.TotalAmount, o.CreatedAt, o.UpdatedAt, businessID,
+		pq.Array(o.Allergies), seatAllergies, o.Source).Scan(&o.ID, &o.CreatedAt, &o.UpdatedAt)
 	if err != nil {
 		tx.Rollback()
 		log.Printf("Error inserting order: %v", err)
 		return nil, err
 	}
 
-	itemSQL := `INSERT INTO order_items (order_id, dish_id, quantity, price, notes, business_id)
-                VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	// Bundle lines first, so that their components can refer to them
+	bundleSQL := `INSERT INTO order_bundles (order_id, bundle_id, name, quantity, price, seat, notes, business_id)
+                  VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	for b := range o.Bundles {
+		bundle := &o.Bundles[b]
+		err = tx.QueryRowContext(ctx, bundleSQL, o.ID, bundle.BundleID, bundle.Name, bundle.Quantity, bundle.Price,
+			bundle.Seat, bundle.Notes, businessID).Scan(&bundle.ID)
+		if err != nil {
+			tx.Rollback()
+			log.Printf("Error inserting order bundle: %v", err)
+			return nil, err
+		}
+		for _, item := range bundle.Items {
+			item.OrderBundleID = bundle.ID
+			o.Items = append(o.Items, item)
+		}
+		bundle.Items = nil
+	}
+
+	itemSQL := `INSERT INTO order_items (order_id, dish_id, quantity, price, notes, business_id, seat, allergen_conflicts, order_bundle_id)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 	for i := range o.Items {
 		item := &o.Items[i]
-		err = tx.QueryRowContext(ctx, itemSQL, o.ID, item.DishID, item.Quantity, item.Price, item.Notes, businessID).Scan(&item.ID)
+		err = tx.QueryRowContext(ctx, itemSQL, o.ID, item.DishID, item.Quantity, item.Price, item.Notes, businessID,
+			item.Seat, pq.Array(item.AllergenConflicts), nilOrVal(item.OrderBundleID)).Scan(&item.ID)
 		if err != nil {
 			tx.Rollback()
 			return nil, err
@@ -215,7 +283,8 @@ func (r *OrderRepository) UpdateOrder(ctx context.Context, o *order.Order) error
 	query := `
         UPDATE orders 
         SET status = $1, comment = $2, total_amount = $3, 
-            updated_at = $4, completed_at = $5, cancelled_at = $6
+            updated_at = $4, completed_at = $5, cancelled_at = $6,
+            waiter_id = COALESCE(waiter_id, $8)
         WHERE id = $7`
 
 	o.UpdatedAt = time.Now()
@@ -223,7 +292,7 @@ func (r *OrderRepository) UpdateOrder(ctx context.Context, o *order.Order) error
 	_, err := r.db.ExecContext(ctx, query,
 		o.Status, o.Comment, o.TotalAmount,
 		o.UpdatedAt, o.CompletedAt, o.CancelledAt,
-		o.ID,
+		o.ID, nullableWaiterID(o.WaiterID),
 	)
 	if err != nil {
 		log.Printf("Error updating order ID %d: %v", o.ID, err)
@@ -262,8 +331,8 @@ func (r *OrderRepository) GetOrderStatus(ctx context.Context, businessID int) (*
 // GetOrderHistoryWithItems retrieves completed or cancelled orders along with their items.
 func (r *OrderRepository) GetOrderHistoryWithItems(ctx context.Context, businessID int) ([]order.Order, error) {
 	query := `
-    	SELECT o.id, o.table_id, o.waiter_id, o.status, o.comment, o.total_amount, 
-       o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+    	SELECT o.id, COALESCE(o.table_id, 0), COALESCE(o.waiter_id, 0), o.status, o.comment, o.total_amount, 
+       o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.allergies, o.seat_allergies, o.source,
        COALESCE(
            json_agg(
                json_build_object(
@@ -273,10 +342,13 @@ func (r *OrderRepository) GetOrderHistoryWithItems(ctx context.Context, business
                    'quantity', oi.quantity,
                    'price', oi.price,
                    'total', oi.quantity * oi.price,
-                   'notes', oi.notes
+                   'notes', oi.notes,
+                   'seat', oi.seat,
+                   'order_bundle_id', oi.order_bundle_id,
+                   'allergen_conflicts', oi.allergen_conflicts
                )
            ) FILTER (WHERE oi.id IS NOT NULL), '[]'::json
-       ) as items
+       ) as items, ` + orderBundlesColumn + `
 		FROM orders o
 		LEFT JOIN order_items oi ON o.id = oi.order_id
 		LEFT JOIN dishes d ON oi.dish_id = d.id 
@@ -296,11 +368,14 @@ func (r *OrderRepository) GetOrderHistoryWithItems(ctx context.Context, business
 	for rows.Next() {
 		var o order.Order
 		var itemsJSON []byte
+		var seatAllergiesJSON []byte
+		var bundlesJSON []byte
 		var completedAt, cancelledAt pq.NullTime
 
 		err := rows.Scan(
 			&o.ID, &o.TableID, &o.WaiterID, &o.Status, &o.Comment, &o.TotalAmount,
-			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt, &itemsJSON,
+			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt,
+			pq.Array(&o.Allergies), &seatAllergiesJSON, &o.Source, &itemsJSON, &bundlesJSON,
 		)
 		if err != nil {
 			log.Printf("Error scanning historical order: %v", err)
@@ -318,6 +393,13 @@ func (r *OrderRepository) GetOrderHistoryWithItems(ctx context.Context, business
 			log.Printf("Error unmarshalling items for historical order %d: %v", o.ID, err)
 			return nil, err
 		}
+		if err := unmarshalSeatAllergies(seatAllergiesJSON, &o); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bundlesJSON, &o.Bundles); err != nil {
+			log.Printf("Error unmarshalling bundles for order %d: %v", o.ID, err)
+			return nil, err
+		}
 		orders = append(orders, o)
 	}
 	if err = rows.Err(); err != nil {
@@ -349,8 +431,8 @@ func (r *OrderRepository) IsLastActiveOrderForTable(ctx context.Context, tableID
 // GetOrdersByStatus retrieves all orders with a specific status along with their items and dish categories.
 func (r *OrderRepository) GetOrdersByStatus(ctx context.Context, status string, businessID int) ([]order.Order, error) {
 	query := `
-        SELECT o.id, o.table_id, o.waiter_id, o.status, o.comment, o.total_amount, 
-               o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+        SELECT o.id, COALESCE(o.table_id, 0), COALESCE(o.waiter_id, 0), o.status, o.comment, o.total_amount, 
+               o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.allergies, o.seat_allergies, o.source,
                COALESCE(
                    json_agg(
                        json_build_object(
@@ -361,10 +443,13 @@ func (r *OrderRepository) GetOrdersByStatus(ctx context.Context, status string,
                            'quantity', oi.quantity,
                            'price', oi.price,
                            'total', (oi.quantity * oi.price),
-                           'notes', oi.notes
+                           'notes', oi.notes,
+                           'seat', oi.seat,
+                           'order_bundle_id', oi.order_bundle_id,
+                           'allergen_conflicts', oi.allergen_conflicts
                        )
                    ) FILTER (WHERE oi.id IS NOT NULL), '[]'::json
-               ) as items
+               ) as items, ` + orderBundlesColumn + `
         FROM orders o
         LEFT JOIN order_items oi ON o.id = oi.order_id
         LEFT JOIN dishes d ON oi.dish_id = d.id
@@ -384,11 +469,14 @@ func (r *OrderRepository) GetOrdersByStatus(ctx context.Context, status string,
 	for rows.Next() {
 		var o order.Order
 		var itemsJSON []byte
+		var seatAllergiesJSON []byte
+		var bundlesJSON []byte
 		var completedAt, cancelledAt sql.NullTime
 
 		err := rows.Scan(
 			&o.ID, &o.TableID, &o.WaiterID, &o.Status, &o.Comment, &o.TotalAmount,
-			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt, &itemsJSON,
+			&o.CreatedAt, &o.UpdatedAt, &completedAt, &cancelledAt,
+			pq.Array(&o.Allergies), &seatAllergiesJSON, &o.Source, &itemsJSON, &bundlesJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -402,7 +490,61 @@ func (r *OrderRepository) GetOrdersByStatus(ctx context.Context, status string,
 		if err := json.Unmarshal(itemsJSON, &o.Items); err != nil {
 			return nil, err
 		}
+		if err := unmarshalSeatAllergies(seatAllergiesJSON, &o); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bundlesJSON, &o.Bundles); err != nil {
+			log.Printf("Error unmarshalling bundles for order %d: %v", o.ID, err)
+			return nil, err
+		}
 		orders = append(orders, o)
 	}
 	return orders, nil
 }
+
+// marshalSeatAllergies encodes per-seat allergies for the JSONB column, storing NULL when there are none
+func marshalSeatAllergies(seats []order.SeatAllergies) (interface{}, error) {
+	if len(seats) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(seats)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// nullableWaiterID stores NULL for orders placed by guests, which have no waiter until one accepts them
+func nullableWaiterID(waiterID int) interface{} {
+	if waiterID <= 0 {
+		return nil
+	}
+	return waiterID
+}
+
+// orderBundlesColumn selects the bundle lines of the order o as a JSON array
+const orderBundlesColumn = `(
+               SELECT COALESCE(json_agg(json_build_object(
+                   'id', ob.id,
+                   'bundle_id', COALESCE(ob.bundle_id, 0),
+                   'name', ob.name,
+                   'quantity', ob.quantity,
+                   'price', ob.price,
+                   'total', ob.quantity * ob.price,
+                   'seat', ob.seat,
+                   'notes', ob.notes
+               ) ORDER BY ob.id), '[]'::json)
+               FROM order_bundles ob
+               WHERE ob.order_id = o.id
+           ) as bundles`
+
+func unmarshalSeatAllergies(data []byte, o *order.Order) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.SeatAllergies); err != nil {
+		log.Printf("Error unmarshalling seat allergies for order %d: %v", o.ID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/reservation"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ReservationRepository struct {
+	db *DB
+}
+
+func NewReservationRepository(db *DB) reservation.Repository {
+	return &ReservationRepository{db: db}
+}
+
+const reservationColumns = `r.id, r.business_id, r.guest_name, r.phone, r.party_size, r.starts_at, r.ends_at,
+	COALESCE(r.notes, ''), r.status, r.created_at, r.updated_at,
+	ARRAY(SELECT rt.table_id FROM reservation_tables rt WHERE rt.reservation_id = r.id ORDER BY rt.table_id)`
+
+func scanReservation(row rowScanner, res *reservation.Reservation) error {
+	var tableIDs pq.Int64Array
+	if err := row.Scan(
+		&res.ID, &res.BusinessID, &res.GuestName, &res.Phone, &res.PartySize, &res.StartsAt, &res.EndsAt,
+		&res.Notes, &res.Status, &res.CreatedAt, &res.UpdatedAt, &tableIDs,
+	); err != nil {
+		return err
+	}
+	res.Duration = int(res.EndsAt.Sub(res.StartsAt) / time.Minute)
+	res.TableIDs = make([]int, len(tableIDs))
+	for i, id := range tableIDs {
+		res.TableIDs[i] = int(id)
+	}
+	return nil
+}
+
+func (r *ReservationRepository) GetReservations(ctx context.Context, from, to time.Time, businessID int) ([]reservation.Reservation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reservationColumns+`
+		FROM reservations r
+		WHERE r.business_id = $1 AND r.starts_at >= $2 AND r.starts_at < $3
+		ORDER BY r.starts_at, r.id`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying reservations: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []reservation.Reservation{}
+	for rows.Next() {
+		var res reservation.Reservation
+		if err := scanReservation(rows, &res); err != nil {
+			return nil, fmt.Errorf("scanning reservation: %w", err)
+		}
+		reservations = append(reservations, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+func (r *ReservationRepository) GetReservationByID(ctx context.Context, id int, businessID int) (*reservation.Reservation, error) {
+	var res reservation.Reservation
+	err := scanReservation(r.db.QueryRowContext(ctx, `
+		SELECT `+reservationColumns+`
+		FROM reservations r
+		WHERE r.id = $1 AND r.business_id = $2`,
+		id, businessID), &res)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying reservation %d: %w", id, err)
+	}
+	return &res, nil
+}
+
+func (r *ReservationRepository) CreateReservation(ctx context.Context, res reservation.Reservation) (*reservation.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for reservation: %v", err)
+		return nil, err
+	}
+
+	if err := checkTablesFree(ctx, tx, res); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO reservations (business_id, guest_name, phone, party_size, starts_at, ends_at, notes, status)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+		RETURNING id`,
+		res.BusinessID, res.GuestName, res.Phone, res.PartySize, res.StartsAt, res.EndsAt, res.Notes, reservation.StatusBooked,
+	).Scan(&res.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("inserting reservation: %w", err)
+	}
+
+	if err := insertReservationTables(ctx, tx, res.ID, res.TableIDs); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for reservation: %v", err)
+		return nil, err
+	}
+	return r.GetReservationByID(ctx, res.ID, res.BusinessID)
+}
+
+func (r *ReservationRepository) UpdateReservation(ctx context.Context, res reservation.Reservation) (*reservation.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for reservation %d: %v", res.ID, err)
+		return nil, err
+	}
+
+	if err := checkTablesFree(ctx, tx, res); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE reservations
+		SET guest_name = $1, phone = $2, party_size = $3, starts_at = $4, ends_at = $5,
+		    notes = NULLIF($6, ''), updated_at = NOW()
+		WHERE id = $7 AND business_id = $8`,
+		res.GuestName, res.Phone, res.PartySize, res.StartsAt, res.EndsAt, res.Notes, res.ID, res.BusinessID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("updating reservation %d: %w", res.ID, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		tx.Rollback()
+		return nil, reservation.ErrReservationNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reservation_tables WHERE reservation_id = $1`, res.ID); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("clearing tables of reservation %d: %w", res.ID, err)
+	}
+	if err := insertReservationTables(ctx, tx, res.ID, res.TableIDs); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for reservation %d: %v", res.ID, err)
+		return nil, err
+	}
+	return r.GetReservationByID(ctx, res.ID, res.BusinessID)
+}
+
+// checkTablesFree locks the tables of a reservation, so concurrent bookings of the same table
+// wait for each other, then looks for an overlapping active reservation on any of them
+func checkTablesFree(ctx context.Context, tx *sql.Tx, res reservation.Reservation) error {
+	if len(res.TableIDs) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM tables WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(res.TableIDs)); err != nil {
+		return fmt.Errorf("locking reservation tables: %w", err)
+	}
+
+	var conflict bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM reservations r
+			JOIN reservation_tables rt ON rt.reservation_id = r.id
+			WHERE rt.table_id = ANY($1)
+			  AND r.id <> $2
+			  AND r.status IN ($3, $4)
+			  AND r.starts_at < $6 AND r.ends_at > $5
+		)`,
+		pq.Array(res.TableIDs), res.ID, reservation.StatusBooked, reservation.StatusSeated, res.StartsAt, res.EndsAt,
+	).Scan(&conflict)
+	if err != nil {
+		return fmt.Errorf("checking reservation conflicts: %w", err)
+	}
+	if conflict {
+		return reservation.ErrReservationConflict
+	}
+	return nil
+}
+
+func insertReservationTables(ctx context.Context, tx *sql.Tx, reservationID int, tableIDs []int) error {
+	for _, tableID := range tableIDs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO reservation_tables (reservation_id, table_id) VALUES ($1, $2)`,
+			reservationID, tableID); err != nil {
+			return fmt.Errorf("assigning table %d to reservation %d: %w", tableID, reservationID, err)
+		}
+	}
+	return nil
+}
+
+func (r *ReservationRepository) UpdateReservationStatus(ctx context.Context, id int, status string, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reservations
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND business_id = $3`,
+		status, id, businessID)
+	if err != nil {
+		return fmt.Errorf("updating status of reservation %d: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return reservation.ErrReservationNotFound
+	}
+	return nil
+}
+
+// HoldTables marks a table reserved with the start of the reservation as its reserved_at,
+// which is how ReleaseTables later recognises the hold
+func (r *ReservationRepository) HoldTables(ctx context.Context, now time.Time, holdBefore time.Duration) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tables t
+		SET status = 'reserved', reserved_at = r.starts_at, status_changed_by = NULL, party_size = NULL
+		FROM reservation_tables rt
+		JOIN reservations r ON r.id = rt.reservation_id
+		WHERE rt.table_id = t.id
+		  AND t.status = 'free'
+		  AND r.status = $1
+		  AND r.starts_at <= $2
+		  AND r.ends_at > $3`,
+		reservation.StatusBooked, now.Add(holdBefore), now)
+	if err != nil {
+		return 0, fmt.Errorf("holding reserved tables: %w", err)
+	}
+	held, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(held), nil
+}
+
+func (r *ReservationRepository) ReleaseTables(ctx context.Context, res reservation.Reservation) error {
+	if len(res.TableIDs) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tables
+		SET status = 'free', reserved_at = NULL, status_changed_by = NULL, party_size = NULL
+		WHERE id = ANY($1) AND status = 'reserved' AND reserved_at = $2`,
+		pq.Array(res.TableIDs), res.StartsAt)
+	if err != nil {
+		return fmt.Errorf("releasing tables of reservation %d: %w", res.ID, err)
+	}
+	return nil
+}
+
+func (r *ReservationRepository) GetOpeningHours(ctx context.Context, businessID int) ([]reservation.OpeningHours, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT day_of_week, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		FROM opening_hours
+		WHERE business_id = $1
+		ORDER BY day_of_week, opens_at`,
+		businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying opening hours: %w", err)
+	}
+	defer rows.Close()
+
+	hours := []reservation.OpeningHours{}
+	for rows.Next() {
+		var h reservation.OpeningHours
+		if err := rows.Scan(&h.DayOfWeek, &h.OpensAt, &h.ClosesAt); err != nil {
+			return nil, fmt.Errorf("scanning opening hours: %w", err)
+		}
+		hours = append(hours, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating opening hours: %w", err)
+	}
+	return hours, nil
+}
+
+// SetOpeningHours replaces all opening hours of a business in a single transaction
+func (r *ReservationRepository) SetOpeningHours(ctx context.Context, hours []reservation.OpeningHours, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error starting transaction for opening hours: %v", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM opening_hours WHERE business_id = $1`, businessID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("clearing opening hours: %w", err)
+	}
+	for _, h := range hours {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO opening_hours (business_id, day_of_week, opens_at, closes_at)
+			VALUES ($1, $2, $3, $4)`,
+			businessID, h.DayOfWeek, h.OpensAt, h.ClosesAt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("inserting opening hours: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing transaction for opening hours: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"restaurant-management/internal/domain/reservation"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const waitlistColumns = `id, business_id, guest_name, phone, party_size, COALESCE(notes, ''), status,
+	table_ids, added_at, notified_at, seated_at`
+
+func scanWaitlistEntry(row rowScanner, e *reservation.WaitlistEntry) error {
+	var tableIDs pq.Int64Array
+	if err := row.Scan(
+		&e.ID, &e.BusinessID, &e.GuestName, &e.Phone, &e.PartySize, &e.Notes, &e.Status,
+		&tableIDs, &e.AddedAt, &e.NotifiedAt, &e.SeatedAt,
+	); err != nil {
+		return err
+	}
+	for _, id := range tableIDs {
+		e.TableIDs = append(e.TableIDs, int(id))
+	}
+	return nil
+}
+
+func (r *ReservationRepository) GetWaitlist(ctx context.Context, businessID int) ([]reservation.WaitlistEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist
+		WHERE business_id = $1 AND status IN ($2, $3)
+		ORDER BY added_at, id`,
+		businessID, reservation.WaitlistWaiting, reservation.WaitlistNotified)
+	if err != nil {
+		return nil, fmt.Errorf("querying waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []reservation.WaitlistEntry{}
+	for rows.Next() {
+		var e reservation.WaitlistEntry
+		if err := scanWaitlistEntry(rows, &e); err != nil {
+			return nil, fmt.Errorf("scanning waitlist entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating waitlist: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *ReservationRepository) GetWaitlistEntry(ctx context.Context, id int, businessID int) (*reservation.WaitlistEntry, error) {
+	var e reservation.WaitlistEntry
+	err := scanWaitlistEntry(r.db.QueryRowContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist
+		WHERE id = $1 AND business_id = $2`,
+		id, businessID), &e)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying waitlist entry %d: %w", id, err)
+	}
+	return &e, nil
+}
+
+func (r *ReservationRepository) CreateWaitlistEntry(ctx context.Context, e reservation.WaitlistEntry) (*reservation.WaitlistEntry, error) {
+	var created reservation.WaitlistEntry
+	err := scanWaitlistEntry(r.db.QueryRowContext(ctx, `
+		INSERT INTO waitlist (business_id, guest_name, phone, party_size, notes, status)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		RETURNING `+waitlistColumns,
+		e.BusinessID, e.GuestName, e.Phone, e.PartySize, e.Notes, reservation.WaitlistWaiting,
+	), &created)
+	if err != nil {
+		return nil, fmt.Errorf("inserting waitlist entry: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *ReservationRepository) UpdateWaitlistStatus(ctx context.Context, id int, status string, tableIDs []int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE waitlist
+		SET status = $1,
+		    notified_at = CASE WHEN $1 = $2 THEN NOW() ELSE notified_at END,
+		    seated_at = CASE WHEN $1 = $3 THEN NOW() ELSE seated_at END,
+		    table_ids = CASE WHEN $1 = $3 THEN $4 ELSE table_ids END,
+		    closed_at = CASE WHEN $1 IN ($2, $5) THEN closed_at ELSE NOW() END
+		WHERE id = $6 AND business_id = $7`,
+		status, reservation.WaitlistNotified, reservation.WaitlistSeated, pq.Array(tableIDs),
+		reservation.WaitlistWaiting, id, businessID)
+	if err != nil {
+		return fmt.Errorf("updating waitlist entry %d: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return reservation.ErrWaitlistEntryNotFound
+	}
+	return nil
+}
+
+// GetTurnTimes learns turn times from completed table orders. A party ordering twice counts
+// as two shorter visits, which errs on the side of a shorter estimate.
+func (r *ReservationRepository) GetTurnTimes(ctx context.Context, since time.Time, businessID int) (map[int]time.Duration, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.seats, AVG(EXTRACT(EPOCH FROM (o.completed_at - o.created_at)))
+		FROM orders o
+		JOIN tables t ON t.id = o.table_id
+		WHERE o.business_id = $1
+		  AND o.status = 'completed'
+		  AND o.completed_at > o.created_at
+		  AND o.created_at >= $2
+		GROUP BY t.seats`,
+		businessID, since)
+	if err != nil {
+		return nil, fmt.Errorf("querying turn times: %w", err)
+	}
+	defer rows.Close()
+
+	turns := make(map[int]time.Duration)
+	for rows.Next() {
+		var seats int
+		var seconds float64
+		if err := rows.Scan(&seats, &seconds); err != nil {
+			return nil, fmt.Errorf("scanning turn time: %w", err)
+		}
+		turns[seats] = time.Duration(seconds * float64(time.Second))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating turn times: %w", err)
+	}
+	return turns, nil
+}
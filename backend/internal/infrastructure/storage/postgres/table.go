@@ -18,7 +18,7 @@ func NewTableRepository(db *DB) table.Repository {
 }
 
 func (r *TableRepository) GetAllTables(ctx context.Context, businessID int) ([]table.Table, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT id, number, seats, status, reserved_at, occupied_at FROM tables WHERE business_id = $1 OR business_id IS NULL ORDER BY number ASC", businessID)
+	rows, err := r.db.QueryContext(ctx, "SELECT "+tableColumns+" FROM tables WHERE business_id = $1 OR business_id IS NULL ORDER BY number ASC", businessID)
 	if err != nil {
 		log.Printf("Error GetAllTables - querying tables: %v", err)
 		return nil, err
@@ -28,7 +28,7 @@ func (r *TableRepository) GetAllTables(ctx context.Context, businessID int) ([]t
 	var tables []table.Table
 	for rows.Next() {
 		var t table.Table
-		if err := rows.Scan(&t.ID, &t.Number, &t.Seats, &t.Status, &t.ReservedAt, &t.OccupiedAt); err != nil {
+		if err := scanTable(rows, &t); err != nil {
 			log.Printf("Error GetAllTables - scanning table row: %v", err)
 			return nil, err
 		}
@@ -80,9 +80,9 @@ func (r *TableRepository) GetAllTables(ctx context.Context, businessID int) ([]t
 }
 
 func (r *TableRepository) GetTableByID(ctx context.Context, id int) (*table.Table, error) {
-	query := `SELECT id, number, seats, status, reserved_at, occupied_at FROM tables WHERE id = $1`
+	query := `SELECT ` + tableColumns + ` FROM tables WHERE id = $1`
 	var t table.Table
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&t.ID, &t.Number, &t.Seats, &t.Status, &t.ReservedAt, &t.OccupiedAt)
+	err := scanTable(r.db.QueryRowContext(ctx, query, id), &t)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("table with ID %d not found", id)
@@ -101,6 +101,7 @@ func (r *TableRepository) GetTableStats(ctx context.Context, businessID int) (*t
             SUM(CASE WHEN status = 'free' THEN 1 ELSE 0 END) as free_tables,
             SUM(CASE WHEN status = 'occupied' THEN 1 ELSE 0 END) as occupied_tables,
             SUM(CASE WHEN status = 'reserved' THEN 1 ELSE 0 END) as reserved_tables,
+            SUM(CASE WHEN status = 'needs_cleaning' THEN 1 ELSE 0 END) as cleaning_tables,
             (SUM(CASE WHEN status = 'occupied' THEN 1 ELSE 0 END) * 100.0 / CASE WHEN COUNT(*) = 0 THEN 1 ELSE COUNT(*) END) as occupancy_percentage
         FROM tables
         WHERE business_id = $1
@@ -110,6 +111,7 @@ func (r *TableRepository) GetTableStats(ctx context.Context, businessID int) (*t
 		&stats.Free,
 		&stats.Occupied,
 		&stats.Reserved,
+		&stats.NeedsCleaning,
 		&stats.Occupancy,
 	)
 	if err != nil {
@@ -141,7 +143,9 @@ func (r *TableRepository) UpdateTableStatus(ctx context.Context, tableID int, st
 		log.Printf("Warning: UpdateTableStatus called with unhandled status '%s' for table %d. occupied_at and reserved_at will not be changed.", status, tableID)
 		// Depending on strictness, you might want to return an error here or just update status and updated_at for unhandled cases.
 		// For now, let's proceed to update only status and updated_at for unhandled cases.
-		_, err := r.db.ExecContext(ctx, "UPDATE tables SET status = $1 WHERE id = $2", status, tableID)
+		_, err := r.db.ExecContext(ctx, `UPDATE tables SET status = $1, status_changed_by = NULL, party_size = NULL,
+			cleaning_since = CASE WHEN $1 = 'needs_cleaning' THEN COALESCE(cleaning_since, NOW()) END
+			WHERE id = $2`, status, tableID)
 		if err != nil {
 			log.Printf("Ошибка обновления статуса (без occupied_at/reserved_at) стола для ID %d: %v", tableID, err)
 			return err
@@ -151,7 +155,8 @@ func (r *TableRepository) UpdateTableStatus(ctx context.Context, tableID int, st
 
 	// updated_at is always set
 	result, err := r.db.ExecContext(ctx, `UPDATE tables 
-						   SET status = $1, occupied_at = $2, reserved_at = $3 
+						   SET status = $1, occupied_at = $2, reserved_at = $3,
+						       status_changed_by = NULL, party_size = NULL, cleaning_since = NULL
 						   WHERE id = $4`,
 		status, occupiedAt, reservedAt, tableID)
 
@@ -170,14 +175,17 @@ func (r *TableRepository) UpdateTableStatus(ctx context.Context, tableID int, st
 	return nil
 }
 
-// UpdateTableStatusWithTimes updates a table's status and timestamp fields
-func (r *TableRepository) UpdateTableStatusWithTimes(ctx context.Context, tableID int, status string, reservedAt, occupiedAt *time.Time) error {
+// UpdateTableStatusWithTimes updates a table's status and timestamp fields. The user and
+// party size are kept on the table for the status history trigger to pick up.
+func (r *TableRepository) UpdateTableStatusWithTimes(ctx context.Context, tableID int, status string, reservedAt, occupiedAt *time.Time, audit table.StatusAudit) error {
 	query := `
 		UPDATE tables 
-		SET status = $1, reserved_at = $2, occupied_at = $3
+		SET status = $1, reserved_at = $2, occupied_at = $3,
+		    status_changed_by = $5, party_size = $6,
+		    cleaning_since = CASE WHEN $1 = 'needs_cleaning' THEN COALESCE(cleaning_since, NOW()) END
 		WHERE id = $4`
 
-	result, err := r.db.ExecContext(ctx, query, status, reservedAt, occupiedAt, tableID)
+	result, err := r.db.ExecContext(ctx, query, status, reservedAt, occupiedAt, tableID, nilOrVal(audit.ChangedBy), nilOrVal(audit.PartySize))
 	if err != nil {
 		log.Printf("Error updating table %d status and timestamps: %v", tableID, err)
 		return err
@@ -213,3 +221,82 @@ func (r *TableRepository) TableHasActiveOrders(ctx context.Context, tableID int)
 	}
 	return count > 0, nil
 }
+
+// HasCompletedOrdersSince checks if a table had an order completed since the given time
+func (r *TableRepository) HasCompletedOrdersSince(ctx context.Context, tableID int, since time.Time) (bool, error) {
+	var completed bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM orders
+			WHERE table_id = $1 AND status = 'completed' AND completed_at >= $2
+		)`,
+		tableID, since,
+	).Scan(&completed)
+	if err != nil {
+		log.Printf("Error checking completed orders for table %d: %v", tableID, err)
+		return false, err
+	}
+	return completed, nil
+}
+
+// FreeCleanedTables frees tables that have waited for cleaning longer than their
+// business's table_auto_free_minutes. Businesses with a zero delay free tables by hand.
+func (r *TableRepository) FreeCleanedTables(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tables t
+		SET status = 'free', reserved_at = NULL, occupied_at = NULL, cleaning_since = NULL,
+		    status_changed_by = NULL, party_size = NULL
+		FROM businesses b
+		WHERE b.id = t.business_id
+		  AND b.table_auto_free_minutes > 0
+		  AND t.status = 'needs_cleaning'
+		  AND t.cleaning_since <= $1::timestamptz - b.table_auto_free_minutes * INTERVAL '1 minute'`,
+		now)
+	if err != nil {
+		log.Printf("Error freeing cleaned tables: %v", err)
+		return 0, err
+	}
+	freed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(freed), nil
+}
+
+// GetBusinessTable retrieves a table of a business, including its QR code version
+func (r *TableRepository) GetBusinessTable(ctx context.Context, id int, businessID int) (*table.Table, error) {
+	query := `
+		SELECT ` + tableColumns + `
+		FROM tables
+		WHERE id = $1 AND (business_id = $2 OR business_id IS NULL)`
+	var t table.Table
+	err := scanTable(r.db.QueryRowContext(ctx, query, id, businessID), &t)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, table.ErrTableNotFound
+		}
+		log.Printf("Error GetBusinessTable - scanning table %d: %v", id, err)
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RotateQRVersion bumps the QR code version of a table so codes printed earlier stop working
+func (r *TableRepository) RotateQRVersion(ctx context.Context, id int, businessID int) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE tables
+		SET qr_version = qr_version + 1
+		WHERE id = $1 AND business_id = $2
+		RETURNING qr_version`,
+		id, businessID,
+	).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, table.ErrTableNotFound
+		}
+		log.Printf("Error rotating QR version of table %d: %v", id, err)
+		return 0, err
+	}
+	return version, nil
+}
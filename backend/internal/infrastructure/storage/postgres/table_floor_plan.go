@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/table"
+)
+
+const tableColumns = `id, number, seats, status, reserved_at, occupied_at, cleaning_since, qr_version,
+	COALESCE(name, ''), COALESCE(zone_id, 0), shape, pos_x, pos_y, width, height, rotation`
+
+func scanTable(row rowScanner, t *table.Table) error {
+	return row.Scan(
+		&t.ID,
+		&t.Number,
+		&t.Seats,
+		&t.Status,
+		&t.ReservedAt,
+		&t.OccupiedAt,
+		&t.CleaningSince,
+		&t.QRVersion,
+		&t.Name,
+		&t.ZoneID,
+		&t.Shape,
+		&t.X,
+		&t.Y,
+		&t.Width,
+		&t.Height,
+		&t.Rotation,
+	)
+}
+
+const zoneColumns = `id, business_id, name, sort_order, width, height, created_at, updated_at`
+
+func scanZone(row rowScanner, z *table.Zone) error {
+	return row.Scan(&z.ID, &z.BusinessID, &z.Name, &z.SortOrder, &z.Width, &z.Height, &z.CreatedAt, &z.UpdatedAt)
+}
+
+// CreateTable adds a free table to the floor
+func (r *TableRepository) CreateTable(ctx context.Context, t table.TableCreate) (*table.Table, error) {
+	var created table.Table
+	err := scanTable(r.db.QueryRowContext(ctx, `
+		INSERT INTO tables (number, name, seats, status, zone_id, shape, pos_x, pos_y, width, height, rotation, business_id)
+		VALUES ($1, NULLIF($2, ''), $3, 'free', $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING `+tableColumns,
+		t.Number, t.Name, t.Seats, nilOrVal(t.ZoneID), t.Shape, t.X, t.Y, t.Width, t.Height, t.Rotation, t.BusinessID,
+	), &created)
+	if err != nil {
+		return nil, fmt.Errorf("creating table: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdateTable changes the number, seats or floor plan placement of a table. Unset fields are kept.
+func (r *TableRepository) UpdateTable(ctx context.Context, id int, t table.TableUpdate) (*table.Table, error) {
+	var updated table.Table
+	err := scanTable(r.db.QueryRowContext(ctx, `
+		UPDATE tables
+		SET number = COALESCE($1, number),
+		    name = CASE WHEN $2::text IS NULL THEN name ELSE NULLIF($2, '') END,
+		    seats = COALESCE($3, seats),
+		    zone_id = CASE WHEN $4::int IS NULL THEN zone_id ELSE NULLIF($4, 0) END,
+		    shape = COALESCE($5, shape),
+		    pos_x = COALESCE($6, pos_x),
+		    pos_y = COALESCE($7, pos_y),
+		    width = COALESCE($8, width),
+		    height = COALESCE($9, height),
+		    rotation = COALESCE($10, rotation)
+		WHERE id = $11 AND business_id = $12
+		RETURNING `+tableColumns,
+		t.Number, t.Name, t.Seats, t.ZoneID, t.Shape, t.X, t.Y, t.Width, t.Height, t.Rotation, id, t.BusinessID,
+	), &updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, table.ErrTableNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating table %d: %w", id, err)
+	}
+	return &updated, nil
+}
+
+// DeleteTable removes a table. Past orders keep their history with the table unset and
+// the status history is kept for the turn-time reports.
+func (r *TableRepository) DeleteTable(ctx context.Context, id int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM tables
+		WHERE id = $1 AND business_id = $2`,
+		id, businessID)
+	if err != nil {
+		log.Printf("Error deleting table %d: %v", id, err)
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return table.ErrTableNotFound
+	}
+	return nil
+}
+
+func (r *TableRepository) GetZones(ctx context.Context, businessID int) ([]table.Zone, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+zoneColumns+`
+		FROM floor_zones
+		WHERE business_id = $1
+		ORDER BY sort_order, name`,
+		businessID)
+	if err != nil {
+		return nil, fmt.Errorf("querying zones: %w", err)
+	}
+	defer rows.Close()
+
+	zones := []table.Zone{}
+	for rows.Next() {
+		var z table.Zone
+		if err := scanZone(rows, &z); err != nil {
+			return nil, fmt.Errorf("scanning zone: %w", err)
+		}
+		zones = append(zones, z)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating zones: %w", err)
+	}
+	return zones, nil
+}
+
+func (r *TableRepository) GetZoneByID(ctx context.Context, id int, businessID int) (*table.Zone, error) {
+	var z table.Zone
+	err := scanZone(r.db.QueryRowContext(ctx, `
+		SELECT `+zoneColumns+`
+		FROM floor_zones
+		WHERE id = $1 AND business_id = $2`,
+		id, businessID), &z)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying zone %d: %w", id, err)
+	}
+	return &z, nil
+}
+
+func (r *TableRepository) CreateZone(ctx context.Context, z table.ZoneCreate) (*table.Zone, error) {
+	var created table.Zone
+	err := scanZone(r.db.QueryRowContext(ctx, `
+		INSERT INTO floor_zones (business_id, name, sort_order, width, height)
+		VALUES ($1, $2, COALESCE($3, (SELECT COALESCE(MAX(sort_order) + 1, 0) FROM floor_zones WHERE business_id = $1)), $4, $5)
+		RETURNING `+zoneColumns,
+		z.BusinessID, z.Name, z.SortOrder, z.Width, z.Height,
+	), &created)
+	if err != nil {
+		return nil, fmt.Errorf("creating zone: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *TableRepository) UpdateZone(ctx context.Context, id int, z table.ZoneUpdate) (*table.Zone, error) {
+	var updated table.Zone
+	err := scanZone(r.db.QueryRowContext(ctx, `
+		UPDATE floor_zones
+		SET name = COALESCE($1, name),
+		    sort_order = COALESCE($2, sort_order),
+		    width = COALESCE($3, width),
+		    height = COALESCE($4, height),
+		    updated_at = NOW()
+		WHERE id = $5 AND business_id = $6
+		RETURNING `+zoneColumns,
+		z.Name, z.SortOrder, z.Width, z.Height, id, z.BusinessID,
+	), &updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, table.ErrZoneNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating zone %d: %w", id, err)
+	}
+	return &updated, nil
+}
+
+// DeleteZone removes a zone; the database takes its tables out of it
+func (r *TableRepository) DeleteZone(ctx context.Context, id int, businessID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM floor_zones WHERE id = $1 AND business_id = $2`, id, businessID)
+	if err != nil {
+		return fmt.Errorf("deleting zone %d: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return table.ErrZoneNotFound
+	}
+	return nil
+}
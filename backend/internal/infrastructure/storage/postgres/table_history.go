@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restaurant-management/internal/domain/table"
+	"time"
+)
+
+// GetStatusHistory lists the status changes recorded by the table_status_history trigger, newest first
+func (r *TableRepository) GetStatusHistory(ctx context.Context, filter table.StatusHistoryFilter, businessID int) ([]table.StatusChange, error) {
+	var from, to interface{}
+	if !filter.From.IsZero() {
+		from = filter.From
+	}
+	if !filter.To.IsZero() {
+		to = filter.To
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT h.id, h.table_id, COALESCE(t.number, 0), COALESCE(h.from_status, ''), h.to_status,
+		       COALESCE(h.party_size, 0), COALESCE(h.changed_by, 0), COALESCE(u.name, u.username, ''), h.changed_at
+		FROM table_status_history h
+		LEFT JOIN tables t ON t.id = h.table_id
+		LEFT JOIN users u ON u.id = h.changed_by
+		WHERE h.business_id = $1
+		  AND ($2::int IS NULL OR h.table_id = $2)
+		  AND ($3::timestamptz IS NULL OR h.changed_at >= $3)
+		  AND ($4::timestamptz IS NULL OR h.changed_at < $4)
+		ORDER BY h.changed_at DESC, h.id DESC
+		LIMIT $5`,
+		businessID, nilOrVal(filter.TableID), from, to, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying table status history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []table.StatusChange
+	for rows.Next() {
+		var c table.StatusChange
+		if err := rows.Scan(&c.ID, &c.TableID, &c.TableNumber, &c.FromStatus, &c.ToStatus,
+			&c.PartySize, &c.ChangedBy, &c.ChangedByName, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
+
+// GetOccupancies returns the finished stays that started in [from, to). A stay ends at
+// the next status change of the table, so a table still occupied is left out.
+func (r *TableRepository) GetOccupancies(ctx context.Context, from, to time.Time, businessID int) ([]table.Occupancy, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.table_id, COALESCE(t.seats, 0), COALESCE(s.party_size, 0), s.changed_at, s.ended_at
+		FROM (
+			SELECT table_id, to_status, party_size, changed_at,
+			       LEAD(changed_at) OVER (PARTITION BY table_id ORDER BY changed_at, id) AS ended_at
+			FROM table_status_history
+			WHERE business_id = $1
+		) s
+		LEFT JOIN tables t ON t.id = s.table_id
+		WHERE s.to_status = 'occupied'
+		  AND s.ended_at IS NOT NULL
+		  AND s.changed_at >= $2 AND s.changed_at < $3
+		ORDER BY s.changed_at`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying table occupancies: %w", err)
+	}
+	defer rows.Close()
+
+	var occupancies []table.Occupancy
+	for rows.Next() {
+		var o table.Occupancy
+		if err := rows.Scan(&o.TableID, &o.Seats, &o.PartySize, &o.Start, &o.End); err != nil {
+			return nil, err
+		}
+		occupancies = append(occupancies, o)
+	}
+	return occupancies, rows.Err()
+}
+
+// GetTableRevenue sums completed orders created in [from, to) by table
+func (r *TableRepository) GetTableRevenue(ctx context.Context, from, to time.Time, businessID int) (map[int]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT table_id, COALESCE(SUM(total_amount), 0)
+		FROM orders
+		WHERE business_id = $1 AND status = 'completed' AND table_id IS NOT NULL
+		  AND created_at >= $2 AND created_at < $3
+		GROUP BY table_id`,
+		businessID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying table revenue: %w", err)
+	}
+	defer rows.Close()
+
+	revenue := make(map[int]float64)
+	for rows.Next() {
+		var tableID int
+		var amount sql.NullFloat64
+		if err := rows.Scan(&tableID, &amount); err != nil {
+			return nil, err
+		}
+		revenue[tableID] = amount.Float64
+	}
+	return revenue, rows.Err()
+}
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"log"
 	"restaurant-management/internal/domain/waiter"
-	"time"
 )
 
 type WaiterRepository struct {
@@ -96,9 +95,7 @@ func (r *WaiterRepository) GetWaiterProfile(ctx context.Context, waiterID int, b
 }
 
 func (r *WaiterRepository) GetWaiterCurrentAndUpcomingShifts(ctx context.Context, waiterID int, businessID int) (*waiter.ShiftWithEmployees, []waiter.ShiftWithEmployees, error) {
-	now := time.Now().UTC().Add(5 * time.Hour)
-	currentDate := now.Format("2006-01-02")
-	currentTime := now.Format("15:04:05")
+	currentDate, currentTime := shiftClock(ctx, r.db, businessID)
 
 	// Query for current shift
 	currentShiftQuery := `
@@ -252,18 +249,18 @@ func (r *WaiterRepository) GetWaiterCurrentAndUpcomingShifts(ctx context.Context
 }
 
 func (r *WaiterRepository) GetTablesAssignedToWaiter(ctx context.Context, waiterID int, businessID int) ([]waiter.Table, error) {
-	// Запрос для получения столов, назначенных официанту
-	// ВАЖНО: Этот запрос должен обращаться к таблице waiter_tables или аналогичной для установления связи официант-стол
-	query := `
+	// Столы из секции официанта в текущих сменах, включая столы назначенных ему зон
+	currentDate, currentTime := shiftClock(ctx, r.db, businessID)
+	query := currentSectionOwners + `
 		SELECT 
 			t.id, t.number, t.seats, t.status, t.reserved_at, t.occupied_at
 		FROM tables t
-		JOIN waiter_tables wt ON t.id = wt.table_id
-		WHERE wt.waiter_id = $1 AND t.business_id = $2
+		WHERE t.business_id = $1
+		AND t.id IN (SELECT table_id FROM section_owners WHERE waiter_id = $4)
 		ORDER BY t.number ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, waiterID, businessID)
+	rows, err := r.db.QueryContext(ctx, query, businessID, currentDate, currentTime, waiterID)
 	if err != nil {
 		log.Printf("Error querying tables assigned to waiter %d: %v", waiterID, err)
 		return nil, err
@@ -0,0 +1,265 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/waiter"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// rowQuerier is satisfied by both the database and a transaction
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// shiftClock returns the current date and time in the business's local time, which is how
+// shift dates and start/end times are stored. Like the services, it falls back to
+// business.DefaultLocation when the business has none or an invalid one.
+func shiftClock(ctx context.Context, db rowQuerier, businessID int) (string, string) {
+	loc := business.DefaultLocation
+	var timezone sql.NullString
+	err := db.QueryRowContext(ctx, `SELECT timezone FROM businesses WHERE id = $1`, businessID).Scan(&timezone)
+	if err == nil && timezone.String != "" {
+		if l, err := time.LoadLocation(timezone.String); err == nil {
+			loc = l
+		} else {
+			log.Printf("Invalid timezone %q for business %d: %v", timezone.String, businessID, err)
+		}
+	}
+
+	now := time.Now().In(loc)
+	return now.Format("2006-01-02"), now.Format("15:04:05")
+}
+
+// currentSectionOwners resolves every table to the waiter covering it in the
+// shifts running at $2 (date) and $3 (time) for business $1. A table assigned
+// directly wins over the zone it stands in.
+const currentSectionOwners = `
+	WITH current_assignments AS (
+		SELECT wa.shift_id, wa.waiter_id, wa.table_id, wa.zone_id
+		FROM waiter_assignments wa
+		JOIN shifts s ON s.id = wa.shift_id
+		WHERE wa.business_id = $1
+		AND s.date = $2::date
+		AND $3::time BETWEEN s.start_time AND s.end_time
+	), section_owners AS (
+		SELECT table_id, waiter_id, shift_id
+		FROM current_assignments
+		WHERE table_id IS NOT NULL
+		UNION ALL
+		SELECT t.id, ca.waiter_id, ca.shift_id
+		FROM current_assignments ca
+		JOIN tables t ON t.zone_id = ca.zone_id
+		WHERE NOT EXISTS (SELECT 1 FROM current_assignments x WHERE x.table_id = t.id)
+	)`
+
+func (r *WaiterRepository) GetSections(ctx context.Context, shiftID int, businessID int) ([]waiter.Section, error) {
+	query := `
+		SELECT wa.waiter_id, COALESCE(u.name, u.username),
+			COALESCE(array_agg(wa.table_id ORDER BY wa.table_id) FILTER (WHERE wa.table_id IS NOT NULL), '{}'),
+			COALESCE(array_agg(wa.zone_id ORDER BY wa.zone_id) FILTER (WHERE wa.zone_id IS NOT NULL), '{}')
+		FROM waiter_assignments wa
+		JOIN users u ON u.id = wa.waiter_id
+		WHERE wa.shift_id = $1 AND wa.business_id = $2
+		GROUP BY wa.waiter_id, u.name, u.username
+		ORDER BY wa.waiter_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, shiftID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []waiter.Section
+	for rows.Next() {
+		section := waiter.Section{ShiftID: shiftID}
+		var tableIDs, zoneIDs pq.Int64Array
+		if err := rows.Scan(&section.WaiterID, &section.WaiterName, &tableIDs, &zoneIDs); err != nil {
+			return nil, err
+		}
+		section.TableIDs = make([]int, len(tableIDs))
+		for i, id := range tableIDs {
+			section.TableIDs[i] = int(id)
+		}
+		section.ZoneIDs = make([]int, len(zoneIDs))
+		for i, id := range zoneIDs {
+			section.ZoneIDs[i] = int(id)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, rows.Err()
+}
+
+func (r *WaiterRepository) IsOnShift(ctx context.Context, shiftID int, waiterID int, businessID int) (bool, error) {
+	var onShift bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM shift_employees se
+			JOIN shifts s ON s.id = se.shift_id
+			WHERE se.shift_id = $1 AND se.employee_id = $2 AND s.business_id = $3
+		)
+	`, shiftID, waiterID, businessID).Scan(&onShift)
+	return onShift, err
+}
+
+// ReplaceSection sets the tables and zones of a waiter for a shift, replacing
+// what was there. It fails with ErrInvalidSection when a table or zone does not
+// belong to the business.
+func (r *WaiterRepository) ReplaceSection(ctx context.Context, section waiter.Section, businessID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM waiter_assignments WHERE shift_id = $1 AND waiter_id = $2 AND business_id = $3
+	`, section.ShiftID, section.WaiterID, businessID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	inserts := []struct {
+		query string
+		ids   []int
+	}{
+		{`INSERT INTO waiter_assignments (business_id, shift_id, waiter_id, table_id)
+			SELECT $1, $2, $3, id FROM tables WHERE business_id = $1 AND id = ANY($4)`, section.TableIDs},
+		{`INSERT INTO waiter_assignments (business_id, shift_id, waiter_id, zone_id)
+			SELECT $1, $2, $3, id FROM floor_zones WHERE business_id = $1 AND id = ANY($4)`, section.ZoneIDs},
+	}
+	for _, ins := range inserts {
+		if len(ins.ids) == 0 {
+			continue
+		}
+		result, err := tx.ExecContext(ctx, ins.query, businessID, section.ShiftID, section.WaiterID, pq.Array(ins.ids))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if int(inserted) != len(ins.ids) {
+			tx.Rollback()
+			return waiter.ErrInvalidSection
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTableOwner returns the waiter whose current section covers the table,
+// or nil when the table is in nobody's section
+func (r *WaiterRepository) GetTableOwner(ctx context.Context, tableID int, businessID int) (*waiter.SectionOwner, error) {
+	currentDate, currentTime := shiftClock(ctx, r.db, businessID)
+	query := currentSectionOwners + `
+		SELECT so.table_id, so.shift_id, so.waiter_id, COALESCE(u.name, u.username)
+		FROM section_owners so
+		JOIN users u ON u.id = so.waiter_id
+		WHERE so.table_id = $4
+		ORDER BY so.shift_id
+		LIMIT 1
+	`
+
+	var owner waiter.SectionOwner
+	err := r.db.QueryRowContext(ctx, query, businessID, currentDate, currentTime, tableID).Scan(
+		&owner.TableID, &owner.ShiftID, &owner.WaiterID, &owner.WaiterName,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// Handoff moves the sender's assignments in the shifts running now, and all of
+// their open orders, to the colleague. The colleague must be another waiter of
+// the business; they are added to those shifts so the section shows up in their schedule.
+func (r *WaiterRepository) Handoff(ctx context.Context, req waiter.HandoffRequest, businessID int) (*waiter.HandoffResult, error) {
+	if req.FromWaiterID == req.ToWaiterID {
+		return nil, waiter.ErrInvalidHandoff
+	}
+	currentDate, currentTime := shiftClock(ctx, r.db, businessID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var colleague bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND business_id = $2 AND role = 'waiter')
+	`, req.ToWaiterID, businessID).Scan(&colleague); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !colleague {
+		tx.Rollback()
+		return nil, waiter.ErrInvalidHandoff
+	}
+
+	result := &waiter.HandoffResult{FromWaiterID: req.FromWaiterID, ToWaiterID: req.ToWaiterID}
+
+	moved, err := tx.ExecContext(ctx, `
+		UPDATE waiter_assignments SET waiter_id = $5
+		WHERE business_id = $1 AND waiter_id = $4
+		AND shift_id IN (
+			SELECT id FROM shifts
+			WHERE business_id = $1 AND date = $2::date AND $3::time BETWEEN start_time AND end_time
+		)
+	`, businessID, currentDate, currentTime, req.FromWaiterID, req.ToWaiterID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	count, err := moved.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	result.Assignments = int(count)
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO shift_employees (shift_id, employee_id, business_id)
+		SELECT DISTINCT shift_id, $2, $1 FROM waiter_assignments
+		WHERE business_id = $1 AND waiter_id = $2
+		AND shift_id IN (
+			SELECT id FROM shifts
+			WHERE business_id = $1 AND date = $3::date AND $4::time BETWEEN start_time AND end_time
+		)
+		ON CONFLICT (shift_id, employee_id) DO NOTHING
+	`, businessID, req.ToWaiterID, currentDate, currentTime); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	moved, err = tx.ExecContext(ctx, `
+		UPDATE orders SET waiter_id = $3, updated_at = NOW()
+		WHERE business_id = $1 AND waiter_id = $2 AND status NOT IN ('completed', 'cancelled')
+	`, businessID, req.FromWaiterID, req.ToWaiterID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	count, err = moved.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	result.Orders = int(count)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"restaurant-management/internal/domain/reservation"
+	"time"
+)
+
+// GuestNotifier posts "table ready" events to a webhook, typically an SMS or messenger gateway
+type GuestNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewGuestNotifier(url string) reservation.GuestNotifier {
+	return &GuestNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type tableReadyEvent struct {
+	Event      string `json:"event"`
+	BusinessID int    `json:"business_id"`
+	EntryID    int    `json:"waitlist_entry_id"`
+	GuestName  string `json:"guest_name"`
+	Phone      string `json:"phone"`
+	PartySize  int    `json:"party_size"`
+}
+
+func (n *GuestNotifier) NotifyTableReady(ctx context.Context, entry reservation.WaitlistEntry) error {
+	body, err := json.Marshal(tableReadyEvent{
+		Event:      "table_ready",
+		BusinessID: entry.BusinessID,
+		EntryID:    entry.ID,
+		GuestName:  entry.GuestName,
+		Phone:      entry.Phone,
+		PartySize:  entry.PartySize,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating table ready notification: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending table ready notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("table ready notification rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter counts requests per key in fixed time windows. State is kept in memory,
+// so each server instance enforces its own limit.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+type rateBucket struct {
+	count   int
+	resetAt time.Time
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per key in each window
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:     limit,
+		window:    window,
+		buckets:   make(map[string]*rateBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow records a request for key. When the limit is exceeded it returns false and the
+// time left until the window resets.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > l.window {
+		for k, b := range l.buckets {
+			if now.After(b.resetAt) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok || now.After(b.resetAt) {
+		b = &rateBucket{resetAt: now.Add(l.window)}
+		l.buckets[key] = b
+	}
+	if b.count >= l.limit {
+		return false, b.resetAt.Sub(now)
+	}
+	b.count++
+	return true, 0
+}
+
+// RateLimitMiddleware rejects clients that exceed the limiter with 429 Too Many Requests,
+// counting requests per client IP
+func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, retryAfter := limiter.Allow(ClientIP(r)); !ok {
+				WriteTooManyRequests(w, retryAfter)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WriteTooManyRequests writes a 429 response telling the client when to retry
+func WriteTooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+	http.Error(w, "Too many requests", http.StatusTooManyRequests)
+}
+
+// ClientIP returns the address of the client without the port. Forwarding headers are
+// ignored because clients can set them freely.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -2,17 +2,23 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"regexp"
 	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/media"
 	"strings"
+	"time"
 )
 
 type BusinessService struct {
-	repo business.Repository
+	repo  business.Repository
+	media media.Service
 }
 
-func NewBusinessService(repo business.Repository) business.Service {
-	return &BusinessService{repo: repo}
+func NewBusinessService(repo business.Repository, mediaService media.Service) business.Service {
+	return &BusinessService{repo: repo, media: mediaService}
 }
 
 func (s *BusinessService) CreateBusiness(ctx context.Context, b *business.Business) error {
@@ -31,6 +37,37 @@ func (s *BusinessService) CreateBusiness(ctx context.Context, b *business.Busine
 		return business.ErrInvalidBusinessData
 	}
 
+	// Validate timezone
+	if b.Timezone != "" {
+		if _, err := time.LoadLocation(b.Timezone); err != nil {
+			return business.ErrInvalidBusinessData
+		}
+	}
+
+	// Validate languages
+	if err := normalizeLanguages(b); err != nil {
+		return err
+	}
+
+	// Validate allergen policy
+	if b.AllergenPolicy == "" {
+		b.AllergenPolicy = business.AllergenPolicyWarn
+	}
+	if !validAllergenPolicy(b.AllergenPolicy) {
+		return business.ErrInvalidBusinessData
+	}
+	if b.TableAutoFreeMinutes != nil && *b.TableAutoFreeMinutes < 0 {
+		return business.ErrInvalidBusinessData
+	}
+
+	// Validate costing method
+	if b.CostingMethod == "" {
+		b.CostingMethod = business.CostingAverage
+	}
+	if !validCostingMethod(b.CostingMethod) {
+		return business.ErrInvalidBusinessData
+	}
+
 	return s.repo.CreateBusiness(ctx, b)
 }
 
@@ -39,7 +76,12 @@ func (s *BusinessService) GetBusinessByID(ctx context.Context, id int) (*busines
 		return nil, business.ErrInvalidBusinessID
 	}
 
-	return s.repo.GetBusinessByID(ctx, id)
+	b, err := s.repo.GetBusinessByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.setLogoThumbnails(b)
+	return b, nil
 }
 
 func (s *BusinessService) GetAllBusinesses(ctx context.Context) ([]business.Business, *business.BusinessStats, error) {
@@ -55,6 +97,10 @@ func (s *BusinessService) GetAllBusinesses(ctx context.Context) ([]business.Busi
 		stats = &business.BusinessStats{}
 	}
 
+	for i := range businesses {
+		s.setLogoThumbnails(&businesses[i])
+	}
+
 	return businesses, stats, nil
 }
 
@@ -73,13 +119,70 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, b *business.Busine
 		return business.ErrInvalidBusinessData
 	}
 
+	// Validate timezone
+	if b.Timezone != "" {
+		if _, err := time.LoadLocation(b.Timezone); err != nil {
+			return business.ErrInvalidBusinessData
+		}
+	}
+
 	// Check if business exists
-	_, err := s.repo.GetBusinessByID(ctx, b.ID)
+	existing, err := s.repo.GetBusinessByID(ctx, b.ID)
 	if err != nil {
 		return business.ErrBusinessNotFound
 	}
 
-	return s.repo.UpdateBusiness(ctx, b)
+	// Keep the current languages unless new ones are provided
+	if b.DefaultLanguage == "" {
+		b.DefaultLanguage = existing.DefaultLanguage
+	}
+	if b.SupportedLanguages == nil {
+		b.SupportedLanguages = existing.SupportedLanguages
+	}
+	if err := normalizeLanguages(b); err != nil {
+		return err
+	}
+
+	// Keep the current allergen policy unless a new one is provided
+	if b.AllergenPolicy == "" {
+		b.AllergenPolicy = existing.AllergenPolicy
+	}
+	if b.AllergenPolicy == "" {
+		b.AllergenPolicy = business.AllergenPolicyWarn
+	}
+	if !validAllergenPolicy(b.AllergenPolicy) {
+		return business.ErrInvalidBusinessData
+	}
+
+	// Keep the current table auto-free delay unless a new one is provided
+	if b.TableAutoFreeMinutes == nil {
+		b.TableAutoFreeMinutes = existing.TableAutoFreeMinutes
+	}
+	if b.TableAutoFreeMinutes != nil && *b.TableAutoFreeMinutes < 0 {
+		return business.ErrInvalidBusinessData
+	}
+
+	// Keep the current costing method unless a new one is provided
+	if b.CostingMethod == "" {
+		b.CostingMethod = existing.CostingMethod
+	}
+	if b.CostingMethod == "" {
+		b.CostingMethod = business.CostingAverage
+	}
+	if !validCostingMethod(b.CostingMethod) {
+		return business.ErrInvalidBusinessData
+	}
+
+	if err := s.repo.UpdateBusiness(ctx, b); err != nil {
+		return err
+	}
+
+	// A replaced logo is no longer referenced, so drop the previous upload
+	if existing.Logo != b.Logo {
+		s.removeLogo(ctx, existing.Logo, existing.ID)
+	}
+	s.setLogoThumbnails(b)
+	return nil
 }
 
 func (s *BusinessService) DeleteBusiness(ctx context.Context, id int) error {
@@ -88,12 +191,17 @@ func (s *BusinessService) DeleteBusiness(ctx context.Context, id int) error {
 	}
 
 	// Check if business exists
-	_, err := s.repo.GetBusinessByID(ctx, id)
+	existing, err := s.repo.GetBusinessByID(ctx, id)
 	if err != nil {
 		return business.ErrBusinessNotFound
 	}
 
-	return s.repo.DeleteBusiness(ctx, id)
+	if err := s.repo.DeleteBusiness(ctx, id); err != nil {
+		return err
+	}
+
+	s.removeLogo(ctx, existing.Logo, id)
+	return nil
 }
 
 func (s *BusinessService) SetBusinessCookie(ctx context.Context, businessID int) error {
@@ -111,3 +219,86 @@ func (s *BusinessService) SetBusinessCookie(ctx context.Context, businessID int)
 	// This service method validates the business exists
 	return nil
 }
+
+func (s *BusinessService) UploadLogo(ctx context.Context, id int, r io.Reader) (*business.Business, error) {
+	if id <= 0 {
+		return nil, business.ErrInvalidBusinessID
+	}
+
+	// Check if business exists
+	b, err := s.repo.GetBusinessByID(ctx, id)
+	if err != nil {
+		return nil, business.ErrBusinessNotFound
+	}
+	oldLogo := b.Logo
+
+	img, err := s.media.UploadImage(ctx, fmt.Sprintf("businesses/%d/logo", id), r)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Logo = img.URL
+	if err := s.repo.UpdateBusiness(ctx, b); err != nil {
+		s.removeLogo(ctx, img.URL, id)
+		return nil, err
+	}
+
+	s.removeLogo(ctx, oldLogo, id)
+	s.setLogoThumbnails(b)
+	return b, nil
+}
+
+// languageTagPattern matches simple BCP 47 tags such as "en", "kk" or "pt-br"
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// normalizeLanguages lower-cases and validates the language settings, defaulting them
+// when unset and making sure the default language is listed as supported
+func normalizeLanguages(b *business.Business) error {
+	b.DefaultLanguage = strings.ToLower(strings.TrimSpace(b.DefaultLanguage))
+	if b.DefaultLanguage == "" {
+		b.DefaultLanguage = business.DefaultLanguage
+	}
+	if !languageTagPattern.MatchString(b.DefaultLanguage) {
+		return business.ErrInvalidBusinessData
+	}
+
+	supported := []string{b.DefaultLanguage}
+	seen := map[string]bool{b.DefaultLanguage: true}
+	for _, lang := range b.SupportedLanguages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if !languageTagPattern.MatchString(lang) {
+			return business.ErrInvalidBusinessData
+		}
+		if !seen[lang] {
+			seen[lang] = true
+			supported = append(supported, lang)
+		}
+	}
+	b.SupportedLanguages = supported
+	return nil
+}
+
+// setLogoThumbnails fills in thumbnail URLs for logos uploaded to our storage
+func (s *BusinessService) setLogoThumbnails(b *business.Business) {
+	if b != nil && b.Logo != "" {
+		b.LogoThumbnails = s.media.ThumbnailURLs(b.Logo)
+	}
+}
+
+// removeLogo deletes an uploaded logo, logging rather than returning failures
+func (s *BusinessService) removeLogo(ctx context.Context, url string, businessID int) {
+	if url == "" {
+		return
+	}
+	if err := s.media.DeleteImage(ctx, url, businessID); err != nil {
+		log.Printf("Error deleting logo %s: %v", url, err)
+	}
+}
+
+func validAllergenPolicy(policy string) bool {
+	return policy == business.AllergenPolicyWarn || policy == business.AllergenPolicyBlock
+}
+
+func validCostingMethod(method string) bool {
+	return method == business.CostingAverage || method == business.CostingFIFO
+}
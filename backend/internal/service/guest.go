@@ -0,0 +1,408 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/guest"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/domain/order"
+	"restaurant-management/internal/domain/table"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	guestTokenType = "guest"
+
+	defaultQRSize = 256
+	minQRSize     = 64
+	maxQRSize     = 2048
+)
+
+type GuestService struct {
+	tableRepo     table.Repository
+	businessRepo  business.Repository
+	menuService   menu.Service
+	orderService  order.Service
+	qrKey         []byte
+	sessionKey    []byte
+	publicBaseURL string
+	sessionTTL    time.Duration
+}
+
+// NewGuestService creates the guest ordering service. QR codes and guest sessions are
+// signed with keys derived from jwtKey, so guest tokens never pass as staff tokens.
+func NewGuestService(
+	tableRepo table.Repository,
+	businessRepo business.Repository,
+	menuService menu.Service,
+	orderService order.Service,
+	jwtKey string,
+	publicBaseURL string,
+	sessionTTL time.Duration,
+) guest.Service {
+	return &GuestService{
+		tableRepo:     tableRepo,
+		businessRepo:  businessRepo,
+		menuService:   menuService,
+		orderService:  orderService,
+		qrKey:         deriveKey(jwtKey, "table-qr"),
+		sessionKey:    deriveKey(jwtKey, "guest-session"),
+		publicBaseURL: strings.TrimSuffix(publicBaseURL, "/"),
+		sessionTTL:    sessionTTL,
+	}
+}
+
+func (s *GuestService) TableLink(ctx context.Context, tableID int, businessID int) (*guest.TableLink, error) {
+	if tableID <= 0 {
+		return nil, table.ErrInvalidTableID
+	}
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	t, err := s.tableRepo.GetBusinessTable(ctx, tableID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tableLink(t, businessID), nil
+}
+
+func (s *GuestService) TableQR(ctx context.Context, tableID int, businessID int, format string, size int) ([]byte, error) {
+	if size == 0 {
+		size = defaultQRSize
+	}
+	if size < minQRSize || size > maxQRSize || (format != guest.QRFormatPNG && format != guest.QRFormatSVG) {
+		return nil, guest.ErrInvalidQRFormat
+	}
+
+	link, err := s.TableLink(ctx, tableID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := qrcode.New(link.URL, qrcode.Medium)
+	if err != nil {
+		log.Printf("Error encoding QR code for table %d: %v", tableID, err)
+		return nil, err
+	}
+
+	if format == guest.QRFormatSVG {
+		return qrSVG(qr.Bitmap(), size), nil
+	}
+	return qr.PNG(size)
+}
+
+func (s *GuestService) RotateTableLink(ctx context.Context, tableID int, businessID int) (*guest.TableLink, error) {
+	if tableID <= 0 {
+		return nil, table.ErrInvalidTableID
+	}
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	if _, err := s.tableRepo.RotateQRVersion(ctx, tableID, businessID); err != nil {
+		return nil, err
+	}
+	return s.TableLink(ctx, tableID, businessID)
+}
+
+func (s *GuestService) StartSession(ctx context.Context, qrToken string) (*guest.Session, error) {
+	businessID, tableID, version, err := s.parseTableToken(qrToken)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.checkTable(ctx, businessID, tableID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	session := &guest.Session{
+		ID:          hex.EncodeToString(id),
+		BusinessID:  businessID,
+		TableID:     t.ID,
+		TableNumber: t.Number,
+		QRVersion:   version,
+		ExpiresAt:   time.Now().Add(s.sessionTTL).Truncate(time.Second),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ":          guestTokenType,
+		"sid":          session.ID,
+		"business_id":  session.BusinessID,
+		"table_id":     session.TableID,
+		"table_number": session.TableNumber,
+		"qr_version":   session.QRVersion,
+		"exp":          session.ExpiresAt.Unix(),
+	})
+	session.Token, err = token.SignedString(s.sessionKey)
+	if err != nil {
+		log.Printf("Failed to sign guest session for table %d: %v", tableID, err)
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *GuestService) ParseSession(ctx context.Context, tokenString string) (*guest.Session, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, guest.ErrInvalidSession
+		}
+		return s.sessionKey, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, guest.ErrInvalidSession
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != guestTokenType {
+		return nil, guest.ErrInvalidSession
+	}
+
+	session := &guest.Session{Token: tokenString}
+	session.ID, _ = claims["sid"].(string)
+	exp, okExp := claimInt(claims, "exp")
+	session.BusinessID, ok = claimInt(claims, "business_id")
+	session.TableID, _ = claimInt(claims, "table_id")
+	session.TableNumber, _ = claimInt(claims, "table_number")
+	session.QRVersion, _ = claimInt(claims, "qr_version")
+	if !ok || !okExp || session.ID == "" || session.TableID <= 0 {
+		return nil, guest.ErrInvalidSession
+	}
+	session.ExpiresAt = time.Unix(int64(exp), 0)
+	return session, nil
+}
+
+func (s *GuestService) GetMenu(ctx context.Context, businessID int, filter menu.MenuItemFilter, languages []string) (*guest.PublicMenu, error) {
+	b, err := s.activeBusiness(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	lang, defaultLang, err := s.menuService.ResolveLanguage(ctx, businessID, languages)
+	if err != nil {
+		return nil, err
+	}
+	if lang != defaultLang {
+		ctx = menu.WithLanguage(ctx, lang)
+	}
+
+	categories, err := s.menuService.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	filter.VisibleOnly = true
+	items, err := s.menuService.GetMenuItems(ctx, filter, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[int][]guest.PublicMenuItem)
+	for _, item := range items {
+		if !item.IsAvailable {
+			continue
+		}
+		byCategory[item.CategoryID] = append(byCategory[item.CategoryID], guest.PublicMenuItem{
+			ID:              item.ID,
+			Name:            item.Name,
+			Description:     item.Description,
+			Price:           item.Price,
+			ImageURL:        item.ImageURL,
+			Thumbnails:      item.Thumbnails,
+			PreparationTime: item.PreparationTime,
+			Calories:        item.Calories,
+			Allergens:       item.Allergens,
+			DietaryTags:     item.DietaryTags,
+		})
+	}
+
+	publicMenu := &guest.PublicMenu{
+		BusinessID:   b.ID,
+		BusinessName: b.Name,
+		Logo:         b.Logo,
+		Language:     lang,
+		Languages:    b.SupportedLanguages,
+		Categories:   []guest.PublicCategory{},
+		Bundles:      []guest.PublicBundle{},
+	}
+	for _, category := range categories {
+		if dishes := byCategory[category.ID]; len(dishes) > 0 {
+			publicMenu.Categories = append(publicMenu.Categories, guest.PublicCategory{
+				ID:    category.ID,
+				Name:  category.Name,
+				Items: dishes,
+			})
+		}
+	}
+
+	bundles, err := s.menuService.GetBundles(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		if bundle.IsAvailable {
+			publicMenu.Bundles = append(publicMenu.Bundles, guest.PublicBundle{
+				ID:          bundle.ID,
+				Name:        bundle.Name,
+				Description: bundle.Description,
+				Price:       bundle.Price,
+				Slots:       bundle.Slots,
+			})
+		}
+	}
+	return publicMenu, nil
+}
+
+func (s *GuestService) PlaceOrder(ctx context.Context, session *guest.Session, req guest.OrderRequest) (*order.Order, error) {
+	if session == nil {
+		return nil, guest.ErrInvalidSession
+	}
+
+	// Rotating the QR code of a table also ends the sessions opened from it
+	if _, err := s.checkTable(ctx, session.BusinessID, session.TableID, session.QRVersion); err != nil {
+		if errors.Is(err, guest.ErrInvalidQRToken) {
+			return nil, guest.ErrInvalidSession
+		}
+		return nil, err
+	}
+
+	return s.orderService.CreateGuestOrder(ctx, order.CreateOrderRequest{
+		TableID:   session.TableID,
+		Comment:   req.Comment,
+		Items:     req.Items,
+		Bundles:   req.Bundles,
+		Allergies: req.Allergies,
+		Seats:     req.Seats,
+	}, session.BusinessID)
+}
+
+func (s *GuestService) tableLink(t *table.Table, businessID int) *guest.TableLink {
+	token := s.signTableToken(businessID, t.ID, t.QRVersion)
+	return &guest.TableLink{
+		TableID:     t.ID,
+		TableNumber: t.Number,
+		Version:     t.QRVersion,
+		Token:       token,
+		URL:         s.publicBaseURL + "/t/" + token,
+	}
+}
+
+// checkTable verifies that a QR code still points at a table of an active business
+func (s *GuestService) checkTable(ctx context.Context, businessID, tableID, version int) (*table.Table, error) {
+	if _, err := s.activeBusiness(ctx, businessID); err != nil {
+		return nil, err
+	}
+
+	t, err := s.tableRepo.GetBusinessTable(ctx, tableID, businessID)
+	if err != nil {
+		if errors.Is(err, table.ErrTableNotFound) {
+			return nil, guest.ErrInvalidQRToken
+		}
+		return nil, err
+	}
+	if t.QRVersion != version {
+		return nil, guest.ErrInvalidQRToken
+	}
+	return t, nil
+}
+
+func (s *GuestService) activeBusiness(ctx context.Context, businessID int) (*business.Business, error) {
+	if businessID <= 0 {
+		return nil, guest.ErrBusinessUnavailable
+	}
+
+	b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, guest.ErrBusinessUnavailable
+		}
+		return nil, err
+	}
+	if b.Status != "" && b.Status != "active" {
+		return nil, guest.ErrBusinessUnavailable
+	}
+	return b, nil
+}
+
+// signTableToken encodes business-table-version followed by a truncated HMAC. The token
+// is kept short so the printed QR code stays easy to scan.
+func (s *GuestService) signTableToken(businessID, tableID, version int) string {
+	payload := fmt.Sprintf("%d-%d-%d", businessID, tableID, version)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.tableMAC(payload))
+}
+
+func (s *GuestService) parseTableToken(token string) (businessID, tableID, version int, err error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, 0, 0, guest.ErrInvalidQRToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.tableMAC(payload)) {
+		return 0, 0, 0, guest.ErrInvalidQRToken
+	}
+
+	parts := strings.Split(payload, "-")
+	if len(parts) != 3 {
+		return 0, 0, 0, guest.ErrInvalidQRToken
+	}
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		if ids[i], err = strconv.Atoi(part); err != nil || ids[i] <= 0 {
+			return 0, 0, 0, guest.ErrInvalidQRToken
+		}
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+func (s *GuestService) tableMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, s.qrKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)[:16]
+}
+
+// deriveKey derives a purpose-specific signing key from the server secret
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func claimInt(claims map[string]interface{}, key string) (int, bool) {
+	value, ok := claims[key].(float64)
+	return int(value), ok
+}
+
+// qrSVG draws the QR modules, including the quiet zone, as a single path
+func qrSVG(bitmap [][]bool, size int) []byte {
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, len(bitmap), len(bitmap))
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	fmt.Fprintf(&buf, `<path fill="#000" d="%s"/></svg>`, path.String())
+	return buf.Bytes()
+}
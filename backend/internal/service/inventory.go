@@ -3,16 +3,20 @@ package service
 import (
 	"context"
 	"log"
+	"restaurant-management/internal/domain/business"
 	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/media"
 	"strings"
 )
 
 type InventoryService struct {
-	repo inventory.Repository
+	repo         inventory.Repository
+	businessRepo business.Repository
+	media        media.Service
 }
 
-func NewInventoryService(repo inventory.Repository) inventory.Service {
-	return &InventoryService{repo: repo}
+func NewInventoryService(repo inventory.Repository, businessRepo business.Repository, mediaService media.Service) inventory.Service {
+	return &InventoryService{repo: repo, businessRepo: businessRepo, media: mediaService}
 }
 
 func (s *InventoryService) GetAllInventory(ctx context.Context, businessID int) ([]inventory.Inventory, error) {
@@ -24,12 +28,22 @@ func (s *InventoryService) GetAllInventory(ctx context.Context, businessID int)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.withUnits(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+	suppliers, err := s.repo.GetItemSuppliers(ctx, 0, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Suppliers = suppliers[items[i].ID]
+	}
 
 	// Check for low stock items and log warnings
 	for _, item := range items {
-		if item.Quantity <= item.MinQuantity {
+		if item.Quantity <= item.MinStock {
 			log.Printf("Warning: Low stock for item %s (ID: %d). Current: %.2f, Minimum: %.2f",
-				item.Name, item.ID, item.Quantity, item.MinQuantity)
+				item.Name, item.ID, item.Quantity, item.MinStock)
 		}
 	}
 
@@ -44,7 +58,23 @@ func (s *InventoryService) GetInventoryByID(ctx context.Context, id int, busines
 		return nil, inventory.ErrInvalidInventoryData
 	}
 
-	return s.repo.GetInventoryByID(ctx, id, businessID)
+	item, err := s.repo.GetInventoryByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	c, conversions, err := s.itemConverter(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	item.Conversions = conversions
+	item.MinStock = minStock(c, item)
+
+	suppliers, err := s.repo.GetItemSuppliers(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	item.Suppliers = suppliers[id]
+	return item, nil
 }
 
 func (s *InventoryService) CreateInventory(ctx context.Context, item *inventory.Inventory, businessID int) error {
@@ -68,17 +98,51 @@ func (s *InventoryService) CreateInventory(ctx context.Context, item *inventory.
 	if item.MinQuantity < 0 {
 		return inventory.ErrInvalidInventoryData
 	}
+	if item.UnitCost != nil && *item.UnitCost < 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+	if item.ParQuantity < 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+	if err := validateItemSuppliers(item.Suppliers); err != nil {
+		return err
+	}
+	allergens, unknown := canonicalAllergens(item.Allergens)
+	if unknown != "" {
+		return inventory.ErrInvalidInventoryData
+	}
+	item.Allergens = allergens
+	if _, err := s.checkUnits(ctx, item); err != nil {
+		return err
+	}
 
 	// Set business ID
 	item.BusinessID = businessID
 
 	// Check for low stock and log warning
-	if item.Quantity <= item.MinQuantity {
+	if item.Quantity <= item.MinStock {
 		log.Printf("Warning: Creating inventory item %s with low stock. Current: %.2f, Minimum: %.2f",
-			item.Name, item.Quantity, item.MinQuantity)
+			item.Name, item.Quantity, item.MinStock)
 	}
 
-	return s.repo.CreateInventory(ctx, item)
+	// The opening stock goes through the ledger like any other change
+	opening := item.Quantity
+	item.Quantity = 0
+	if err := s.repo.CreateInventory(ctx, item); err != nil {
+		return err
+	}
+	if len(item.Conversions) > 0 {
+		if err := s.repo.SetConversions(ctx, item.ID, item.Conversions, businessID); err != nil {
+			return err
+		}
+	}
+	if len(item.Suppliers) > 0 {
+		if err := s.repo.SetItemSuppliers(ctx, item.ID, item.Suppliers, businessID); err != nil {
+			return err
+		}
+	}
+	item.Quantity = opening
+	return s.adjustStock(ctx, item, 0, "Opening stock")
 }
 
 func (s *InventoryService) UpdateInventory(ctx context.Context, item *inventory.Inventory, businessID int) error {
@@ -105,6 +169,15 @@ func (s *InventoryService) UpdateInventory(ctx context.Context, item *inventory.
 	if item.MinQuantity < 0 {
 		return inventory.ErrInvalidInventoryData
 	}
+	if item.UnitCost != nil && *item.UnitCost < 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+	if item.ParQuantity < 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+	if err := validateItemSuppliers(item.Suppliers); err != nil {
+		return err
+	}
 
 	// Verify item exists
 	existing, err := s.repo.GetInventoryByID(ctx, item.ID, businessID)
@@ -115,13 +188,64 @@ func (s *InventoryService) UpdateInventory(ctx context.Context, item *inventory.
 	// Set business ID to maintain consistency
 	item.BusinessID = existing.BusinessID
 
+	// Keep the current allergens unless new ones are provided
+	if item.Allergens == nil {
+		item.Allergens = existing.Allergens
+	}
+	allergens, unknown := canonicalAllergens(item.Allergens)
+	if unknown != "" {
+		return inventory.ErrInvalidInventoryData
+	}
+	item.Allergens = allergens
+	keepCost := item.UnitCost == nil
+	if keepCost {
+		item.UnitCost = existing.UnitCost
+	}
+
+	// Keep the current conversions unless new ones are provided
+	newConversions := item.Conversions != nil
+	if !newConversions {
+		conversions, err := s.repo.GetConversions(ctx, item.ID, businessID)
+		if err != nil {
+			return err
+		}
+		item.Conversions = conversions[item.ID]
+	}
+	c, err := s.checkUnits(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	// A new stock unit must convert from the old one; the stock and the recipes are
+	// rescaled and the submitted quantity is read in the new unit
+	current := existing.Quantity
+	factor := 1.0
+	if c.key(item.Unit) != c.key(existing.Unit) {
+		f, ok := c.factor(existing.Unit, item.Unit)
+		if !ok {
+			return inventory.ErrIncompatibleUnits
+		}
+		factor = f
+		current = existing.Quantity * f
+		if keepCost && existing.UnitCost != nil {
+			cost := *existing.UnitCost / f
+			item.UnitCost = &cost
+		}
+	}
+
 	// Check for low stock and log warning
-	if item.Quantity <= item.MinQuantity {
+	if item.Quantity <= item.MinStock {
 		log.Printf("Warning: Updating inventory item %s to low stock. Current: %.2f, Minimum: %.2f",
-			item.Name, item.Quantity, item.MinQuantity)
+			item.Name, item.Quantity, item.MinStock)
 	}
 
-	return s.repo.UpdateInventory(ctx, item)
+	var conversions []inventory.Conversion
+	if newConversions {
+		conversions = item.Conversions
+	}
+	// A changed quantity is booked as an adjustment rather than overwritten
+	adjustment := stockAdjustment(item, current, "Stock edited by hand")
+	return s.repo.UpdateInventory(ctx, item, factor, conversions, adjustment)
 }
 
 func (s *InventoryService) DeleteInventory(ctx context.Context, id int, businessID int) error {
@@ -151,14 +275,17 @@ func (s *InventoryService) CheckLowStockLevels(ctx context.Context, businessID i
 	if err != nil {
 		return nil, err
 	}
+	if err := s.withUnits(ctx, items, businessID); err != nil {
+		return nil, err
+	}
 
-	// Filter items that are at or below minimum quantity
+	// Filter items that are at or below minimum quantity, compared in the stock unit
 	var lowStockItems []inventory.Inventory
 	for _, item := range items {
-		if item.Quantity <= item.MinQuantity {
+		if item.Quantity <= item.MinStock {
 			lowStockItems = append(lowStockItems, item)
 			log.Printf("Low stock detected: %s (ID: %d) - Current: %.2f, Minimum: %.2f",
-				item.Name, item.ID, item.Quantity, item.MinQuantity)
+				item.Name, item.ID, item.Quantity, item.MinStock)
 		}
 	}
 
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/inventory"
+	"sort"
+	"strings"
+)
+
+// defaultCostDays is the length of the cost of goods period when no start date is given
+const defaultCostDays = 30
+
+// GetValuation values the stock on hand. The weighted average method uses the current
+// average cost of each item; FIFO uses the cost of the layers still in stock, with any
+// stock outside them at the average cost. Negative stock is worth nothing.
+func (s *InventoryService) GetValuation(ctx context.Context, method string, businessID int) (*inventory.Valuation, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	method, err := s.costingMethod(ctx, method, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetAllInventory(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	layers := make(map[int]inventory.CostLayerTotal)
+	if method == business.CostingFIFO {
+		totals, err := s.repo.GetCostLayers(ctx, businessID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range totals {
+			layers[t.InventoryID] = t
+		}
+	}
+
+	valuation := &inventory.Valuation{Method: method, Items: []inventory.ItemValuation{}}
+	for _, item := range items {
+		v := inventory.ItemValuation{
+			InventoryID: item.ID,
+			Name:        item.Name,
+			Unit:        item.Unit,
+			Quantity:    round3(item.Quantity),
+		}
+		stock := math.Max(item.Quantity, 0)
+
+		switch method {
+		case business.CostingFIFO:
+			layer := layers[item.ID]
+			covered := math.Min(layer.Remaining, stock)
+			value := 0.0
+			if layer.Remaining > 0 {
+				// Layers beyond the stock, left by a rescale or a manual edit, scale down
+				value = layer.Value * covered / layer.Remaining
+				v.Uncosted = layer.Uncosted > 0
+			}
+			if rest := stock - covered; rest > 0 {
+				if item.UnitCost == nil {
+					v.Uncosted = true
+				} else {
+					value += rest * *item.UnitCost
+				}
+			}
+			v.Value = value
+			if stock > 0 && !v.Uncosted {
+				cost := roundMoney(value / stock)
+				v.UnitCost = &cost
+			}
+		default:
+			if item.UnitCost == nil {
+				v.Uncosted = stock > 0
+			} else {
+				v.Value = stock * *item.UnitCost
+				cost := *item.UnitCost
+				v.UnitCost = &cost
+			}
+		}
+
+		v.Value = roundMoney(v.Value)
+		valuation.Value += v.Value
+		if v.Uncosted {
+			valuation.Uncosted++
+		}
+		valuation.Items = append(valuation.Items, v)
+	}
+	valuation.Value = roundMoney(valuation.Value)
+
+	sort.Slice(valuation.Items, func(i, j int) bool {
+		if valuation.Items[i].Value != valuation.Items[j].Value {
+			return valuation.Items[i].Value > valuation.Items[j].Value
+		}
+		return valuation.Items[i].Name < valuation.Items[j].Name
+	})
+	return valuation, nil
+}
+
+// GetCostOfGoods sums the cost of the stock consumed and wasted in a period and sets it
+// against the sales of the completed orders of the same period
+func (s *InventoryService) GetCostOfGoods(ctx context.Context, query inventory.CostOfGoodsQuery, businessID int) (*inventory.CostOfGoods, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	method, err := s.costingMethod(ctx, query.Method, businessID)
+	if err != nil {
+		return nil, err
+	}
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	from, to, err := parsePeriod(query.From, query.To, defaultCostDays, loc)
+	if err != nil {
+		return nil, inventory.ErrInvalidCostPeriod
+	}
+
+	lines, err := s.repo.GetCostLines(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.repo.GetSalesTotal(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &inventory.CostOfGoods{
+		From:   from.Format(menuDateLayout),
+		To:     to.AddDate(0, 0, -1).Format(menuDateLayout),
+		Method: method,
+		Sales:  roundMoney(sales),
+		Items:  []inventory.ItemCostOfGoods{},
+	}
+
+	type itemKey struct {
+		id   int
+		unit string
+	}
+	items := make(map[itemKey]*inventory.ItemCostOfGoods)
+	for _, line := range lines {
+		key := itemKey{line.InventoryID, line.Unit}
+		item := items[key]
+		if item == nil {
+			item = &inventory.ItemCostOfGoods{InventoryID: line.InventoryID, Name: line.Name, Unit: line.Unit}
+			items[key] = item
+		}
+
+		value, uncosted := line.AverageValue, line.Uncosted
+		if method == business.CostingFIFO {
+			value, uncosted = line.FIFOValue, line.FIFOUncosted
+		}
+		if uncosted {
+			item.Uncosted = true
+		}
+		if line.Type == inventory.MovementWaste {
+			item.Wasted += line.Quantity
+			item.Waste += value
+			report.Waste += value
+		} else {
+			item.Consumed += line.Quantity
+			item.Consumption += value
+			report.Consumption += value
+		}
+	}
+
+	for _, item := range items {
+		item.Consumed = round3(item.Consumed)
+		item.Wasted = round3(item.Wasted)
+		item.Consumption = roundMoney(item.Consumption)
+		item.Waste = roundMoney(item.Waste)
+		item.Total = roundMoney(item.Consumption + item.Waste)
+		report.Items = append(report.Items, *item)
+	}
+	report.Consumption = roundMoney(report.Consumption)
+	report.Waste = roundMoney(report.Waste)
+	report.Total = roundMoney(report.Consumption + report.Waste)
+	if report.Sales > 0 {
+		percent := round2(report.Total / report.Sales * 100)
+		report.FoodCostPercent = &percent
+	}
+
+	sort.Slice(report.Items, func(i, j int) bool {
+		if report.Items[i].Total != report.Items[j].Total {
+			return report.Items[i].Total > report.Items[j].Total
+		}
+		return report.Items[i].Name < report.Items[j].Name
+	})
+	return report, nil
+}
+
+// costingMethod returns the requested method, or the one the business is set to
+func (s *InventoryService) costingMethod(ctx context.Context, method string, businessID int) (string, error) {
+	method = strings.ToLower(strings.TrimSpace(method))
+	if method == "" {
+		method = business.CostingAverage
+		if s.businessRepo != nil {
+			if b, err := s.businessRepo.GetBusinessByID(ctx, businessID); err == nil && b != nil && b.CostingMethod != "" {
+				method = b.CostingMethod
+			}
+		}
+	}
+	if method != business.CostingAverage && method != business.CostingFIFO {
+		return "", inventory.ErrInvalidCostingMethod
+	}
+	return method, nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetLots lists the lots of a business with stock left, first expiring first. With
+// ExpiringWithin only lots expiring within that many days are listed, expired ones included.
+func (s *InventoryService) GetLots(ctx context.Context, query inventory.LotQuery, businessID int) ([]inventory.Lot, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if query.InventoryID < 0 {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+	if query.ExpiringWithin != nil && *query.ExpiringWithin < 0 {
+		return nil, inventory.ErrInvalidLot
+	}
+
+	today := s.lotToday(ctx, businessID)
+	filter := inventory.LotFilter{InventoryID: query.InventoryID, Open: !query.All}
+	if query.ExpiringWithin != nil {
+		filter.ExpiresBefore = today.AddDate(0, 0, *query.ExpiringWithin+1).Format(menuDateLayout)
+	}
+	return s.lots(ctx, filter, today, businessID)
+}
+
+// GetLot returns a lot with the movements that drew from it
+func (s *InventoryService) GetLot(ctx context.Context, id int, businessID int) (*inventory.Lot, error) {
+	if id <= 0 {
+		return nil, inventory.ErrLotNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	lot, err := s.repo.GetLot(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	setDaysLeft(lot, s.lotToday(ctx, businessID))
+	return lot, nil
+}
+
+// GetExpiredLots lists the lots with stock left whose expiry date has passed
+func (s *InventoryService) GetExpiredLots(ctx context.Context, businessID int) ([]inventory.Lot, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	today := s.lotToday(ctx, businessID)
+	filter := inventory.LotFilter{Open: true, ExpiresBefore: today.Format(menuDateLayout)}
+	return s.lots(ctx, filter, today, businessID)
+}
+
+// WriteOffExpiredLots logs the stock left in each expired lot as spoiled waste taken
+// from that lot. Either every lot is written off or none is.
+func (s *InventoryService) WriteOffExpiredLots(ctx context.Context, req inventory.WriteOffRequest, businessID int) ([]inventory.Waste, error) {
+	expired, err := s.GetExpiredLots(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	lots := expired
+	if len(req.LotIDs) > 0 {
+		byID := make(map[int]inventory.Lot, len(expired))
+		for _, lot := range expired {
+			byID[lot.ID] = lot
+		}
+		lots = make([]inventory.Lot, 0, len(req.LotIDs))
+		picked := make(map[int]bool, len(req.LotIDs))
+		for _, id := range req.LotIDs {
+			lot, ok := byID[id]
+			if !ok {
+				return nil, inventory.ErrInvalidWriteOff
+			}
+			if !picked[id] {
+				picked[id] = true
+				lots = append(lots, lot)
+			}
+		}
+	}
+	if len(lots) == 0 {
+		return []inventory.Waste{}, nil
+	}
+
+	note := strings.TrimSpace(req.Note)
+	entries := make([]*inventory.Waste, 0, len(lots))
+	for _, lot := range lots {
+		inventoryID, lotID := lot.InventoryID, lot.ID
+		w := &inventory.Waste{
+			BusinessID:  businessID,
+			InventoryID: &inventoryID,
+			Quantity:    round3(lot.Remaining),
+			Unit:        lot.Unit,
+			Reason:      inventory.WasteSpoiled,
+			Note:        note,
+			UserID:      req.UserID,
+		}
+		if w.Note == "" {
+			w.Note = "Expired lot " + lotLabel(lot) + " (expired " + lot.ExpiresOn + ")"
+		}
+		w.Movements = []inventory.Movement{{
+			InventoryID: inventoryID,
+			BusinessID:  businessID,
+			Type:        inventory.MovementWaste,
+			Quantity:    -lot.Remaining,
+			UserID:      req.UserID,
+			Reason:      string(w.Reason) + ": " + w.Note,
+			LotID:       &lotID,
+		}}
+		entries = append(entries, w)
+	}
+
+	if err := s.repo.CreateWastes(ctx, entries); err != nil {
+		return nil, err
+	}
+	written := make([]inventory.Waste, 0, len(entries))
+	for _, entry := range entries {
+		w, err := s.GetWaste(ctx, entry.ID, businessID)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, *w)
+	}
+	return written, nil
+}
+
+// GetExpiringLots returns the lots with stock expiring within days, expired ones
+// included, that no expiry alert was sent for
+func (s *InventoryService) GetExpiringLots(ctx context.Context, days int, businessID int) ([]inventory.Lot, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if days < 0 {
+		return nil, inventory.ErrInvalidLot
+	}
+
+	today := s.lotToday(ctx, businessID)
+	filter := inventory.LotFilter{
+		Open:          true,
+		ExpiresBefore: today.AddDate(0, 0, days+1).Format(menuDateLayout),
+		Unalerted:     true,
+	}
+	return s.lots(ctx, filter, today, businessID)
+}
+
+func (s *InventoryService) MarkExpiryAlerted(ctx context.Context, lotIDs []int, businessID int) error {
+	if businessID <= 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+	return s.repo.MarkExpiryAlerted(ctx, lotIDs, businessID)
+}
+
+func (s *InventoryService) lots(ctx context.Context, filter inventory.LotFilter, today time.Time, businessID int) ([]inventory.Lot, error) {
+	lots, err := s.repo.GetLots(ctx, filter, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if lots == nil {
+		lots = []inventory.Lot{}
+	}
+	for i := range lots {
+		setDaysLeft(&lots[i], today)
+	}
+	return lots, nil
+}
+
+// lotToday returns the start of the current day in business local time
+func (s *InventoryService) lotToday(ctx context.Context, businessID int) time.Time {
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+}
+
+func setDaysLeft(lot *inventory.Lot, today time.Time) {
+	if lot.ExpiresOn == "" {
+		return
+	}
+	expires, err := time.ParseInLocation(menuDateLayout, lot.ExpiresOn, today.Location())
+	if err != nil {
+		return
+	}
+	// Rounded, as a day may be 23 or 25 hours around a clock change
+	days := int(math.Round(expires.Sub(today).Hours() / 24))
+	lot.DaysLeft = &days
+}
+
+// lotLabel names a lot by its number, or by ID when it has none
+func lotLabel(lot inventory.Lot) string {
+	if lot.LotNumber != "" {
+		return lot.LotNumber
+	}
+	return "#" + strconv.Itoa(lot.ID)
+}
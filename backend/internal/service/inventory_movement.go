@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"log"
+	"restaurant-management/internal/domain/inventory"
+	"strings"
+	"time"
+)
+
+// RecordMovement validates a movement and appends it to the ledger. Consumption and
+// waste take stock out, receipts bring it in, and a transfer does both on two items
+// whose units convert. A quantity entered in another unit is converted to the stock unit,
+// and so is the cost paid for a receipt. Every receipt opens a lot.
+func (s *InventoryService) RecordMovement(ctx context.Context, inventoryID int, req inventory.MovementRequest, businessID int) ([]inventory.Movement, error) {
+	if inventoryID <= 0 {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if !inventory.ValidMovementType(req.Type) || req.Quantity == 0 {
+		return nil, inventory.ErrInvalidMovement
+	}
+	if req.Type != inventory.MovementAdjustment && req.Quantity < 0 {
+		return nil, inventory.ErrInvalidMovement
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" && (req.Type == inventory.MovementWaste || req.Type == inventory.MovementAdjustment) {
+		return nil, inventory.ErrInvalidMovement
+	}
+	if (req.OrderID != nil && *req.OrderID <= 0) || (req.RequestID != nil && *req.RequestID <= 0) {
+		return nil, inventory.ErrInvalidMovement
+	}
+	if req.UnitCost != nil && (req.Type != inventory.MovementReceipt || *req.UnitCost < 0) {
+		return nil, inventory.ErrInvalidMovement
+	}
+	lot, err := receiptLot(req)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.GetInventoryByID(ctx, inventoryID, businessID)
+	if err != nil || item == nil {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+	c, _, err := s.itemConverter(ctx, inventoryID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Unit) != "" {
+		f, ok := c.factor(req.Unit, item.Unit)
+		if !ok {
+			return nil, inventory.ErrIncompatibleUnits
+		}
+		req.Quantity *= f
+		if req.UnitCost != nil {
+			cost := *req.UnitCost / f
+			req.UnitCost = &cost
+		}
+	}
+
+	m := inventory.Movement{
+		InventoryID: inventoryID,
+		BusinessID:  businessID,
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		UserID:      req.UserID,
+		Reason:      req.Reason,
+		OrderID:     req.OrderID,
+		RequestID:   req.RequestID,
+		UnitCost:    req.UnitCost,
+		Lot:         lot,
+	}
+	switch req.Type {
+	case inventory.MovementConsumption, inventory.MovementWaste:
+		m.Quantity = -req.Quantity
+	case inventory.MovementTransfer:
+		m.Quantity = -req.Quantity
+	}
+	movements := []inventory.Movement{m}
+
+	if req.Type == inventory.MovementTransfer {
+		if req.ToInventoryID <= 0 || req.ToInventoryID == inventoryID {
+			return nil, inventory.ErrInvalidMovement
+		}
+		target, err := s.repo.GetInventoryByID(ctx, req.ToInventoryID, businessID)
+		if err != nil || target == nil {
+			return nil, inventory.ErrInventoryItemNotFound
+		}
+		// The source item's units are tried first, then the target's own
+		f, ok := c.factor(item.Unit, target.Unit)
+		if !ok {
+			tc, _, err := s.itemConverter(ctx, target.ID, businessID)
+			if err != nil {
+				return nil, err
+			}
+			if f, ok = tc.factor(item.Unit, target.Unit); !ok {
+				return nil, inventory.ErrIncompatibleUnits
+			}
+		}
+
+		toID, fromID := req.ToInventoryID, inventoryID
+		movements[0].TransferItemID = &toID
+		in := m
+		in.InventoryID = req.ToInventoryID
+		in.Quantity = req.Quantity * f
+		in.TransferItemID = &fromID
+		movements = append(movements, in)
+	}
+
+	recorded, err := s.repo.RecordMovements(ctx, movements, false)
+	if err != nil {
+		return nil, err
+	}
+	minimum := minStock(c, item)
+	for _, rec := range recorded {
+		if rec.InventoryID == inventoryID && rec.BalanceAfter <= minimum {
+			log.Printf("Warning: Low stock for item %s (ID: %d) after %s. Current: %.2f, Minimum: %.2f",
+				item.Name, item.ID, rec.Type, rec.BalanceAfter, minimum)
+		}
+	}
+	return recorded, nil
+}
+
+func (s *InventoryService) GetMovements(ctx context.Context, inventoryID int, query inventory.MovementQuery, businessID int) ([]inventory.Movement, error) {
+	if inventoryID <= 0 {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if query.Type != "" && !inventory.ValidMovementType(query.Type) {
+		return nil, inventory.ErrInvalidMovement
+	}
+	if query.Limit < 0 {
+		return nil, inventory.ErrInvalidMovement
+	}
+
+	if item, err := s.repo.GetInventoryByID(ctx, inventoryID, businessID); err != nil || item == nil {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+
+	filter := inventory.MovementFilter{Type: query.Type, Limit: query.Limit}
+	if filter.Limit == 0 {
+		filter.Limit = inventory.DefaultMovementLimit
+	}
+	if filter.Limit > inventory.MaxMovementLimit {
+		filter.Limit = inventory.MaxMovementLimit
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	if query.From != "" {
+		from, err := time.ParseInLocation(menuDateLayout, query.From, loc)
+		if err != nil {
+			return nil, inventory.ErrInvalidMovementPeriod
+		}
+		filter.From = from
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation(menuDateLayout, query.To, loc)
+		if err != nil {
+			return nil, inventory.ErrInvalidMovementPeriod
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, inventory.ErrInvalidMovementPeriod
+	}
+
+	return s.repo.GetMovements(ctx, inventoryID, filter, businessID)
+}
+
+// ConsumeOrder takes the recipe ingredients of a completed order out of stock. Sales
+// are recorded even when the book stock runs negative; the count will correct it.
+func (s *InventoryService) ConsumeOrder(ctx context.Context, orderID int, userID int, businessID int) error {
+	if orderID <= 0 || businessID <= 0 {
+		return inventory.ErrInvalidMovement
+	}
+
+	consumed, err := s.repo.HasOrderConsumption(ctx, orderID, businessID)
+	if err != nil || consumed {
+		return err
+	}
+
+	movements, err := s.repo.GetOrderConsumption(ctx, orderID, businessID)
+	if err != nil {
+		return err
+	}
+	for i := range movements {
+		movements[i].UserID = userID
+	}
+
+	_, err = s.repo.RecordMovements(ctx, movements, true)
+	return err
+}
+
+// adjustStock records the difference between the wanted and the current quantity of
+// an item as an adjustment
+func (s *InventoryService) adjustStock(ctx context.Context, item *inventory.Inventory, current float64, reason string) error {
+	adjustment := stockAdjustment(item, current, reason)
+	if adjustment == nil {
+		return nil
+	}
+
+	recorded, err := s.repo.RecordMovements(ctx, []inventory.Movement{*adjustment}, false)
+	if err != nil {
+		return err
+	}
+	item.Quantity = recorded[0].BalanceAfter
+	return nil
+}
+
+// stockAdjustment is the adjustment that takes an item from the current to the wanted
+// quantity, or nil when they are equal
+func stockAdjustment(item *inventory.Inventory, current float64, reason string) *inventory.Movement {
+	delta := item.Quantity - current
+	if delta == 0 {
+		return nil
+	}
+	return &inventory.Movement{
+		InventoryID: item.ID,
+		BusinessID:  item.BusinessID,
+		Type:        inventory.MovementAdjustment,
+		Quantity:    delta,
+		UserID:      item.ChangedBy,
+		Reason:      reason,
+	}
+}
+
+// receiptLot returns the details of the lot a receipt opens. Other movements may not
+// carry any.
+func receiptLot(req inventory.MovementRequest) (*inventory.Lot, error) {
+	lot := &inventory.Lot{
+		LotNumber: strings.TrimSpace(req.LotNumber),
+		ExpiresOn: strings.TrimSpace(req.ExpiresOn),
+	}
+	if req.SupplierID != 0 {
+		supplierID := req.SupplierID
+		lot.SupplierID = &supplierID
+	}
+	if req.Type != inventory.MovementReceipt {
+		if lot.LotNumber != "" || lot.ExpiresOn != "" || lot.SupplierID != nil {
+			return nil, inventory.ErrInvalidLot
+		}
+		return nil, nil
+	}
+
+	if len(lot.LotNumber) > 50 || req.SupplierID < 0 {
+		return nil, inventory.ErrInvalidLot
+	}
+	if lot.ExpiresOn != "" {
+		if _, err := time.Parse(menuDateLayout, lot.ExpiresOn); err != nil {
+			return nil, inventory.ErrInvalidLot
+		}
+	}
+	return lot, nil
+}
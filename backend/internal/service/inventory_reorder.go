@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetReorderSuggestions works out how much of each low stock item to order and groups
+// the lines by supplier: the preferred supplier of the item, else the first active
+// supplier whose categories include the item category.
+func (s *InventoryService) GetReorderSuggestions(ctx context.Context, query inventory.ReorderQuery, businessID int) (*inventory.ReorderPlan, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if query.CoverDays == 0 {
+		query.CoverDays = inventory.DefaultReorderCoverDays
+	}
+	if query.LookbackDays == 0 {
+		query.LookbackDays = inventory.DefaultReorderLookbackDays
+	}
+	if query.CoverDays < 0 || query.CoverDays > inventory.MaxReorderDays ||
+		query.LookbackDays < 0 || query.LookbackDays > inventory.MaxReorderDays {
+		return nil, inventory.ErrInvalidReorder
+	}
+
+	low, err := s.CheckLowStockLevels(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	plan := &inventory.ReorderPlan{
+		CoverDays:    query.CoverDays,
+		LookbackDays: query.LookbackDays,
+		Groups:       []inventory.ReorderGroup{},
+	}
+	if len(low) == 0 {
+		return plan, nil
+	}
+
+	to := time.Now()
+	used, err := s.repo.GetConsumption(ctx, to.AddDate(0, 0, -query.LookbackDays), to, businessID)
+	if err != nil {
+		return nil, err
+	}
+	onOrder, err := s.repo.GetOnOrder(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.repo.GetItemSuppliers(ctx, 0, businessID)
+	if err != nil {
+		return nil, err
+	}
+	suppliers, err := s.repo.GetActiveSuppliers(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	units, err := s.repo.GetUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversions, err := s.repo.GetConversions(ctx, 0, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[int]string, len(suppliers))
+	for _, sup := range suppliers {
+		active[sup.ID] = sup.Name
+	}
+
+	groups := make(map[int]*inventory.ReorderGroup) // 0 for items without a supplier
+	var order []int
+	for _, item := range low {
+		line := inventory.ReorderLine{
+			InventoryID: item.ID,
+			Name:        item.Name,
+			Category:    item.Category,
+			Unit:        item.Unit,
+			Quantity:    round3(item.Quantity),
+			MinStock:    round3(item.MinStock),
+			ParQuantity: item.ParQuantity,
+			OnOrder:     round3(onOrder[item.ID]),
+		}
+		if query.LookbackDays > 0 {
+			line.DailyUsage = round3(used[item.ID] / float64(query.LookbackDays))
+		}
+		target := math.Max(item.ParQuantity, item.MinStock+line.DailyUsage*float64(query.CoverDays))
+		line.Target = round3(target)
+
+		need := target - item.Quantity - onOrder[item.ID]
+		if c := newUnitConverter(units, conversions[item.ID]); isCountUnit(c, item.Unit) {
+			need = math.Ceil(need - 1e-9)
+		} else {
+			need = math.Ceil(need*1000-1e-6) / 1000
+		}
+		if need <= 0 {
+			continue
+		}
+		line.OrderQuantity = need
+
+		supplierID, name, source := pickSupplier(item, links[item.ID], suppliers, active)
+		line.Source = source
+		group := groups[supplierID]
+		if group == nil {
+			group = &inventory.ReorderGroup{SupplierName: name}
+			if supplierID != 0 {
+				id := supplierID
+				group.SupplierID = &id
+			}
+			groups[supplierID] = group
+			order = append(order, supplierID)
+		}
+		group.Lines = append(group.Lines, line)
+	}
+
+	// Suppliers by name, the items without one last
+	sort.Slice(order, func(i, j int) bool {
+		a, b := groups[order[i]], groups[order[j]]
+		if (a.SupplierID == nil) != (b.SupplierID == nil) {
+			return b.SupplierID == nil
+		}
+		return a.SupplierName < b.SupplierName
+	})
+	for _, id := range order {
+		group := groups[id]
+		sort.Slice(group.Lines, func(i, j int) bool { return group.Lines[i].Name < group.Lines[j].Name })
+		plan.Groups = append(plan.Groups, *group)
+	}
+	return plan, nil
+}
+
+// CreateReorderRequests turns the reorder suggestions into draft requests, one per
+// supplier, for a manager to approve. Items without a supplier are returned but not ordered.
+func (s *InventoryService) CreateReorderRequests(ctx context.Context, query inventory.ReorderQuery, businessID int) (*inventory.ReorderPlan, error) {
+	plan, err := s.GetReorderSuggestions(ctx, query, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Groups) == 0 {
+		return plan, nil
+	}
+	if err := s.repo.CreateReorderRequests(ctx, plan.Groups, businessID); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// pickSupplier returns the active preferred supplier of an item, else the first active
+// supplier covering its category, with how it was picked; 0 when there is none
+func pickSupplier(item inventory.Inventory, links []inventory.ItemSupplier, suppliers []inventory.SupplierInfo, active map[int]string) (int, string, string) {
+	for _, link := range links {
+		if name, ok := active[link.SupplierID]; ok && link.Preferred {
+			return link.SupplierID, name, inventory.ReorderSourcePreferred
+		}
+	}
+	category := strings.TrimSpace(item.Category)
+	for _, sup := range suppliers {
+		for _, c := range sup.Categories {
+			if strings.EqualFold(strings.TrimSpace(c), category) {
+				return sup.ID, sup.Name, inventory.ReorderSourceCategory
+			}
+		}
+	}
+	return 0, "", ""
+}
+
+// isCountUnit reports whether the stock is counted in pieces, so orders are whole
+func isCountUnit(c *unitConverter, unit string) bool {
+	f, ok := c.factor(unit, "pcs")
+	return ok && f == 1
+}
+
+// validateItemSuppliers checks that no supplier is linked twice and at most one is preferred
+func validateItemSuppliers(suppliers []inventory.ItemSupplier) error {
+	seen := make(map[int]bool, len(suppliers))
+	preferred := 0
+	for _, sup := range suppliers {
+		if sup.SupplierID <= 0 || seen[sup.SupplierID] {
+			return inventory.ErrInvalidSupplier
+		}
+		seen[sup.SupplierID] = true
+		if sup.Preferred {
+			preferred++
+		}
+	}
+	if preferred > 1 {
+		return inventory.ErrInvalidSupplier
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"sort"
+	"strings"
+)
+
+const defaultVarianceDays = 90
+
+func (s *InventoryService) OpenStocktake(ctx context.Context, req inventory.StocktakeRequest, businessID int) (*inventory.Stocktake, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	st := &inventory.Stocktake{
+		BusinessID: businessID,
+		Category:   strings.TrimSpace(req.Category),
+		Note:       strings.TrimSpace(req.Note),
+		OpenedBy:   req.UserID,
+	}
+	if err := s.repo.CreateStocktake(ctx, st); err != nil {
+		return nil, err
+	}
+	return s.GetStocktake(ctx, st.ID, businessID)
+}
+
+func (s *InventoryService) GetStocktakes(ctx context.Context, status inventory.StocktakeStatus, businessID int) ([]inventory.Stocktake, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if status != "" && !inventory.ValidStocktakeStatus(status) {
+		return nil, inventory.ErrInvalidStocktake
+	}
+	return s.repo.GetStocktakes(ctx, status, businessID)
+}
+
+// GetStocktake returns a session with the counted quantity and the variance of each line
+func (s *InventoryService) GetStocktake(ctx context.Context, id int, businessID int) (*inventory.Stocktake, error) {
+	if id <= 0 {
+		return nil, inventory.ErrStocktakeNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	st, err := s.repo.GetStocktake(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	st.VarianceValue = 0
+	for i := range st.Lines {
+		line := &st.Lines[i]
+		if len(line.Counts) == 0 {
+			continue
+		}
+		var counted float64
+		for _, c := range line.Counts {
+			counted += c.Quantity
+		}
+		counted = round3(counted)
+		variance := round3(counted - line.SystemQuantity)
+		line.Counted = &counted
+		line.Variance = &variance
+		if line.UnitCost != nil {
+			value := roundMoney(variance * *line.UnitCost)
+			line.VarianceValue = &value
+			st.VarianceValue += value
+		}
+	}
+	st.VarianceValue = roundMoney(st.VarianceValue)
+	return st, nil
+}
+
+// SubmitCounts records what a counter found. Quantities entered in another unit are
+// converted to the unit the item had when the session opened.
+func (s *InventoryService) SubmitCounts(ctx context.Context, stocktakeID int, req inventory.CountRequest, businessID int) (*inventory.Stocktake, error) {
+	if stocktakeID <= 0 {
+		return nil, inventory.ErrStocktakeNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if len(req.Counts) == 0 {
+		return nil, inventory.ErrInvalidCount
+	}
+
+	st, err := s.repo.GetStocktake(ctx, stocktakeID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if st.Status != inventory.StocktakeOpen {
+		return nil, inventory.ErrStocktakeClosed
+	}
+	lineUnits := make(map[int]string, len(st.Lines))
+	for _, line := range st.Lines {
+		lineUnits[line.InventoryID] = line.Unit
+	}
+
+	units, err := s.repo.GetUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversions, err := s.repo.GetConversions(ctx, 0, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]inventory.StocktakeCount, 0, len(req.Counts))
+	seen := make(map[int]bool, len(req.Counts))
+	for _, entry := range req.Counts {
+		unit, ok := lineUnits[entry.InventoryID]
+		if !ok || seen[entry.InventoryID] || entry.Quantity < 0 || math.IsNaN(entry.Quantity) || math.IsInf(entry.Quantity, 0) {
+			return nil, inventory.ErrInvalidCount
+		}
+		seen[entry.InventoryID] = true
+
+		quantity := entry.Quantity
+		if strings.TrimSpace(entry.Unit) != "" {
+			f, ok := newUnitConverter(units, conversions[entry.InventoryID]).factor(entry.Unit, unit)
+			if !ok {
+				return nil, inventory.ErrIncompatibleUnits
+			}
+			quantity *= f
+		}
+		counts = append(counts, inventory.StocktakeCount{InventoryID: entry.InventoryID, Quantity: round3(quantity)})
+	}
+
+	if err := s.repo.SaveCounts(ctx, stocktakeID, req.UserID, counts, businessID); err != nil {
+		return nil, err
+	}
+	return s.GetStocktake(ctx, stocktakeID, businessID)
+}
+
+// ApproveStocktake posts the variance of every counted line as an adjustment. The
+// variance is measured against the quantity when the session opened, so sales and
+// deliveries booked during the count are kept.
+func (s *InventoryService) ApproveStocktake(ctx context.Context, id int, userID int, businessID int) (*inventory.Stocktake, error) {
+	st, err := s.GetStocktake(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if st.Status != inventory.StocktakeOpen {
+		return nil, inventory.ErrStocktakeClosed
+	}
+
+	var adjustments []inventory.Movement
+	reason := fmt.Sprintf("Stocktake #%d", st.ID)
+	for _, line := range st.Lines {
+		if line.Variance == nil || *line.Variance == 0 {
+			continue
+		}
+		adjustments = append(adjustments, inventory.Movement{
+			InventoryID: line.InventoryID,
+			BusinessID:  businessID,
+			Type:        inventory.MovementAdjustment,
+			Quantity:    *line.Variance,
+			UserID:      userID,
+			Reason:      reason,
+		})
+	}
+
+	if err := s.repo.ApproveStocktake(ctx, id, userID, adjustments, businessID); err != nil {
+		return nil, err
+	}
+	return s.GetStocktake(ctx, id, businessID)
+}
+
+func (s *InventoryService) CancelStocktake(ctx context.Context, id int, businessID int) error {
+	if id <= 0 {
+		return inventory.ErrStocktakeNotFound
+	}
+	if businessID <= 0 {
+		return inventory.ErrInvalidInventoryData
+	}
+
+	if _, err := s.repo.GetStocktake(ctx, id, businessID); err != nil {
+		return err
+	}
+	return s.repo.CancelStocktake(ctx, id, businessID)
+}
+
+// GetVarianceReport sums the variance found by the sessions approved in a period,
+// by item, with the items losing the most value first
+func (s *InventoryService) GetVarianceReport(ctx context.Context, query inventory.VarianceQuery, businessID int) (*inventory.VarianceReport, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if query.InventoryID < 0 {
+		return nil, inventory.ErrInventoryItemNotFound
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	from, to, err := parsePeriod(query.From, query.To, defaultVarianceDays, loc)
+	if err != nil {
+		return nil, inventory.ErrInvalidVariancePeriod
+	}
+
+	items, err := s.repo.GetStocktakeVariances(ctx, from, to, query.InventoryID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &inventory.VarianceReport{
+		From:  from.Format(menuDateLayout),
+		To:    to.AddDate(0, 0, -1).Format(menuDateLayout),
+		Items: make([]inventory.ItemVariance, 0, len(items)),
+	}
+	for _, item := range items {
+		for i, session := range item.Sessions {
+			item.Quantity += session.Quantity
+			if session.Value != nil {
+				value := roundMoney(*session.Value)
+				item.Sessions[i].Value = &value
+				item.ValueLost -= value
+			}
+		}
+		item.Quantity = round3(item.Quantity)
+		item.ValueLost = roundMoney(item.ValueLost)
+		report.ValueLost += item.ValueLost
+		report.Items = append(report.Items, item)
+	}
+	report.ValueLost = roundMoney(report.ValueLost)
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].ValueLost > report.Items[j].ValueLost
+	})
+	return report, nil
+}
+
+// round3 rounds a stock quantity to the precision of the ledger
+func round3(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"strings"
+)
+
+// unitConverter converts between catalogue units, freely within a dimension, and the
+// units an item defines through its own conversions
+type unitConverter struct {
+	catalogue   map[string]inventory.Unit // by lower-case code and alias
+	byDimension map[inventory.Dimension][]inventory.Unit
+	edges       map[string]map[string]float64 // item conversions both ways
+}
+
+func newUnitConverter(units []inventory.Unit, conversions []inventory.Conversion) *unitConverter {
+	c := &unitConverter{
+		catalogue:   make(map[string]inventory.Unit, len(units)),
+		byDimension: make(map[inventory.Dimension][]inventory.Unit),
+		edges:       make(map[string]map[string]float64),
+	}
+	for _, u := range units {
+		c.catalogue[strings.ToLower(u.Code)] = u
+		for _, alias := range u.Aliases {
+			c.catalogue[strings.ToLower(strings.TrimSpace(alias))] = u
+		}
+		c.byDimension[u.Dimension] = append(c.byDimension[u.Dimension], u)
+	}
+	for _, conv := range conversions {
+		c.add(conv)
+	}
+	return c
+}
+
+// key identifies a unit: the catalogue code, or the trimmed lower-case name of an item unit
+func (c *unitConverter) key(unit string) string {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if u, ok := c.catalogue[unit]; ok {
+		return strings.ToLower(u.Code)
+	}
+	return unit
+}
+
+func (c *unitConverter) known(unit string) bool {
+	_, ok := c.catalogue[strings.ToLower(strings.TrimSpace(unit))]
+	return ok
+}
+
+func (c *unitConverter) add(conv inventory.Conversion) {
+	from, to := c.key(conv.FromUnit), c.key(conv.ToUnit)
+	if c.edges[from] == nil {
+		c.edges[from] = make(map[string]float64)
+	}
+	if c.edges[to] == nil {
+		c.edges[to] = make(map[string]float64)
+	}
+	c.edges[from][to] = conv.Factor
+	c.edges[to][from] = 1 / conv.Factor
+}
+
+// factor returns how many to units make one from unit
+func (c *unitConverter) factor(from, to string) (float64, bool) {
+	start, target := c.key(from), c.key(to)
+	if start == "" || target == "" {
+		return 0, false
+	}
+	if start == target {
+		return 1, true
+	}
+
+	amounts := map[string]float64{start: 1}
+	queue := []string{start}
+	visit := func(unit string, amount float64) {
+		if _, seen := amounts[unit]; !seen {
+			amounts[unit] = amount
+			queue = append(queue, unit)
+		}
+	}
+	for len(queue) > 0 {
+		unit := queue[0]
+		queue = queue[1:]
+		amount := amounts[unit]
+		if u, ok := c.catalogue[unit]; ok {
+			for _, other := range c.byDimension[u.Dimension] {
+				visit(strings.ToLower(other.Code), amount*u.Factor/other.Factor)
+			}
+		}
+		for next, f := range c.edges[unit] {
+			visit(next, amount*f)
+		}
+	}
+
+	f, ok := amounts[target]
+	return f, ok
+}
+
+// validateConversions checks the conversions of an item with the given stock unit: each
+// one links a unit outside the catalogue, reaches the stock unit and agrees with the rest
+func validateConversions(units []inventory.Unit, stockUnit string, conversions []inventory.Conversion) ([]inventory.Conversion, error) {
+	c := newUnitConverter(units, nil)
+	valid := make([]inventory.Conversion, 0, len(conversions))
+	for _, conv := range conversions {
+		conv.FromUnit = strings.TrimSpace(conv.FromUnit)
+		conv.ToUnit = strings.TrimSpace(conv.ToUnit)
+		if conv.FromUnit == "" || conv.ToUnit == "" || conv.Factor <= 0 || math.IsInf(conv.Factor, 0) || math.IsNaN(conv.Factor) {
+			return nil, inventory.ErrInvalidConversion
+		}
+		if c.key(conv.FromUnit) == c.key(conv.ToUnit) || (c.known(conv.FromUnit) && c.known(conv.ToUnit)) {
+			return nil, inventory.ErrInvalidConversion
+		}
+		if existing, ok := c.factor(conv.FromUnit, conv.ToUnit); ok && math.Abs(existing-conv.Factor) > 1e-6*conv.Factor {
+			return nil, inventory.ErrInvalidConversion
+		}
+		c.add(conv)
+		valid = append(valid, conv)
+	}
+	for _, conv := range valid {
+		if _, ok := c.factor(conv.FromUnit, stockUnit); !ok {
+			return nil, inventory.ErrInvalidConversion
+		}
+	}
+	return valid, nil
+}
+
+// minStock converts the minimum level of an item to its stock unit. A minimum in a unit
+// that no longer converts is compared as is.
+func minStock(c *unitConverter, item *inventory.Inventory) float64 {
+	if item.MinUnit == "" {
+		return item.MinQuantity
+	}
+	f, ok := c.factor(item.MinUnit, item.Unit)
+	if !ok {
+		log.Printf("Warning: minimum of item %s (ID: %d) is in %s which does not convert to %s",
+			item.Name, item.ID, item.MinUnit, item.Unit)
+		return item.MinQuantity
+	}
+	return item.MinQuantity * f
+}
+
+// checkUnits validates the conversions and the minimum unit of an item and sets its
+// minimum in stock units
+func (s *InventoryService) checkUnits(ctx context.Context, item *inventory.Inventory) (*unitConverter, error) {
+	units, err := s.repo.GetUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	item.Unit = strings.TrimSpace(item.Unit)
+	item.MinUnit = strings.TrimSpace(item.MinUnit)
+
+	conversions, err := validateConversions(units, item.Unit, item.Conversions)
+	if err != nil {
+		return nil, err
+	}
+	if item.Conversions != nil {
+		item.Conversions = conversions
+	}
+	c := newUnitConverter(units, conversions)
+	if item.MinUnit != "" {
+		if _, ok := c.factor(item.MinUnit, item.Unit); !ok {
+			return nil, inventory.ErrIncompatibleUnits
+		}
+	}
+	item.MinStock = minStock(c, item)
+	return c, nil
+}
+
+func (s *InventoryService) GetUnits(ctx context.Context) ([]inventory.Unit, error) {
+	return s.repo.GetUnits(ctx)
+}
+
+// itemConverter loads the units catalogue and the conversions of one item
+func (s *InventoryService) itemConverter(ctx context.Context, inventoryID int, businessID int) (*unitConverter, []inventory.Conversion, error) {
+	units, err := s.repo.GetUnits(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	conversions, err := s.repo.GetConversions(ctx, inventoryID, businessID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newUnitConverter(units, conversions[inventoryID]), conversions[inventoryID], nil
+}
+
+// withUnits fills in the conversions and the minimum in stock units of the items
+func (s *InventoryService) withUnits(ctx context.Context, items []inventory.Inventory, businessID int) error {
+	units, err := s.repo.GetUnits(ctx)
+	if err != nil {
+		return err
+	}
+	conversions, err := s.repo.GetConversions(ctx, 0, businessID)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Conversions = conversions[items[i].ID]
+		items[i].MinStock = minStock(newUnitConverter(units, items[i].Conversions), &items[i])
+	}
+	return nil
+}
+
+func (s *InventoryService) ConvertQuantity(ctx context.Context, inventoryID int, quantity float64, unit string, businessID int) (float64, error) {
+	if inventoryID <= 0 {
+		return 0, inventory.ErrInventoryItemNotFound
+	}
+	if businessID <= 0 {
+		return 0, inventory.ErrInvalidInventoryData
+	}
+
+	item, err := s.repo.GetInventoryByID(ctx, inventoryID, businessID)
+	if err != nil || item == nil {
+		return 0, inventory.ErrInventoryItemNotFound
+	}
+	if strings.TrimSpace(unit) == "" {
+		return quantity, nil
+	}
+
+	c, _, err := s.itemConverter(ctx, inventoryID, businessID)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := c.factor(unit, item.Unit)
+	if !ok {
+		return 0, inventory.ErrIncompatibleUnits
+	}
+	return quantity * f, nil
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"restaurant-management/internal/domain/inventory"
+	"sort"
+	"strings"
+)
+
+const defaultWasteDays = 30
+
+// LogWaste records waste of an item or of dish portions and takes it out of stock.
+// Item waste may not exceed the book stock and is taken from the given lot first; dish
+// waste follows the recipe and, like sales, is recorded even when that runs the stock
+// negative.
+func (s *InventoryService) LogWaste(ctx context.Context, req inventory.WasteRequest, businessID int) (*inventory.Waste, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if !inventory.ValidWasteReason(req.Reason) || req.Quantity <= 0 || math.IsInf(req.Quantity, 0) {
+		return nil, inventory.ErrInvalidWaste
+	}
+
+	// A lot names its item, which may then be left out
+	var lotID *int
+	if req.LotID != 0 {
+		if req.LotID < 0 || req.DishID != 0 {
+			return nil, inventory.ErrInvalidWaste
+		}
+		lot, err := s.repo.GetLot(ctx, req.LotID, businessID)
+		if err != nil {
+			return nil, err
+		}
+		if req.InventoryID == 0 {
+			req.InventoryID = lot.InventoryID
+		}
+		if lot.InventoryID != req.InventoryID {
+			return nil, inventory.ErrInvalidWaste
+		}
+		lotID = &lot.ID
+	}
+	if (req.InventoryID > 0) == (req.DishID > 0) || req.InventoryID < 0 || req.DishID < 0 {
+		return nil, inventory.ErrInvalidWaste
+	}
+
+	w := &inventory.Waste{
+		BusinessID: businessID,
+		Quantity:   req.Quantity,
+		Reason:     req.Reason,
+		Note:       strings.TrimSpace(req.Note),
+		UserID:     req.UserID,
+	}
+	reason := string(req.Reason)
+	if w.Note != "" {
+		reason += ": " + w.Note
+	}
+
+	var movements []inventory.Movement
+	allowNegative := false
+	if req.InventoryID > 0 {
+		item, err := s.repo.GetInventoryByID(ctx, req.InventoryID, businessID)
+		if err != nil || item == nil {
+			return nil, inventory.ErrInventoryItemNotFound
+		}
+		if strings.TrimSpace(req.Unit) != "" {
+			c, _, err := s.itemConverter(ctx, item.ID, businessID)
+			if err != nil {
+				return nil, err
+			}
+			f, ok := c.factor(req.Unit, item.Unit)
+			if !ok {
+				return nil, inventory.ErrIncompatibleUnits
+			}
+			w.Quantity = round3(req.Quantity * f)
+		}
+		w.InventoryID = &item.ID
+		w.Unit = item.Unit
+		movements = []inventory.Movement{{
+			InventoryID: item.ID,
+			BusinessID:  businessID,
+			Type:        inventory.MovementWaste,
+			Quantity:    -w.Quantity,
+			LotID:       lotID,
+		}}
+	} else {
+		if strings.TrimSpace(req.Unit) != "" {
+			return nil, inventory.ErrInvalidWaste
+		}
+		usage, err := s.repo.GetDishUsage(ctx, req.DishID, req.Quantity, businessID)
+		if err != nil {
+			return nil, err
+		}
+		dishID := req.DishID
+		w.DishID = &dishID
+		movements = usage
+		allowNegative = true
+	}
+	for i := range movements {
+		movements[i].UserID = req.UserID
+		movements[i].Reason = reason
+	}
+
+	if err := s.repo.CreateWaste(ctx, w, movements, allowNegative); err != nil {
+		return nil, err
+	}
+	return s.GetWaste(ctx, w.ID, businessID)
+}
+
+func (s *InventoryService) GetWaste(ctx context.Context, id int, businessID int) (*inventory.Waste, error) {
+	if id <= 0 {
+		return nil, inventory.ErrWasteNotFound
+	}
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	w, err := s.repo.GetWaste(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	roundWasteValue(w)
+	return w, nil
+}
+
+// GetWasteEntries lists the waste logged in a period, newest first
+func (s *InventoryService) GetWasteEntries(ctx context.Context, query inventory.WasteQuery, businessID int) ([]inventory.Waste, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+	if query.Reason != "" && !inventory.ValidWasteReason(query.Reason) {
+		return nil, inventory.ErrInvalidWaste
+	}
+
+	from, to, err := parsePeriod(query.From, query.To, defaultWasteDays, businessLocation(ctx, s.businessRepo, businessID))
+	if err != nil {
+		return nil, inventory.ErrInvalidWastePeriod
+	}
+	entries, err := s.repo.GetWasteEntries(ctx, from, to, query.Reason, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		roundWasteValue(&entries[i])
+	}
+	return entries, nil
+}
+
+// UploadWastePhoto stores a photo of the waste, replacing an earlier one
+func (s *InventoryService) UploadWastePhoto(ctx context.Context, id int, businessID int, r io.Reader) (*inventory.Waste, error) {
+	existing, err := s.GetWaste(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := s.media.UploadImage(ctx, fmt.Sprintf("businesses/%d/waste", businessID), r)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetWastePhoto(ctx, id, img.URL, businessID); err != nil {
+		s.removePhoto(ctx, img.URL, businessID)
+		return nil, err
+	}
+
+	s.removePhoto(ctx, existing.PhotoURL, businessID)
+	return s.GetWaste(ctx, id, businessID)
+}
+
+// GetWasteReport sums the waste of a period by reason, item and shift. Values use the
+// unit cost when the waste was logged; items without a cost are flagged instead.
+func (s *InventoryService) GetWasteReport(ctx context.Context, query inventory.WasteQuery, businessID int) (*inventory.WasteReport, error) {
+	if businessID <= 0 {
+		return nil, inventory.ErrInvalidInventoryData
+	}
+
+	from, to, err := parsePeriod(query.From, query.To, defaultWasteDays, businessLocation(ctx, s.businessRepo, businessID))
+	if err != nil {
+		return nil, inventory.ErrInvalidWastePeriod
+	}
+	lines, err := s.repo.GetWasteLines(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &inventory.WasteReport{
+		From:     from.Format(menuDateLayout),
+		To:       to.AddDate(0, 0, -1).Format(menuDateLayout),
+		ByReason: []inventory.WasteReasonTotal{},
+		ByItem:   []inventory.WasteItemTotal{},
+		ByShift:  []inventory.WasteShiftTotal{},
+	}
+
+	type itemKey struct {
+		id   int
+		unit string
+	}
+	reasons := make(map[inventory.WasteReason]*inventory.WasteReasonTotal)
+	items := make(map[itemKey]*inventory.WasteItemTotal)
+	shifts := make(map[int]*inventory.WasteShiftTotal) // 0 for waste outside any shift
+	counted := make(map[int]bool)
+
+	for _, line := range lines {
+		reason := reasons[line.Reason]
+		if reason == nil {
+			reason = &inventory.WasteReasonTotal{Reason: line.Reason}
+			reasons[line.Reason] = reason
+		}
+		shiftKey := 0
+		if line.ShiftID != nil {
+			shiftKey = *line.ShiftID
+		}
+		shift := shifts[shiftKey]
+		if shift == nil {
+			shift = &inventory.WasteShiftTotal{ShiftID: line.ShiftID, Label: line.ShiftLabel}
+			shifts[shiftKey] = shift
+		}
+		if !counted[line.WasteID] {
+			counted[line.WasteID] = true
+			report.Entries++
+			reason.Entries++
+			shift.Entries++
+		}
+		if line.InventoryID == 0 {
+			continue
+		}
+
+		key := itemKey{line.InventoryID, line.Unit}
+		item := items[key]
+		if item == nil {
+			item = &inventory.WasteItemTotal{InventoryID: line.InventoryID, Name: line.Name, Unit: line.Unit}
+			items[key] = item
+		}
+		item.Quantity += line.Quantity
+		if line.UnitCost == nil {
+			item.Uncosted = true
+			continue
+		}
+		value := line.Quantity * *line.UnitCost
+		item.Value += value
+		reason.Value += value
+		shift.Value += value
+		report.Value += value
+	}
+
+	for _, reason := range reasons {
+		reason.Value = roundMoney(reason.Value)
+		report.ByReason = append(report.ByReason, *reason)
+	}
+	for _, item := range items {
+		item.Quantity = round3(item.Quantity)
+		item.Value = roundMoney(item.Value)
+		report.ByItem = append(report.ByItem, *item)
+	}
+	for _, shift := range shifts {
+		shift.Value = roundMoney(shift.Value)
+		report.ByShift = append(report.ByShift, *shift)
+	}
+	report.Value = roundMoney(report.Value)
+
+	sort.Slice(report.ByReason, func(i, j int) bool {
+		if report.ByReason[i].Value != report.ByReason[j].Value {
+			return report.ByReason[i].Value > report.ByReason[j].Value
+		}
+		return report.ByReason[i].Reason < report.ByReason[j].Reason
+	})
+	sort.Slice(report.ByItem, func(i, j int) bool {
+		if report.ByItem[i].Value != report.ByItem[j].Value {
+			return report.ByItem[i].Value > report.ByItem[j].Value
+		}
+		return report.ByItem[i].Name < report.ByItem[j].Name
+	})
+	sort.Slice(report.ByShift, func(i, j int) bool {
+		return report.ByShift[i].Label < report.ByShift[j].Label
+	})
+	return report, nil
+}
+
+func roundWasteValue(w *inventory.Waste) {
+	if w.Value != nil {
+		value := roundMoney(*w.Value)
+		w.Value = &value
+	}
+}
+
+// removePhoto deletes an uploaded waste photo. Failures are logged, not returned.
+func (s *InventoryService) removePhoto(ctx context.Context, url string, businessID int) {
+	if url == "" {
+		return
+	}
+	if err := s.media.DeleteImage(ctx, url, businessID); err != nil {
+		log.Printf("Error deleting image %s: %v", url, err)
+	}
+}
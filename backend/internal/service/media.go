@@ -0,0 +1,244 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder for image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"restaurant-management/internal/domain/media"
+	"strings"
+)
+
+// maxImagePixels guards against decompression bombs: small files that decode to huge images
+const maxImagePixels = 40_000_000
+
+type MediaService struct {
+	storage media.Storage
+	maxSize int64
+}
+
+func NewMediaService(storage media.Storage, maxSize int64) media.Service {
+	return &MediaService{storage: storage, maxSize: maxSize}
+}
+
+func (s *MediaService) MaxUploadSize() int64 {
+	return s.maxSize
+}
+
+func (s *MediaService) UploadImage(ctx context.Context, folder string, r io.Reader) (*media.Image, error) {
+	// Read one byte past the limit to tell "exactly at the limit" from "too large"
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, media.ErrImageTooLarge
+	}
+
+	// Trust the bytes, not the client-supplied Content-Type
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, media.ErrUnsupportedImageType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, media.ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, media.ErrInvalidImage
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, media.ErrInvalidImage
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	key := path.Join(strings.Trim(folder, "/"), name+ext)
+
+	if err := s.storage.Put(ctx, key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, fmt.Errorf("storing image: %w", err)
+	}
+	stored := []string{key}
+
+	img := &media.Image{
+		URL:         s.storage.URL(key),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       config.Width,
+		Height:      config.Height,
+		Thumbnails:  make(map[string]string, len(media.ThumbnailSizes)),
+	}
+
+	rgba := toRGBA(src)
+	for _, size := range media.ThumbnailSizes {
+		thumbKey := thumbnailKey(key, size.Name)
+		var buf bytes.Buffer
+		if err := encodeThumbnail(&buf, resizeToWidth(rgba, size.Width), thumbKey); err != nil {
+			s.deleteKeys(ctx, stored)
+			return nil, fmt.Errorf("encoding %s thumbnail: %w", size.Name, err)
+		}
+		if err := s.storage.Put(ctx, thumbKey, thumbnailContentType(thumbKey), &buf, int64(buf.Len())); err != nil {
+			s.deleteKeys(ctx, stored)
+			return nil, fmt.Errorf("storing %s thumbnail: %w", size.Name, err)
+		}
+		stored = append(stored, thumbKey)
+		img.Thumbnails[size.Name] = s.storage.URL(thumbKey)
+	}
+
+	return img, nil
+}
+
+func (s *MediaService) DeleteImage(ctx context.Context, url string, businessID int) error {
+	key, ok := s.storage.Key(url)
+	if !ok {
+		return nil
+	}
+	// Uploads are stored under businesses/<id>/, so anything else is not the caller's to delete
+	if !strings.HasPrefix(path.Clean(key), fmt.Sprintf("businesses/%d/", businessID)) {
+		return media.ErrForeignImage
+	}
+
+	keys := []string{key}
+	for _, size := range media.ThumbnailSizes {
+		keys = append(keys, thumbnailKey(key, size.Name))
+	}
+	return s.deleteKeys(ctx, keys)
+}
+
+func (s *MediaService) ThumbnailURLs(url string) map[string]string {
+	key, ok := s.storage.Key(url)
+	if !ok {
+		return nil
+	}
+
+	urls := make(map[string]string, len(media.ThumbnailSizes))
+	for _, size := range media.ThumbnailSizes {
+		urls[size.Name] = s.storage.URL(thumbnailKey(key, size.Name))
+	}
+	return urls
+}
+
+// deleteKeys removes every key, logging failures and returning the first error
+func (s *MediaService) deleteKeys(ctx context.Context, keys []string) error {
+	var firstErr error
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Error deleting stored file %s: %v", key, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// thumbnailKey derives a thumbnail key from the original, e.g. a/b.jpg -> a/b_small.jpg.
+// GIF thumbnails are stored as PNG since only the first frame is kept.
+func thumbnailKey(key, sizeName string) string {
+	ext := path.Ext(key)
+	thumbExt := ext
+	if ext == ".gif" {
+		thumbExt = ".png"
+	}
+	return strings.TrimSuffix(key, ext) + "_" + sizeName + thumbExt
+}
+
+func thumbnailContentType(key string) string {
+	if path.Ext(key) == ".png" {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+func encodeThumbnail(w io.Writer, img image.Image, key string) error {
+	if path.Ext(key) == ".png" {
+		return png.Encode(w, img)
+	}
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	return rgba
+}
+
+// resizeToWidth scales an image down to the given width, keeping the aspect ratio, by
+// averaging the source pixels covered by each destination pixel. Images that are already
+// narrower are returned unchanged.
+func resizeToWidth(src *image.RGBA, width int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if width >= sw {
+		return src
+	}
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -2,24 +2,44 @@ package service
 
 import (
 	"context"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/media"
 	"restaurant-management/internal/domain/menu"
 	"strings"
+	"time"
 )
 
 type MenuService struct {
-	repo menu.Repository
+	repo         menu.Repository
+	businessRepo business.Repository
+	media        media.Service
+	inventory    inventory.Service
 }
 
-func NewMenuService(repo menu.Repository) menu.Service {
-	return &MenuService{repo: repo}
+func NewMenuService(repo menu.Repository, businessRepo business.Repository, mediaService media.Service, inventoryService inventory.Service) menu.Service {
+	return &MenuService{repo: repo, businessRepo: businessRepo, media: mediaService, inventory: inventoryService}
 }
 
-func (s *MenuService) GetMenuItems(ctx context.Context, categoryID *int, businessID int) ([]menu.MenuItem, error) {
-	if businessID <= 0 {
-		return nil, menu.ErrInvalidMenuData
+func (s *MenuService) GetMenuItems(ctx context.Context, filter menu.MenuItemFilter, businessID int) ([]menu.MenuItem, error) {
+	var unknown string
+	if filter.ExcludeAllergens, unknown = canonicalAllergens(filter.ExcludeAllergens); unknown != "" {
+		return nil, menu.ErrUnknownAllergen
+	}
+	if filter.DietaryTags, unknown = canonicalDietaryTags(filter.DietaryTags); unknown != "" {
+		return nil, menu.ErrUnknownDietaryTag
 	}
 
-	return s.repo.GetMenuItems(ctx, categoryID, businessID)
+	items, err := s.GetMenuItemsAt(ctx, filter.CategoryID, businessID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if filter.VisibleOnly {
+		if items, err = s.visibleMenuItems(ctx, items, businessID); err != nil {
+			return nil, err
+		}
+	}
+	return filterMenuItems(items, filter), nil
 }
 
 func (s *MenuService) GetMenuItemByID(ctx context.Context, id int, businessID int) (*menu.MenuItem, error) {
@@ -30,7 +50,17 @@ func (s *MenuService) GetMenuItemByID(ctx context.Context, id int, businessID in
 		return nil, menu.ErrInvalidMenuData
 	}
 
-	return s.repo.GetMenuItemByID(ctx, id, businessID)
+	item, err := s.repo.GetMenuItemByID(ctx, id, businessID)
+	if err != nil || item == nil {
+		return item, err
+	}
+	s.setThumbnails(item)
+
+	items := []menu.MenuItem{*item}
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+	return &items[0], nil
 }
 
 func (s *MenuService) CreateMenuItem(ctx context.Context, item menu.MenuItemCreate, businessID int) (*menu.MenuItem, error) {
@@ -48,6 +78,20 @@ func (s *MenuService) CreateMenuItem(ctx context.Context, item menu.MenuItemCrea
 	if item.Price <= 0 {
 		return nil, menu.ErrInvalidMenuData
 	}
+	if item.Cost != nil && *item.Cost < 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if item.Cost != nil && *item.Cost == 0 {
+		item.Cost = nil
+	}
+
+	var unknown string
+	if item.Allergens, unknown = canonicalAllergens(item.Allergens); unknown != "" {
+		return nil, menu.ErrUnknownAllergen
+	}
+	if item.DietaryTags, unknown = canonicalDietaryTags(item.DietaryTags); unknown != "" {
+		return nil, menu.ErrUnknownDietaryTag
+	}
 
 	// Set business ID
 	item.BusinessID = businessID
@@ -58,7 +102,12 @@ func (s *MenuService) CreateMenuItem(ctx context.Context, item menu.MenuItemCrea
 		return nil, menu.ErrCategoryNotFound
 	}
 
-	return s.repo.CreateMenuItem(ctx, item)
+	created, err := s.repo.CreateMenuItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	s.setThumbnails(created)
+	return created, nil
 }
 
 func (s *MenuService) UpdateMenuItem(ctx context.Context, id int, item menu.MenuItemUpdate, businessID int) (*menu.MenuItem, error) {
@@ -76,6 +125,16 @@ func (s *MenuService) UpdateMenuItem(ctx context.Context, id int, item menu.Menu
 	if item.Price > 0 && item.Price <= 0 {
 		return nil, menu.ErrInvalidMenuData
 	}
+	if item.Cost != nil && *item.Cost < 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	var unknown string
+	if item.Allergens, unknown = canonicalAllergens(item.Allergens); unknown != "" {
+		return nil, menu.ErrUnknownAllergen
+	}
+	if item.DietaryTags, unknown = canonicalDietaryTags(item.DietaryTags); unknown != "" {
+		return nil, menu.ErrUnknownDietaryTag
+	}
 
 	// Verify menu item exists
 	existing, err := s.repo.GetMenuItemByID(ctx, id, businessID)
@@ -94,7 +153,17 @@ func (s *MenuService) UpdateMenuItem(ctx context.Context, id int, item menu.Menu
 	// Set business ID
 	item.BusinessID = businessID
 
-	return s.repo.UpdateMenuItem(ctx, id, item)
+	updated, err := s.repo.UpdateMenuItem(ctx, id, item)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new image URL replaces the old one, so drop the previous upload
+	if item.ImageURL != "" && item.ImageURL != existing.ImageURL {
+		s.removeImage(ctx, existing.ImageURL, businessID)
+	}
+	s.setThumbnails(updated)
+	return updated, nil
 }
 
 func (s *MenuService) DeleteMenuItem(ctx context.Context, id int, businessID int) error {
@@ -111,7 +180,12 @@ func (s *MenuService) DeleteMenuItem(ctx context.Context, id int, businessID int
 		return menu.ErrMenuItemNotFound
 	}
 
-	return s.repo.DeleteMenuItem(ctx, id, businessID)
+	if err := s.repo.DeleteMenuItem(ctx, id, businessID); err != nil {
+		return err
+	}
+
+	s.removeImage(ctx, existing.ImageURL, businessID)
+	return nil
 }
 
 func (s *MenuService) GetCategories(ctx context.Context, businessID int) ([]menu.Category, error) {
@@ -119,7 +193,14 @@ func (s *MenuService) GetCategories(ctx context.Context, businessID int) ([]menu
 		return nil, menu.ErrInvalidMenuData
 	}
 
-	return s.repo.GetCategories(ctx, businessID)
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translateCategories(ctx, categories, businessID); err != nil {
+		return nil, err
+	}
+	return categories, nil
 }
 
 func (s *MenuService) GetCategoryByID(ctx context.Context, id int, businessID int) (*menu.Category, error) {
@@ -130,7 +211,16 @@ func (s *MenuService) GetCategoryByID(ctx context.Context, id int, businessID in
 		return nil, menu.ErrInvalidMenuData
 	}
 
-	return s.repo.GetCategoryByID(ctx, id, businessID)
+	category, err := s.repo.GetCategoryByID(ctx, id, businessID)
+	if err != nil || category == nil {
+		return category, err
+	}
+
+	categories := []menu.Category{*category}
+	if err := s.translateCategories(ctx, categories, businessID); err != nil {
+		return nil, err
+	}
+	return &categories[0], nil
 }
 
 func (s *MenuService) CreateCategory(ctx context.Context, category menu.CategoryCreate, businessID int) (*menu.Category, error) {
@@ -142,6 +232,9 @@ func (s *MenuService) CreateCategory(ctx context.Context, category menu.Category
 	if strings.TrimSpace(category.Name) == "" {
 		return nil, menu.ErrInvalidMenuData
 	}
+	if err := s.validateCategoryParent(ctx, 0, category.ParentID, businessID); err != nil {
+		return nil, err
+	}
 
 	// Set business ID
 	category.BusinessID = businessID
@@ -167,6 +260,11 @@ func (s *MenuService) UpdateCategory(ctx context.Context, id int, category menu.
 	if err != nil || existing == nil {
 		return nil, menu.ErrCategoryNotFound
 	}
+	if category.ParentID != nil {
+		if err := s.validateCategoryParent(ctx, id, *category.ParentID, businessID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Set business ID
 	category.BusinessID = businessID
@@ -205,6 +303,15 @@ func (s *MenuService) GetMenuSummary(ctx context.Context, businessID int) (inter
 	if err != nil {
 		return nil, err
 	}
+	for i := range items {
+		s.setThumbnails(&items[i])
+	}
+	if err := s.translateCategories(ctx, categories, businessID); err != nil {
+		return nil, err
+	}
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
 
 	// Just return categories and items separately like the old handler
 	return map[string]interface{}{
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/menu"
+	"sort"
+	"strings"
+)
+
+func (s *MenuService) GetAllergenMatrix(ctx context.Context, businessID int) (*menu.AllergenMatrix, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	lang := menu.LanguageFromContext(ctx)
+	if lang == "" {
+		lang = businessDefaultLanguage(b)
+	}
+
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetMenuItems(ctx, nil, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translateCategories(ctx, categories, businessID); err != nil {
+		return nil, err
+	}
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+
+	names, err := s.CatalogueNames(ctx, lang, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryNames := make(map[int]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	matrix := &menu.AllergenMatrix{
+		BusinessName: b.Name,
+		Language:     lang,
+		Allergens:    make([]menu.AllergenColumn, 0, len(menu.Allergens)),
+		Rows:         make([]menu.AllergenMatrixRow, 0, len(items)),
+	}
+	for _, allergen := range menu.Allergens {
+		matrix.Allergens = append(matrix.Allergens, menu.AllergenColumn{Code: allergen.Code, Name: names[allergen.Code]})
+	}
+
+	for _, item := range items {
+		row := menu.AllergenMatrixRow{
+			ItemID:      item.ID,
+			Name:        item.Name,
+			Category:    categoryNames[item.CategoryID],
+			Contains:    make([]bool, len(menu.Allergens)),
+			DietaryTags: []string{},
+		}
+		for i, allergen := range menu.Allergens {
+			row.Contains[i] = containsString(item.Allergens, allergen.Code)
+		}
+		for _, tag := range menu.DietaryTags {
+			if containsString(item.DietaryTags, tag.Code) {
+				row.DietaryTags = append(row.DietaryTags, names[tag.Code])
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	// Group by category for printing; the repository already orders dishes by name
+	sort.SliceStable(matrix.Rows, func(i, j int) bool { return matrix.Rows[i].Category < matrix.Rows[j].Category })
+
+	return matrix, nil
+}
+
+// filterMenuItems drops dishes containing an excluded allergen or missing a required dietary tag
+func filterMenuItems(items []menu.MenuItem, filter menu.MenuItemFilter) []menu.MenuItem {
+	if len(filter.ExcludeAllergens) == 0 && len(filter.DietaryTags) == 0 {
+		return items
+	}
+
+	filtered := []menu.MenuItem{}
+	for _, item := range items {
+		if matchesMenuItemFilter(item, filter) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func matchesMenuItemFilter(item menu.MenuItem, filter menu.MenuItemFilter) bool {
+	for _, allergen := range filter.ExcludeAllergens {
+		if containsString(item.Allergens, allergen) {
+			return false
+		}
+	}
+	for _, tag := range filter.DietaryTags {
+		if !containsString(item.DietaryTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalAllergens maps free-text allergens (codes, aliases or display names in any
+// language) to catalogue codes. It returns the first value it does not recognise.
+func canonicalAllergens(values []string) ([]string, string) {
+	return canonicalCodes(values, menu.Allergens)
+}
+
+// canonicalDietaryTags maps free-text dietary tags to catalogue codes. It returns the
+// first value it does not recognise.
+func canonicalDietaryTags(values []string) ([]string, string) {
+	return canonicalCodes(values, menu.DietaryTags)
+}
+
+// canonicalCodes returns the matched codes in catalogue order, without duplicates.
+// A nil input stays nil so partial updates can tell "unchanged" from "cleared".
+func canonicalCodes(values []string, catalogue []menu.CatalogueEntry) ([]string, string) {
+	if values == nil {
+		return nil, ""
+	}
+
+	found := make(map[string]bool, len(values))
+	for _, value := range values {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" {
+			continue
+		}
+		code := lookupCatalogue(key, catalogue)
+		if code == "" {
+			return nil, strings.TrimSpace(value)
+		}
+		found[code] = true
+	}
+
+	codes := []string{}
+	for _, entry := range catalogue {
+		if found[entry.Code] {
+			codes = append(codes, entry.Code)
+		}
+	}
+	return codes, ""
+}
+
+func lookupCatalogue(key string, catalogue []menu.CatalogueEntry) string {
+	for _, entry := range catalogue {
+		if key == entry.Code {
+			return entry.Code
+		}
+		for _, name := range entry.Names {
+			if key == strings.ToLower(name) {
+				return entry.Code
+			}
+		}
+		for _, alias := range entry.Aliases {
+			if key == alias {
+				return entry.Code
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+)
+
+func (s *MenuService) GetBundles(ctx context.Context, businessID int) ([]menu.Bundle, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	return s.repo.GetBundles(ctx, businessID)
+}
+
+func (s *MenuService) GetBundleByID(ctx context.Context, id int, businessID int) (*menu.Bundle, error) {
+	if id <= 0 {
+		return nil, menu.ErrBundleNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	b, err := s.repo.GetBundleByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, menu.ErrBundleNotFound
+	}
+	return b, nil
+}
+
+func (s *MenuService) CreateBundle(ctx context.Context, b menu.BundleCreate, businessID int) (*menu.Bundle, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation
+	if strings.TrimSpace(b.Name) == "" || b.Price <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if len(b.Slots) == 0 {
+		return nil, menu.ErrInvalidBundle
+	}
+	if err := s.validateBundleSlots(ctx, b.Slots, businessID); err != nil {
+		return nil, err
+	}
+
+	// Set business ID
+	b.BusinessID = businessID
+
+	return s.repo.CreateBundle(ctx, b)
+}
+
+func (s *MenuService) UpdateBundle(ctx context.Context, id int, b menu.BundleUpdate, businessID int) (*menu.Bundle, error) {
+	if id <= 0 {
+		return nil, menu.ErrBundleNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation for provided fields
+	if b.Name != "" && strings.TrimSpace(b.Name) == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if b.Price < 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if b.Slots != nil && len(b.Slots) == 0 {
+		return nil, menu.ErrInvalidBundle
+	}
+	if err := s.validateBundleSlots(ctx, b.Slots, businessID); err != nil {
+		return nil, err
+	}
+
+	// Set business ID
+	b.BusinessID = businessID
+
+	updated, err := s.repo.UpdateBundle(ctx, id, b)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, menu.ErrBundleNotFound
+	}
+	return updated, nil
+}
+
+func (s *MenuService) DeleteBundle(ctx context.Context, id int, businessID int) error {
+	if id <= 0 {
+		return menu.ErrBundleNotFound
+	}
+	if businessID <= 0 {
+		return menu.ErrInvalidMenuData
+	}
+
+	// Verify bundle exists
+	existing, err := s.repo.GetBundleByID(ctx, id, businessID)
+	if err != nil || existing == nil {
+		return menu.ErrBundleNotFound
+	}
+
+	return s.repo.DeleteBundle(ctx, id, businessID)
+}
+
+// validateBundleSlots checks that every slot offers dishes of the business and
+// defaults the number of dishes to choose to one
+func (s *MenuService) validateBundleSlots(ctx context.Context, slots []menu.BundleSlot, businessID int) error {
+	for i := range slots {
+		slot := &slots[i]
+		slot.Name = strings.TrimSpace(slot.Name)
+		if slot.Name == "" || slot.Quantity < 0 || slot.CategoryID < 0 {
+			return menu.ErrInvalidBundle
+		}
+		if slot.Quantity == 0 {
+			slot.Quantity = 1
+		}
+		if slot.CategoryID == 0 && len(slot.DishIDs) == 0 {
+			return menu.ErrInvalidBundle
+		}
+
+		if slot.CategoryID > 0 {
+			category, err := s.repo.GetCategoryByID(ctx, slot.CategoryID, businessID)
+			if err != nil || category == nil {
+				return menu.ErrCategoryNotFound
+			}
+		}
+		for _, dishID := range slot.DishIDs {
+			item, err := s.repo.GetMenuItemByID(ctx, dishID, businessID)
+			if err != nil || item == nil {
+				return menu.ErrMenuItemNotFound
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/menu"
+)
+
+const (
+	// defaultEngineeringDays is the report period when none is given: about a quarter
+	defaultEngineeringDays = 90
+
+	// popularityThreshold is the share of an even menu mix from which a dish counts as popular
+	popularityThreshold = 0.7
+)
+
+func (s *MenuService) GetMenuEngineering(ctx context.Context, query menu.MenuEngineeringQuery, businessID int) (*menu.MenuEngineeringReport, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	from, to, err := parsePeriod(query.From, query.To, defaultEngineeringDays, s.businessLocation(ctx, businessID))
+	if err != nil {
+		return nil, menu.ErrInvalidReportPeriod
+	}
+
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetMenuItems(ctx, nil, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translateCategories(ctx, categories, businessID); err != nil {
+		return nil, err
+	}
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+	recipeCosts, err := s.repo.GetRecipeCosts(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.repo.GetDishSales(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	salesByDish := make(map[int][]menu.DishSales, len(sales))
+	for _, sale := range sales {
+		salesByDish[sale.DishID] = append(salesByDish[sale.DishID], sale)
+	}
+	categoryNames := make(map[int]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	report := &menu.MenuEngineeringReport{
+		From:       from,
+		To:         to,
+		ByCategory: query.ByCategory,
+		Dishes:     make([]menu.DishEngineering, 0, len(items)),
+		Categories: make([]menu.CategoryEngineering, 0, len(categories)),
+	}
+	groups := make(map[int][]int)
+	for i, item := range items {
+		report.Dishes = append(report.Dishes, dishEngineering(item, categoryNames[item.CategoryID], salesByDish[item.ID], recipeCosts))
+		group := 0
+		if query.ByCategory {
+			group = item.CategoryID
+		}
+		groups[group] = append(groups[group], i)
+	}
+	for _, group := range groups {
+		classifyDishes(report.Dishes, group)
+	}
+
+	var menuTotals engineeringTotals
+	categoryTotals := make(map[int]*engineeringTotals, len(categories))
+	for _, dish := range report.Dishes {
+		menuTotals.add(dish)
+		if categoryTotals[dish.CategoryID] == nil {
+			categoryTotals[dish.CategoryID] = &engineeringTotals{classes: map[string]int{}}
+		}
+		categoryTotals[dish.CategoryID].add(dish)
+	}
+
+	for _, category := range categories {
+		totals := categoryTotals[category.ID]
+		if totals == nil {
+			totals = &engineeringTotals{classes: map[string]int{}}
+		}
+		report.Categories = append(report.Categories, menu.CategoryEngineering{
+			CategoryID:                category.ID,
+			Name:                      category.Name,
+			Dishes:                    totals.dishes,
+			Sold:                      totals.sold,
+			Revenue:                   roundMoney(totals.revenue),
+			FoodCost:                  roundMoney(totals.foodCost),
+			ContributionMargin:        roundMoney(totals.margin),
+			AverageContributionMargin: roundMoney(ratio(totals.margin, float64(totals.costedSold))),
+			FoodCostPercent:           percent(totals.foodCost, totals.costedRevenue),
+			Classes:                   totals.classes,
+		})
+	}
+	report.Sold = menuTotals.sold
+	report.Revenue = roundMoney(menuTotals.revenue)
+	report.FoodCost = roundMoney(menuTotals.foodCost)
+	report.ContributionMargin = roundMoney(menuTotals.margin)
+	report.FoodCostPercent = percent(menuTotals.foodCost, menuTotals.costedRevenue)
+
+	return report, nil
+}
+
+// dishEngineering computes the per-dish figures. A manual cost wins over the recipe,
+// e.g. for desserts bought in ready-made.
+func dishEngineering(item menu.MenuItem, category string, sales []menu.DishSales, recipeCosts map[int]float64) menu.DishEngineering {
+	dish := menu.DishEngineering{
+		DishID:       item.ID,
+		Name:         item.Name,
+		CategoryID:   item.CategoryID,
+		Category:     category,
+		Price:        item.Price,
+		AveragePrice: item.Price,
+		Prices:       make([]menu.PriceSales, 0, len(sales)),
+	}
+	var revenue float64
+	for _, sale := range sales {
+		dish.Sold += sale.Quantity
+		revenue += sale.Revenue
+		dish.Prices = append(dish.Prices, menu.PriceSales{
+			Price:   sale.Price,
+			Sold:    sale.Quantity,
+			Revenue: roundMoney(sale.Revenue),
+		})
+	}
+	dish.Revenue = roundMoney(revenue)
+	if dish.Sold > 0 {
+		dish.AveragePrice = roundMoney(revenue / float64(dish.Sold))
+	}
+
+	if item.Cost != nil {
+		dish.Cost, dish.CostSource = item.Cost, menu.CostSourceManual
+	} else if cost, ok := recipeCosts[item.ID]; ok {
+		cost = roundMoney(cost)
+		dish.Cost, dish.CostSource = &cost, menu.CostSourceRecipe
+	}
+	if dish.Cost != nil {
+		dish.ContributionMargin = roundMoney(dish.AveragePrice - *dish.Cost)
+		dish.TotalContributionMargin = roundMoney(dish.ContributionMargin * float64(dish.Sold))
+		dish.FoodCostPercent = percent(*dish.Cost, dish.AveragePrice)
+	}
+	return dish
+}
+
+// classifyDishes places a group of dishes in the menu engineering matrix. Popularity
+// counts every dish of the group; profitability compares margins with the average
+// margin per portion of the dishes with a known cost.
+func classifyDishes(dishes []menu.DishEngineering, group []int) {
+	var sold, costedSold int
+	var margin float64
+	for _, i := range group {
+		sold += dishes[i].Sold
+		if dishes[i].Cost != nil {
+			costedSold += dishes[i].Sold
+			margin += dishes[i].TotalContributionMargin
+		}
+	}
+	if sold == 0 {
+		return
+	}
+
+	evenShare := 1 / float64(len(group))
+	averageMargin := ratio(margin, float64(costedSold))
+	for _, i := range group {
+		dish := &dishes[i]
+		mix := float64(dish.Sold) / float64(sold)
+		dish.MenuMix = round2(mix * 100)
+		dish.PopularityIndex = round2(mix / evenShare)
+		if dish.Cost == nil || costedSold == 0 {
+			continue
+		}
+
+		popular := mix >= popularityThreshold*evenShare
+		profitable := dish.ContributionMargin >= averageMargin
+		switch {
+		case popular && profitable:
+			dish.Class = menu.DishClassStar
+		case popular:
+			dish.Class = menu.DishClassPlowhorse
+		case profitable:
+			dish.Class = menu.DishClassPuzzle
+		default:
+			dish.Class = menu.DishClassDog
+		}
+	}
+}
+
+// engineeringTotals sums the figures of a category or of the whole menu. Cost figures
+// only cover dishes with a known cost.
+type engineeringTotals struct {
+	dishes        int
+	sold          int
+	costedSold    int
+	revenue       float64
+	costedRevenue float64
+	foodCost      float64
+	margin        float64
+	classes       map[string]int
+}
+
+func (t *engineeringTotals) add(dish menu.DishEngineering) {
+	t.dishes++
+	t.sold += dish.Sold
+	t.revenue += dish.Revenue
+	if dish.Class != "" && t.classes != nil {
+		t.classes[dish.Class]++
+	}
+	if dish.Cost == nil {
+		return
+	}
+	t.costedSold += dish.Sold
+	t.costedRevenue += dish.Revenue
+	t.foodCost += *dish.Cost * float64(dish.Sold)
+	t.margin += dish.TotalContributionMargin
+}
+
+func ratio(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return part / whole
+}
+
+// percent returns part as a percentage of whole, rounded to two decimals
+func percent(part, whole float64) float64 {
+	return round2(ratio(part, whole) * 100)
+}
+
+func roundMoney(amount float64) float64 {
+	return round2(amount)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"restaurant-management/internal/domain/menu"
+)
+
+func (s *MenuService) UploadMenuItemImage(ctx context.Context, id int, businessID int, r io.Reader) (*menu.MenuItem, error) {
+	if id <= 0 {
+		return nil, menu.ErrMenuItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	existing, err := s.repo.GetMenuItemByID(ctx, id, businessID)
+	if err != nil || existing == nil {
+		return nil, menu.ErrMenuItemNotFound
+	}
+
+	img, err := s.media.UploadImage(ctx, fmt.Sprintf("businesses/%d/dishes", businessID), r)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateMenuItem(ctx, id, menu.MenuItemUpdate{ImageURL: img.URL, BusinessID: businessID})
+	if err != nil {
+		s.removeImage(ctx, img.URL, businessID)
+		return nil, err
+	}
+
+	s.removeImage(ctx, existing.ImageURL, businessID)
+	s.setThumbnails(updated)
+	return updated, nil
+}
+
+// setThumbnails fills in thumbnail URLs for dishes whose image was uploaded to our storage
+func (s *MenuService) setThumbnails(item *menu.MenuItem) {
+	if item != nil && item.ImageURL != "" {
+		item.Thumbnails = s.media.ThumbnailURLs(item.ImageURL)
+	}
+}
+
+// removeImage deletes an uploaded dish image. Failures are logged, not returned, so a
+// storage outage never blocks menu changes.
+func (s *MenuService) removeImage(ctx context.Context, url string, businessID int) {
+	if url == "" {
+		return
+	}
+	if err := s.media.DeleteImage(ctx, url, businessID); err != nil {
+		log.Printf("Error deleting image %s: %v", url, err)
+	}
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"restaurant-management/internal/domain/menu"
+	"strconv"
+	"strings"
+)
+
+// menuCSVHeader lists the CSV columns in export order. Allergens and dietary tags are separated by "|".
+// A row with only the category column filled declares a category that has no dishes.
+var menuCSVHeader = []string{"name", "category", "price", "description", "allergens", "preparation_time", "calories", "is_available", "dietary_tags"}
+
+func (s *MenuService) ExportMenu(ctx context.Context, w io.Writer, format menu.ImportFormat, businessID int) error {
+	if businessID <= 0 {
+		return menu.ErrInvalidMenuData
+	}
+	if format != menu.ImportFormatCSV && format != menu.ImportFormatJSON {
+		return menu.ErrUnsupportedImportFormat
+	}
+
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	items, err := s.repo.GetMenuItems(ctx, nil, businessID)
+	if err != nil {
+		return err
+	}
+
+	categoryNames := make(map[int]string, len(categories))
+	export := menu.MenuExport{
+		Categories: make([]menu.CategoryExport, 0, len(categories)),
+		Dishes:     make([]menu.MenuItemExport, 0, len(items)),
+	}
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+		export.Categories = append(export.Categories, menu.CategoryExport{Name: category.Name})
+	}
+	for _, item := range items {
+		isAvailable := item.IsAvailable
+		export.Dishes = append(export.Dishes, menu.MenuItemExport{
+			Name:            item.Name,
+			Category:        categoryNames[item.CategoryID],
+			Price:           item.Price,
+			Description:     item.Description,
+			Allergens:       item.Allergens,
+			DietaryTags:     item.DietaryTags,
+			PreparationTime: item.PreparationTime,
+			Calories:        item.Calories,
+			IsAvailable:     &isAvailable,
+		})
+	}
+
+	if format == menu.ImportFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(export)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(menuCSVHeader); err != nil {
+		return err
+	}
+	usedCategories := make(map[string]bool, len(export.Categories))
+	for _, dish := range export.Dishes {
+		usedCategories[dish.Category] = true
+		record := []string{
+			dish.Name,
+			dish.Category,
+			strconv.FormatFloat(dish.Price, 'f', -1, 64),
+			dish.Description,
+			strings.Join(dish.Allergens, "|"),
+			strconv.Itoa(dish.PreparationTime),
+			strconv.Itoa(dish.Calories),
+			strconv.FormatBool(*dish.IsAvailable),
+			strings.Join(dish.DietaryTags, "|"),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	for _, category := range export.Categories {
+		if usedCategories[category.Name] {
+			continue
+		}
+		record := make([]string, len(menuCSVHeader))
+		record[1] = category.Name
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *MenuService) ImportMenu(ctx context.Context, r io.Reader, opts menu.MenuImportOptions, businessID int) (*menu.MenuImportResult, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if opts.Mode == "" {
+		opts.Mode = menu.ImportModeCreate
+	}
+	if opts.Mode != menu.ImportModeCreate && opts.Mode != menu.ImportModeUpsert {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	var data menu.MenuExport
+	var rows []int
+	var rowErrors []menu.MenuImportRowError
+	var err error
+
+	switch opts.Format {
+	case menu.ImportFormatJSON:
+		if err := json.NewDecoder(r).Decode(&data); err != nil {
+			return nil, menu.ErrInvalidImportFile
+		}
+		rows = make([]int, len(data.Dishes))
+		for i := range data.Dishes {
+			rows[i] = i + 1
+		}
+	case menu.ImportFormatCSV:
+		data, rows, rowErrors, err = parseMenuCSV(r)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, menu.ErrUnsupportedImportFormat
+	}
+
+	result := &menu.MenuImportResult{
+		DryRun:    opts.DryRun,
+		Mode:      opts.Mode,
+		TotalRows: len(data.Dishes) + len(rowErrors),
+		Errors:    rowErrors,
+	}
+
+	// Compare against what already exists to plan creates and updates
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetMenuItems(ctx, nil, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	knownCategories := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		knownCategories[importKey(category.Name)] = true
+	}
+	existingItems := make(map[string]bool, len(items))
+	for _, item := range items {
+		existingItems[importKey(item.Name)] = true
+	}
+
+	planCategory := func(name string) {
+		key := importKey(name)
+		if key != "" && !knownCategories[key] {
+			knownCategories[key] = true
+			result.CategoriesCreated++
+		}
+	}
+	for _, category := range data.Categories {
+		planCategory(category.Name)
+	}
+
+	seen := make(map[string]int, len(data.Dishes))
+	for i := range data.Dishes {
+		dish := &data.Dishes[i]
+		rowErrs := validateImportedDish(*dish, rows[i])
+
+		var unknown string
+		if dish.Allergens, unknown = canonicalAllergens(dish.Allergens); unknown != "" {
+			rowErrs = append(rowErrs, menu.MenuImportRowError{
+				Row: rows[i], Name: dish.Name, Field: "allergens",
+				Message: fmt.Sprintf("unknown allergen %q", unknown),
+			})
+		}
+		if dish.DietaryTags, unknown = canonicalDietaryTags(dish.DietaryTags); unknown != "" {
+			rowErrs = append(rowErrs, menu.MenuImportRowError{
+				Row: rows[i], Name: dish.Name, Field: "dietary_tags",
+				Message: fmt.Sprintf("unknown dietary tag %q", unknown),
+			})
+		}
+
+		key := importKey(dish.Name)
+		if key != "" {
+			if firstRow, dup := seen[key]; dup {
+				rowErrs = append(rowErrs, menu.MenuImportRowError{
+					Row: rows[i], Name: dish.Name, Field: "name",
+					Message: fmt.Sprintf("duplicate dish name, first seen in row %d", firstRow),
+				})
+			} else {
+				seen[key] = rows[i]
+			}
+		}
+
+		if len(rowErrs) > 0 {
+			result.Errors = append(result.Errors, rowErrs...)
+			continue
+		}
+
+		planCategory(dish.Category)
+		if existingItems[key] {
+			if opts.Mode != menu.ImportModeUpsert {
+				result.Errors = append(result.Errors, menu.MenuImportRowError{
+					Row: rows[i], Name: dish.Name, Field: "name",
+					Message: "dish already exists; use mode=upsert to update it",
+				})
+				continue
+			}
+			result.ItemsUpdated++
+		} else {
+			result.ItemsCreated++
+		}
+	}
+
+	if result.Errors == nil {
+		result.Errors = []menu.MenuImportRowError{}
+	}
+	if len(result.Errors) > 0 || opts.DryRun {
+		return result, nil
+	}
+
+	applied, err := s.repo.ImportMenu(ctx, data, opts.Mode, businessID)
+	if err != nil {
+		return nil, err
+	}
+	applied.TotalRows = result.TotalRows
+	return applied, nil
+}
+
+// parseMenuCSV reads dish rows keyed by header name. Rows that cannot be parsed are
+// reported as row errors rather than aborting, so the caller gets a full report.
+func parseMenuCSV(r io.Reader) (menu.MenuExport, []int, []menu.MenuImportRowError, error) {
+	var data menu.MenuExport
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return data, nil, nil, menu.ErrInvalidImportFile
+	}
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")) // Excel adds a UTF-8 BOM
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return data, nil, nil, menu.ErrInvalidImportFile
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "category", "price"} {
+		if _, ok := columns[required]; !ok {
+			return data, nil, nil, menu.ErrInvalidImportFile
+		}
+	}
+
+	var rows []int
+	var rowErrors []menu.MenuImportRowError
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			rowErrors = append(rowErrors, menu.MenuImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		if strings.Join(record, "") == "" {
+			continue // skip blank lines
+		}
+		if field("name") == "" && field("price") == "" && field("category") != "" {
+			data.Categories = append(data.Categories, menu.CategoryExport{Name: field("category")})
+			continue
+		}
+
+		dish := menu.MenuItemExport{
+			Name:        field("name"),
+			Category:    field("category"),
+			Description: field("description"),
+		}
+		var parseErrs []menu.MenuImportRowError
+
+		if dish.Price, err = strconv.ParseFloat(strings.Replace(field("price"), ",", ".", 1), 64); err != nil {
+			parseErrs = append(parseErrs, menu.MenuImportRowError{Row: line, Name: dish.Name, Field: "price", Message: "price must be a number"})
+		}
+		if v := field("preparation_time"); v != "" {
+			if dish.PreparationTime, err = strconv.Atoi(v); err != nil {
+				parseErrs = append(parseErrs, menu.MenuImportRowError{Row: line, Name: dish.Name, Field: "preparation_time", Message: "preparation_time must be a whole number of minutes"})
+			}
+		}
+		if v := field("calories"); v != "" {
+			if dish.Calories, err = strconv.Atoi(v); err != nil {
+				parseErrs = append(parseErrs, menu.MenuImportRowError{Row: line, Name: dish.Name, Field: "calories", Message: "calories must be a whole number"})
+			}
+		}
+		if v := field("is_available"); v != "" {
+			available, err := strconv.ParseBool(v)
+			if err != nil {
+				parseErrs = append(parseErrs, menu.MenuImportRowError{Row: line, Name: dish.Name, Field: "is_available", Message: "is_available must be true or false"})
+			}
+			dish.IsAvailable = &available
+		}
+		if v := field("allergens"); v != "" {
+			dish.Allergens = strings.Split(v, "|")
+		}
+		if v := field("dietary_tags"); v != "" {
+			dish.DietaryTags = strings.Split(v, "|")
+		}
+
+		if len(parseErrs) > 0 {
+			rowErrors = append(rowErrors, parseErrs...)
+			continue
+		}
+		data.Dishes = append(data.Dishes, dish)
+		rows = append(rows, line)
+	}
+
+	return data, rows, rowErrors, nil
+}
+
+func validateImportedDish(dish menu.MenuItemExport, row int) []menu.MenuImportRowError {
+	var errs []menu.MenuImportRowError
+	if strings.TrimSpace(dish.Name) == "" {
+		errs = append(errs, menu.MenuImportRowError{Row: row, Field: "name", Message: "name is required"})
+	}
+	if strings.TrimSpace(dish.Category) == "" {
+		errs = append(errs, menu.MenuImportRowError{Row: row, Name: dish.Name, Field: "category", Message: "category is required"})
+	}
+	if dish.Price <= 0 {
+		errs = append(errs, menu.MenuImportRowError{Row: row, Name: dish.Name, Field: "price", Message: "price must be greater than zero"})
+	}
+	if dish.PreparationTime < 0 {
+		errs = append(errs, menu.MenuImportRowError{Row: row, Name: dish.Name, Field: "preparation_time", Message: "preparation_time cannot be negative"})
+	}
+	if dish.Calories < 0 {
+		errs = append(errs, menu.MenuImportRowError{Row: row, Name: dish.Name, Field: "calories", Message: "calories cannot be negative"})
+	}
+	return errs
+}
+
+func importKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
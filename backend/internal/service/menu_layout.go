@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/menu"
+)
+
+func (s *MenuService) GetMenuLayout(ctx context.Context, businessID int, includeHidden bool) ([]menu.Category, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	categories, err := s.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The waiter view only has what can be sold now; managers arranging the menu see every dish
+	var items []menu.MenuItem
+	if includeHidden {
+		items, err = s.repo.GetMenuItems(ctx, nil, businessID)
+		if err == nil {
+			for i := range items {
+				s.setThumbnails(&items[i])
+			}
+			err = s.translateItems(ctx, items, businessID)
+		}
+	} else {
+		items, err = s.GetMenuItems(ctx, menu.MenuItemFilter{VisibleOnly: true}, businessID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[int][]menu.MenuItem)
+	for _, item := range items {
+		byCategory[item.CategoryID] = append(byCategory[item.CategoryID], item)
+	}
+
+	hidden := hiddenCategories(categories)
+	var visible []menu.Category
+	for _, category := range categories {
+		if includeHidden || !hidden[category.ID] {
+			category.Items = byCategory[category.ID]
+			visible = append(visible, category)
+		}
+	}
+	return categoryTree(visible), nil
+}
+
+func (s *MenuService) SetMenuLayout(ctx context.Context, layout menu.MenuLayout, businessID int) ([]menu.Category, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.GetMenuItems(ctx, nil, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every category exactly once, and only dishes of the business, each at most once
+	if len(layout.Categories) != len(categories) {
+		return nil, menu.ErrInvalidMenuLayout
+	}
+	known := make(map[int]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+	knownItems := make(map[int]bool, len(items))
+	for _, item := range items {
+		knownItems[item.ID] = true
+	}
+
+	parents := make(map[int]int, len(layout.Categories))
+	placedItems := make(map[int]bool)
+	for _, category := range layout.Categories {
+		if !known[category.ID] {
+			return nil, menu.ErrInvalidMenuLayout
+		}
+		if _, seen := parents[category.ID]; seen {
+			return nil, menu.ErrInvalidMenuLayout
+		}
+		parents[category.ID] = category.ParentID
+
+		for _, itemID := range category.ItemIDs {
+			if !knownItems[itemID] || placedItems[itemID] {
+				return nil, menu.ErrInvalidMenuLayout
+			}
+			placedItems[itemID] = true
+		}
+	}
+	for id, parentID := range parents {
+		if parentID != 0 && !known[parentID] {
+			return nil, menu.ErrInvalidCategoryParent
+		}
+		if hasAncestor(parents, parentID, id) {
+			return nil, menu.ErrInvalidCategoryParent
+		}
+	}
+
+	if err := s.repo.SetMenuLayout(ctx, layout, businessID); err != nil {
+		return nil, err
+	}
+	return s.GetMenuLayout(ctx, businessID, true)
+}
+
+// validateCategoryParent checks that a category can be placed under parentID:
+// the parent exists and is not the category itself or one of its descendants
+func (s *MenuService) validateCategoryParent(ctx context.Context, id, parentID, businessID int) error {
+	if parentID < 0 {
+		return menu.ErrInvalidCategoryParent
+	}
+	if parentID == 0 {
+		return nil
+	}
+
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	parents := make(map[int]int, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	if _, ok := parents[parentID]; !ok {
+		return menu.ErrInvalidCategoryParent
+	}
+	if id > 0 && hasAncestor(parents, parentID, id) {
+		return menu.ErrInvalidCategoryParent
+	}
+	return nil
+}
+
+// hasAncestor reports whether ancestor is id itself or one of its ancestors in parents
+// (category ID -> parent ID). A cycle that does not pass through ancestor also counts,
+// so a broken tree is never accepted.
+func hasAncestor(parents map[int]int, id, ancestor int) bool {
+	for steps := 0; id != 0; steps++ {
+		if id == ancestor || steps > len(parents) {
+			return true
+		}
+		id = parents[id]
+	}
+	return false
+}
+
+// hiddenCategories returns the categories that are hidden themselves or sit below a hidden one
+func hiddenCategories(categories []menu.Category) map[int]bool {
+	byID := make(map[int]menu.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	hidden := make(map[int]bool)
+	for _, category := range categories {
+		for c, steps := category, 0; steps <= len(categories); steps++ {
+			if !c.IsVisible {
+				hidden[category.ID] = true
+				break
+			}
+			parent, ok := byID[c.ParentID]
+			if !ok {
+				break
+			}
+			c = parent
+		}
+	}
+	return hidden
+}
+
+// categoryTree nests categories under their parents, keeping their order. Categories whose
+// parent is missing from the list are placed at the top level.
+func categoryTree(categories []menu.Category) []menu.Category {
+	present := make(map[int]bool, len(categories))
+	children := make(map[int][]menu.Category)
+	for _, category := range categories {
+		present[category.ID] = true
+	}
+	for _, category := range categories {
+		parentID := category.ParentID
+		if !present[parentID] {
+			parentID = 0
+		}
+		children[parentID] = append(children[parentID], category)
+	}
+
+	var build func(parentID int, depth int) []menu.Category
+	build = func(parentID int, depth int) []menu.Category {
+		level := children[parentID]
+		if depth > len(categories) {
+			return nil
+		}
+		for i := range level {
+			level[i].Children = build(level[i].ID, depth+1)
+		}
+		return level
+	}
+
+	tree := build(0, 0)
+	if tree == nil {
+		tree = []menu.Category{}
+	}
+	return tree
+}
+
+// visibleMenuItems drops hidden dishes and the dishes of hidden categories
+func (s *MenuService) visibleMenuItems(ctx context.Context, items []menu.MenuItem, businessID int) ([]menu.MenuItem, error) {
+	categories, err := s.repo.GetCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	hidden := hiddenCategories(categories)
+
+	visible := []menu.MenuItem{}
+	for _, item := range items {
+		if item.IsVisible && !hidden[item.CategoryID] {
+			visible = append(visible, item)
+		}
+	}
+	return visible, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+	"time"
+)
+
+func (s *MenuService) GetPriceHistory(ctx context.Context, itemID int, businessID int) ([]menu.PriceChange, error) {
+	if _, err := s.priceChangeItem(ctx, itemID, businessID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPriceHistory(ctx, itemID, businessID)
+}
+
+func (s *MenuService) SchedulePriceChange(ctx context.Context, itemID int, input menu.PriceChangeInput, businessID int) (*menu.PriceChange, error) {
+	if input.Price <= 0 {
+		return nil, menu.ErrInvalidPriceChange
+	}
+	if _, err := s.priceChangeItem(ctx, itemID, businessID); err != nil {
+		return nil, err
+	}
+	effectiveFrom, err := s.priceEffectiveFrom(ctx, input.EffectiveFrom, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.schedulePrices(ctx, []menu.PriceChange{{
+		DishID:        itemID,
+		Price:         roundMoney(input.Price),
+		EffectiveFrom: effectiveFrom,
+		Note:          strings.TrimSpace(input.Note),
+		BusinessID:    businessID,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return &scheduled[0], nil
+}
+
+func (s *MenuService) CancelPriceChange(ctx context.Context, itemID int, changeID int, businessID int) error {
+	if changeID <= 0 {
+		return menu.ErrPriceChangeNotFound
+	}
+	if _, err := s.priceChangeItem(ctx, itemID, businessID); err != nil {
+		return err
+	}
+	return s.repo.DeletePriceChange(ctx, changeID, itemID, businessID)
+}
+
+func (s *MenuService) BulkChangePrices(ctx context.Context, change menu.BulkPriceChange, businessID int) (*menu.BulkPriceResult, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if (change.Percent == 0) == (change.Amount == 0) || change.Percent <= -100 || change.RoundTo < 0 {
+		return nil, menu.ErrInvalidPriceChange
+	}
+	roundTo := change.RoundTo
+	if roundTo == 0 {
+		roundTo = 0.01
+	}
+
+	var categoryID *int
+	if change.CategoryID > 0 {
+		category, err := s.repo.GetCategoryByID(ctx, change.CategoryID, businessID)
+		if err != nil || category == nil {
+			return nil, menu.ErrCategoryNotFound
+		}
+		categoryID = &change.CategoryID
+	}
+	effectiveFrom, err := s.priceEffectiveFrom(ctx, change.EffectiveFrom, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetMenuItems(ctx, categoryID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+
+	result := &menu.BulkPriceResult{
+		EffectiveFrom: effectiveFrom,
+		DryRun:        change.DryRun,
+		Items:         []menu.BulkPriceResultItem{},
+	}
+	var changes []menu.PriceChange
+	for _, item := range items {
+		price := item.Price*(1+change.Percent/100) + change.Amount
+		price = roundMoney(math.Round(price/roundTo) * roundTo)
+		if price <= 0 {
+			return nil, menu.ErrInvalidPriceChange
+		}
+		if price == item.Price {
+			continue
+		}
+
+		result.Items = append(result.Items, menu.BulkPriceResultItem{
+			DishID:   item.ID,
+			Name:     item.Name,
+			OldPrice: item.Price,
+			NewPrice: price,
+		})
+		changes = append(changes, menu.PriceChange{
+			DishID:        item.ID,
+			Price:         price,
+			EffectiveFrom: effectiveFrom,
+			Note:          strings.TrimSpace(change.Note),
+			BusinessID:    businessID,
+		})
+	}
+
+	if change.DryRun || len(changes) == 0 {
+		return result, nil
+	}
+	if _, err := s.schedulePrices(ctx, changes); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MenuService) ApplyDuePriceChanges(ctx context.Context) (int, error) {
+	return s.repo.ApplyDuePriceChanges(ctx, time.Now())
+}
+
+// schedulePrices stores the changes and applies them at once when they are already effective
+func (s *MenuService) schedulePrices(ctx context.Context, changes []menu.PriceChange) ([]menu.PriceChange, error) {
+	scheduled, err := s.repo.SchedulePriceChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if changes[0].EffectiveFrom.After(now) {
+		return scheduled, nil
+	}
+	if _, err := s.repo.ApplyDuePriceChanges(ctx, now); err != nil {
+		return nil, err
+	}
+	for i := range scheduled {
+		scheduled[i].Status = menu.PriceStatusApplied
+		scheduled[i].AppliedAt = &now
+	}
+	return scheduled, nil
+}
+
+// priceChangeItem verifies the dish belongs to the business
+func (s *MenuService) priceChangeItem(ctx context.Context, itemID int, businessID int) (*menu.MenuItem, error) {
+	if itemID <= 0 {
+		return nil, menu.ErrMenuItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	item, err := s.repo.GetMenuItemByID(ctx, itemID, businessID)
+	if err != nil || item == nil {
+		return nil, menu.ErrMenuItemNotFound
+	}
+	return item, nil
+}
+
+// priceEffectiveFrom parses when a price change takes effect. Empty or past moments mean now,
+// since history cannot be rewritten.
+func (s *MenuService) priceEffectiveFrom(ctx context.Context, value string, businessID int) (time.Time, error) {
+	now := time.Now()
+	if value == "" {
+		return now, nil
+	}
+
+	moment, err := time.ParseInLocation(menuPreviewLayout, value, s.businessLocation(ctx, businessID))
+	if err != nil {
+		moment, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, menu.ErrInvalidPriceChange
+		}
+	}
+	if moment.Before(now) {
+		return now, nil
+	}
+	return moment, nil
+}
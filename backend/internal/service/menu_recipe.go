@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/menu"
+)
+
+func (s *MenuService) GetRecipe(ctx context.Context, itemID int, businessID int) ([]menu.RecipeIngredient, error) {
+	if itemID <= 0 {
+		return nil, menu.ErrMenuItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	item, err := s.repo.GetMenuItemByID(ctx, itemID, businessID)
+	if err != nil || item == nil {
+		return nil, menu.ErrMenuItemNotFound
+	}
+
+	return s.repo.GetRecipe(ctx, itemID, businessID)
+}
+
+func (s *MenuService) SetRecipe(ctx context.Context, itemID int, ingredients []menu.RecipeIngredientInput, businessID int) ([]menu.RecipeIngredient, error) {
+	if itemID <= 0 {
+		return nil, menu.ErrMenuItemNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation
+	seen := make(map[int]bool, len(ingredients))
+	for _, ingredient := range ingredients {
+		if ingredient.InventoryID <= 0 || ingredient.Quantity <= 0 || seen[ingredient.InventoryID] {
+			return nil, menu.ErrInvalidMenuData
+		}
+		seen[ingredient.InventoryID] = true
+	}
+
+	item, err := s.repo.GetMenuItemByID(ctx, itemID, businessID)
+	if err != nil || item == nil {
+		return nil, menu.ErrMenuItemNotFound
+	}
+
+	// Recipes are stored in the stock unit of each ingredient
+	for i, ingredient := range ingredients {
+		if ingredient.Unit == "" {
+			continue
+		}
+		quantity, err := s.inventory.ConvertQuantity(ctx, ingredient.InventoryID, ingredient.Quantity, ingredient.Unit, businessID)
+		switch err {
+		case nil:
+		case inventory.ErrInventoryItemNotFound:
+			return nil, menu.ErrIngredientNotFound
+		case inventory.ErrIncompatibleUnits:
+			return nil, menu.ErrIncompatibleUnit
+		default:
+			return nil, err
+		}
+		ingredients[i].Quantity = quantity
+		ingredients[i].Unit = ""
+	}
+
+	if err := s.repo.SetRecipe(ctx, itemID, ingredients, businessID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, menu.ErrIngredientNotFound
+		}
+		return nil, err
+	}
+	return s.repo.GetRecipe(ctx, itemID, businessID)
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+	"time"
+)
+
+const (
+	menuClockLayout   = "15:04"
+	menuDateLayout    = "2006-01-02"
+	menuPreviewLayout = "2006-01-02T15:04"
+)
+
+func (s *MenuService) GetMenus(ctx context.Context, businessID int) ([]menu.Menu, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	return s.repo.GetMenus(ctx, businessID)
+}
+
+func (s *MenuService) GetMenuByID(ctx context.Context, id int, businessID int) (*menu.Menu, error) {
+	if id <= 0 {
+		return nil, menu.ErrMenuNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	m, err := s.repo.GetMenuByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, menu.ErrMenuNotFound
+	}
+	return m, nil
+}
+
+func (s *MenuService) CreateMenu(ctx context.Context, m menu.MenuCreate, businessID int) (*menu.Menu, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation
+	if strings.TrimSpace(m.Name) == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if err := s.validateMenuContents(ctx, m.CategoryIDs, m.ItemIDs, m.Schedules, businessID); err != nil {
+		return nil, err
+	}
+
+	// Set business ID
+	m.BusinessID = businessID
+
+	return s.repo.CreateMenu(ctx, m)
+}
+
+func (s *MenuService) UpdateMenu(ctx context.Context, id int, m menu.MenuUpdate, businessID int) (*menu.Menu, error) {
+	if id <= 0 {
+		return nil, menu.ErrMenuNotFound
+	}
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation for provided fields
+	if m.Name != "" && strings.TrimSpace(m.Name) == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if err := s.validateMenuContents(ctx, m.CategoryIDs, m.ItemIDs, m.Schedules, businessID); err != nil {
+		return nil, err
+	}
+
+	// Verify menu exists
+	existing, err := s.repo.GetMenuByID(ctx, id, businessID)
+	if err != nil || existing == nil {
+		return nil, menu.ErrMenuNotFound
+	}
+
+	// Set business ID
+	m.BusinessID = businessID
+
+	updated, err := s.repo.UpdateMenu(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, menu.ErrMenuNotFound
+	}
+	return updated, nil
+}
+
+func (s *MenuService) DeleteMenu(ctx context.Context, id int, businessID int) error {
+	if id <= 0 {
+		return menu.ErrMenuNotFound
+	}
+	if businessID <= 0 {
+		return menu.ErrInvalidMenuData
+	}
+
+	// Verify menu exists
+	existing, err := s.repo.GetMenuByID(ctx, id, businessID)
+	if err != nil || existing == nil {
+		return menu.ErrMenuNotFound
+	}
+
+	return s.repo.DeleteMenu(ctx, id, businessID)
+}
+
+func (s *MenuService) GetMenuItemsAt(ctx context.Context, categoryID *int, businessID int, at time.Time) ([]menu.MenuItem, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	items, err := s.repo.GetMenuItems(ctx, categoryID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		s.setThumbnails(&items[i])
+	}
+
+	active, restricted, err := s.activeMenusAt(ctx, businessID, at)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		var offered []menu.MenuItem
+		for _, item := range items {
+			if menusOfferItem(active, item) {
+				offered = append(offered, item)
+			}
+		}
+		items = offered
+	}
+
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *MenuService) ActiveMenusAt(ctx context.Context, businessID int, at time.Time) ([]menu.Menu, bool, error) {
+	if businessID <= 0 {
+		return nil, false, menu.ErrInvalidMenuData
+	}
+
+	return s.activeMenusAt(ctx, businessID, at)
+}
+
+func (s *MenuService) PreviewMenu(ctx context.Context, businessID int, at string) (*menu.MenuPreview, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	loc := s.businessLocation(ctx, businessID)
+
+	moment := time.Now()
+	if at != "" {
+		parsed, err := time.ParseInLocation(menuPreviewLayout, at, loc)
+		if err != nil {
+			parsed, err = time.Parse(time.RFC3339, at)
+			if err != nil {
+				return nil, menu.ErrInvalidMenuSchedule
+			}
+		}
+		moment = parsed
+	}
+	moment = moment.In(loc)
+
+	active, _, err := s.activeMenusAt(ctx, businessID, moment)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.GetMenuItemsAt(ctx, nil, businessID, moment)
+	if err != nil {
+		return nil, err
+	}
+
+	if active == nil {
+		active = []menu.Menu{}
+	}
+	if items == nil {
+		items = []menu.MenuItem{}
+	}
+
+	return &menu.MenuPreview{
+		At:       moment,
+		Timezone: loc.String(),
+		Menus:    active,
+		Items:    items,
+	}, nil
+}
+
+// activeMenusAt returns the menus on offer at the given moment. restricted is
+// false when the business has no enabled menus, in which case every item is on offer.
+func (s *MenuService) activeMenusAt(ctx context.Context, businessID int, at time.Time) ([]menu.Menu, bool, error) {
+	menus, err := s.repo.GetMenus(ctx, businessID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	restricted := false
+	for _, m := range menus {
+		if m.IsActive {
+			restricted = true
+			break
+		}
+	}
+	if !restricted {
+		return nil, false, nil
+	}
+
+	local := at.In(s.businessLocation(ctx, businessID))
+
+	var active []menu.Menu
+	for _, m := range menus {
+		if menuActiveAt(m, local) {
+			active = append(active, m)
+		}
+	}
+	return active, true, nil
+}
+
+// businessLocation resolves the business timezone, falling back to business.DefaultLocation
+func (s *MenuService) businessLocation(ctx context.Context, businessID int) *time.Location {
+	return businessLocation(ctx, s.businessRepo, businessID)
+}
+
+// businessLocation is shared by the services that work in business local time
+func businessLocation(ctx context.Context, businessRepo business.Repository, businessID int) *time.Location {
+	if businessRepo == nil {
+		return business.DefaultLocation
+	}
+
+	b, err := businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil || b == nil || b.Timezone == "" {
+		return business.DefaultLocation
+	}
+
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil {
+		log.Printf("Invalid timezone %q for business %d: %v", b.Timezone, businessID, err)
+		return business.DefaultLocation
+	}
+	return loc
+}
+
+// errInvalidPeriod is returned by parsePeriod; callers report their own period error
+var errInvalidPeriod = errors.New("invalid period")
+
+// parsePeriod turns inclusive local dates (YYYY-MM-DD) into a [from, to) interval. An
+// empty to means today and an empty from means days before the end.
+func parsePeriod(from, to string, days int, loc *time.Location) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if to != "" {
+		parsed, err := time.ParseInLocation(menuDateLayout, to, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidPeriod
+		}
+		end = parsed
+	}
+	end = end.AddDate(0, 0, 1)
+
+	start := end.AddDate(0, 0, -days)
+	if from != "" {
+		parsed, err := time.ParseInLocation(menuDateLayout, from, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidPeriod
+		}
+		start = parsed
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, errInvalidPeriod
+	}
+	return start, end, nil
+}
+
+func (s *MenuService) validateMenuContents(ctx context.Context, categoryIDs, itemIDs []int, schedules []menu.MenuSchedule, businessID int) error {
+	for _, categoryID := range categoryIDs {
+		category, err := s.repo.GetCategoryByID(ctx, categoryID, businessID)
+		if err != nil || category == nil {
+			return menu.ErrCategoryNotFound
+		}
+	}
+
+	for _, itemID := range itemIDs {
+		item, err := s.repo.GetMenuItemByID(ctx, itemID, businessID)
+		if err != nil || item == nil {
+			return menu.ErrMenuItemNotFound
+		}
+	}
+
+	for _, schedule := range schedules {
+		if !isValidMenuSchedule(schedule) {
+			return menu.ErrInvalidMenuSchedule
+		}
+	}
+
+	return nil
+}
+
+func isValidMenuSchedule(schedule menu.MenuSchedule) bool {
+	for _, day := range schedule.DaysOfWeek {
+		if day < 0 || day > 6 {
+			return false
+		}
+	}
+
+	for _, clock := range []string{schedule.StartTime, schedule.EndTime} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse(menuClockLayout, clock); err != nil {
+			return false
+		}
+	}
+
+	for _, date := range []string{schedule.StartDate, schedule.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(menuDateLayout, date); err != nil {
+			return false
+		}
+	}
+
+	if schedule.StartDate != "" && schedule.EndDate != "" && schedule.EndDate < schedule.StartDate {
+		return false
+	}
+
+	return true
+}
+
+// menuActiveAt reports whether an enabled menu has a schedule covering the local time.
+// A menu without schedules is always on offer.
+func menuActiveAt(m menu.Menu, local time.Time) bool {
+	if !m.IsActive {
+		return false
+	}
+	if len(m.Schedules) == 0 {
+		return true
+	}
+
+	for _, schedule := range m.Schedules {
+		if scheduleActiveAt(schedule, local) {
+			return true
+		}
+	}
+	return false
+}
+
+func scheduleActiveAt(schedule menu.MenuSchedule, local time.Time) bool {
+	minute := local.Hour()*60 + local.Minute()
+	start, end := 0, 24*60
+	if schedule.StartTime != "" {
+		start = clockMinutes(schedule.StartTime)
+	}
+	if schedule.EndTime != "" {
+		end = clockMinutes(schedule.EndTime)
+	}
+
+	// Overnight ranges (e.g. 22:00-02:00) belong to the day they started on
+	day := local
+	if end <= start {
+		if minute < end {
+			day = local.AddDate(0, 0, -1)
+		} else if minute < start {
+			return false
+		}
+	} else if minute < start || minute >= end {
+		return false
+	}
+
+	if len(schedule.DaysOfWeek) > 0 {
+		matched := false
+		for _, d := range schedule.DaysOfWeek {
+			if time.Weekday(d) == day.Weekday() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	date := day.Format(menuDateLayout)
+	if schedule.StartDate != "" && date < schedule.StartDate {
+		return false
+	}
+	if schedule.EndDate != "" && date > schedule.EndDate {
+		return false
+	}
+
+	return true
+}
+
+func clockMinutes(clock string) int {
+	t, err := time.Parse(menuClockLayout, clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+func menusOfferItem(menus []menu.Menu, item menu.MenuItem) bool {
+	for _, m := range menus {
+		for _, id := range m.ItemIDs {
+			if id == item.ID {
+				return true
+			}
+		}
+		for _, categoryID := range m.CategoryIDs {
+			if categoryID == item.CategoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
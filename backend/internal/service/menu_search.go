@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+	"time"
+	"unicode"
+)
+
+func (s *MenuService) SearchMenuItems(ctx context.Context, query string, limit int, businessID int) ([]menu.MenuItem, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	search := menu.MenuSearch{Query: strings.TrimSpace(query), Terms: searchTerms(query), Limit: limit}
+	if len(search.Terms) == 0 {
+		return nil, menu.ErrInvalidSearchQuery
+	}
+	if search.Limit <= 0 {
+		search.Limit = menu.DefaultSearchLimit
+	}
+	if search.Limit > menu.MaxSearchLimit {
+		search.Limit = menu.MaxSearchLimit
+	}
+
+	items, err := s.repo.SearchMenuItems(ctx, search, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		s.setThumbnails(&items[i])
+	}
+
+	// Only what can be ordered now, keeping the ranking
+	active, restricted, err := s.activeMenusAt(ctx, businessID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		offered := []menu.MenuItem{}
+		for _, item := range items {
+			if menusOfferItem(active, item) {
+				offered = append(offered, item)
+			}
+		}
+		items = offered
+	}
+
+	if err := s.translateItems(ctx, items, businessID); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// searchTerms splits a query into lower-case words of letters and digits. Everything else is a
+// separator, so the terms are safe to turn into a full-text prefix query.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/menu"
+	"strings"
+)
+
+func (s *MenuService) GetTranslations(ctx context.Context, language string, businessID int) ([]menu.Translation, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	return s.repo.GetTranslations(ctx, strings.ToLower(strings.TrimSpace(language)), businessID)
+}
+
+func (s *MenuService) GetEntityTranslations(ctx context.Context, entityType string, entityID int, businessID int) ([]menu.Translation, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if err := s.verifyTranslatable(ctx, entityType, entityID, businessID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetEntityTranslations(ctx, entityType, entityID, businessID)
+}
+
+func (s *MenuService) SetTranslation(ctx context.Context, entityType string, entityID int, language string, input menu.TranslationInput, businessID int) (*menu.Translation, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	// Validation
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Name == "" && input.Description == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if entityType == menu.TranslationEntityCategory && input.Name == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == businessDefaultLanguage(b) || !containsString(b.SupportedLanguages, language) {
+		return nil, menu.ErrUnsupportedLanguage
+	}
+
+	if err := s.verifyTranslatable(ctx, entityType, entityID, businessID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpsertTranslation(ctx, menu.Translation{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Language:    language,
+		Name:        input.Name,
+		Description: input.Description,
+		BusinessID:  businessID,
+	})
+}
+
+func (s *MenuService) DeleteTranslation(ctx context.Context, entityType string, entityID int, language string, businessID int) error {
+	if businessID <= 0 {
+		return menu.ErrInvalidMenuData
+	}
+
+	err := s.repo.DeleteTranslation(ctx, entityType, entityID, strings.ToLower(strings.TrimSpace(language)), businessID)
+	if err == sql.ErrNoRows {
+		return menu.ErrTranslationNotFound
+	}
+	return err
+}
+
+func (s *MenuService) GetCatalogueTranslations(ctx context.Context, language string, businessID int) ([]menu.CatalogueTranslation, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	return s.repo.GetCatalogueTranslations(ctx, strings.ToLower(strings.TrimSpace(language)), businessID)
+}
+
+// SetCatalogueTranslation names an allergen or dietary tag in one of the business
+// languages, including the default one since the catalogue only has built-in names for some
+func (s *MenuService) SetCatalogueTranslation(ctx context.Context, code string, language string, input menu.TranslationInput, businessID int) (*menu.CatalogueTranslation, error) {
+	if businessID <= 0 {
+		return nil, menu.ErrInvalidMenuData
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, menu.ErrInvalidMenuData
+	}
+	if _, ok := menu.FindCatalogueEntry(code); !ok {
+		return nil, menu.ErrUnknownCatalogueEntry
+	}
+
+	b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language != businessDefaultLanguage(b) && !containsString(b.SupportedLanguages, language) {
+		return nil, menu.ErrUnsupportedLanguage
+	}
+
+	return s.repo.UpsertCatalogueTranslation(ctx, menu.CatalogueTranslation{
+		Code:       code,
+		Language:   language,
+		Name:       input.Name,
+		BusinessID: businessID,
+	})
+}
+
+func (s *MenuService) DeleteCatalogueTranslation(ctx context.Context, code string, language string, businessID int) error {
+	if businessID <= 0 {
+		return menu.ErrInvalidMenuData
+	}
+
+	err := s.repo.DeleteCatalogueTranslation(ctx, code, strings.ToLower(strings.TrimSpace(language)), businessID)
+	if err == sql.ErrNoRows {
+		return menu.ErrTranslationNotFound
+	}
+	return err
+}
+
+// CatalogueNames names every allergen and dietary tag in lang, preferring the names set by
+// the business over the built-in ones, which fall back to English
+func (s *MenuService) CatalogueNames(ctx context.Context, lang string, businessID int) (map[string]string, error) {
+	names := make(map[string]string, len(menu.Allergens)+len(menu.DietaryTags))
+	for _, catalogue := range [][]menu.CatalogueEntry{menu.Allergens, menu.DietaryTags} {
+		for _, entry := range catalogue {
+			names[entry.Code] = entry.Name(lang)
+		}
+	}
+
+	translations, err := s.repo.GetCatalogueTranslations(ctx, lang, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range translations {
+		names[t.Code] = t.Name
+	}
+	return names, nil
+}
+
+func (s *MenuService) ResolveLanguage(ctx context.Context, businessID int, requested []string) (string, string, error) {
+	if businessID <= 0 {
+		return "", "", menu.ErrInvalidMenuData
+	}
+
+	b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return "", "", err
+	}
+	defaultLanguage := businessDefaultLanguage(b)
+
+	// Prefer an exact match, then a match on the primary subtag (en-US -> en)
+	for _, lang := range requested {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if containsString(b.SupportedLanguages, lang) || lang == defaultLanguage {
+			return lang, defaultLanguage, nil
+		}
+		for _, supported := range b.SupportedLanguages {
+			if primaryLanguage(supported) == primaryLanguage(lang) {
+				return supported, defaultLanguage, nil
+			}
+		}
+	}
+	return defaultLanguage, defaultLanguage, nil
+}
+
+// translateItems replaces dish and category texts with their translations into the
+// context language. Missing translations keep the base text.
+func (s *MenuService) translateItems(ctx context.Context, items []menu.MenuItem, businessID int) error {
+	translations, err := s.contextTranslations(ctx, businessID)
+	if err != nil || translations == nil {
+		return err
+	}
+
+	for i := range items {
+		item := &items[i]
+		if t, ok := translations[translationRef{menu.TranslationEntityDish, item.ID}]; ok {
+			if t.Name != "" {
+				item.Name = t.Name
+			}
+			if t.Description != "" {
+				item.Description = t.Description
+			}
+			item.Language = t.Language
+		}
+		if t, ok := translations[translationRef{menu.TranslationEntityCategory, item.CategoryID}]; ok && t.Name != "" {
+			item.Category.ID = item.CategoryID
+			item.Category.Name = t.Name
+			item.Category.Language = t.Language
+		}
+	}
+	return nil
+}
+
+// translateCategories replaces category names with their translations into the context language
+func (s *MenuService) translateCategories(ctx context.Context, categories []menu.Category, businessID int) error {
+	translations, err := s.contextTranslations(ctx, businessID)
+	if err != nil || translations == nil {
+		return err
+	}
+
+	for i := range categories {
+		if t, ok := translations[translationRef{menu.TranslationEntityCategory, categories[i].ID}]; ok && t.Name != "" {
+			categories[i].Name = t.Name
+			categories[i].Language = t.Language
+		}
+	}
+	return nil
+}
+
+// contextTranslations loads the translations for the context language, or nil when
+// base texts were requested
+func (s *MenuService) contextTranslations(ctx context.Context, businessID int) (map[translationRef]menu.Translation, error) {
+	lang := menu.LanguageFromContext(ctx)
+	if lang == "" {
+		return nil, nil
+	}
+
+	list, err := s.repo.GetTranslations(ctx, lang, businessID)
+	if err != nil {
+		return nil, err
+	}
+	translations := make(map[translationRef]menu.Translation, len(list))
+	for _, t := range list {
+		translations[translationRef{t.EntityType, t.EntityID}] = t
+	}
+	return translations, nil
+}
+
+// verifyTranslatable checks that the translated category or dish belongs to the business
+func (s *MenuService) verifyTranslatable(ctx context.Context, entityType string, entityID int, businessID int) error {
+	switch entityType {
+	case menu.TranslationEntityCategory:
+		category, err := s.repo.GetCategoryByID(ctx, entityID, businessID)
+		if err != nil || category == nil {
+			return menu.ErrCategoryNotFound
+		}
+	case menu.TranslationEntityDish:
+		item, err := s.repo.GetMenuItemByID(ctx, entityID, businessID)
+		if err != nil || item == nil {
+			return menu.ErrMenuItemNotFound
+		}
+	default:
+		return menu.ErrInvalidMenuData
+	}
+	return nil
+}
+
+// translationRef identifies the translated category or dish
+type translationRef struct {
+	entityType string
+	entityID   int
+}
+
+func businessDefaultLanguage(b *business.Business) string {
+	if b.DefaultLanguage == "" {
+		return business.DefaultLanguage
+	}
+	return b.DefaultLanguage
+}
+
+// primaryLanguage returns the primary subtag of a language tag, e.g. "pt" for "pt-br"
+func primaryLanguage(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
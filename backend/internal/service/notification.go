@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
+	"strings"
 	"time"
 
 	"restaurant-management/internal/domain/notification"
@@ -142,6 +144,29 @@ func (s *NotificationService) SendLowInventoryAlert(ctx context.Context, busines
 	return err
 }
 
+func (s *NotificationService) SendExpiryAlert(ctx context.Context, businessID int, lots []notification.ExpiringStock) error {
+	if len(lots) == 0 {
+		return nil
+	}
+	subject := fmt.Sprintf("⏰ Истекает срок годности: %d партий", len(lots))
+	body := s.generateExpiryHTML(lots)
+
+	recipients, err := s.getManagerEmails(ctx, businessID)
+	if err != nil {
+		return fmt.Errorf("failed to get manager emails: %w", err)
+	}
+
+	req := notification.CreateNotificationRequest{
+		Type:       notification.NotificationTypeExpiry,
+		Subject:    subject,
+		Body:       body,
+		Recipients: recipients,
+	}
+
+	_, err = s.CreateNotification(ctx, businessID, req)
+	return err
+}
+
 func (s *NotificationService) SendNewHiringAlert(ctx context.Context, businessID int, applicantName, position, experience, location string) error {
 	subject := fmt.Sprintf("📋 Новая заявка на найм: %s", position)
 	body := s.generateNewHiringHTML(applicantName, position, experience, location)
@@ -233,6 +258,59 @@ func (s *NotificationService) generateLowInventoryHTML(itemName string, currentS
 `, itemName, currentStock, unit, minStock, unit)
 }
 
+func (s *NotificationService) generateExpiryHTML(lots []notification.ExpiringStock) string {
+	var rows strings.Builder
+	for _, lot := range lots {
+		status := fmt.Sprintf("через %d дн.", lot.DaysLeft)
+		switch {
+		case lot.DaysLeft < 0:
+			status = "просрочено"
+		case lot.DaysLeft == 0:
+			status = "сегодня"
+		}
+		lotNumber := lot.LotNumber
+		if lotNumber == "" {
+			lotNumber = "—"
+		}
+		fmt.Fprintf(&rows, `
+            <tr>
+                <td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+                <td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+                <td style="padding: 8px; border-bottom: 1px solid #eee;">%.2f %s</td>
+                <td style="padding: 8px; border-bottom: 1px solid #eee;">%s (%s)</td>
+            </tr>`, html.EscapeString(lot.ItemName), html.EscapeString(lotNumber), lot.Quantity, html.EscapeString(lot.Unit), lot.ExpiresOn, status)
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Истекает срок годности</title>
+</head>
+<body style="font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f4f4f4;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: white; padding: 20px; border-radius: 8px; box-shadow: 0 2px 4px rgba(0,0,0,0.1);">
+        <h2 style="color: #d32f2f; margin-bottom: 20px;">⏰ Истекает срок годности</h2>
+        <p>Здравствуйте!</p>
+        <p>У следующих партий скоро истекает или уже истёк срок годности:</p>
+        <table style="width: 100%%; border-collapse: collapse; margin: 20px 0;">
+            <tr style="background-color: #fff3cd;">
+                <th style="padding: 8px; text-align: left;">Товар</th>
+                <th style="padding: 8px; text-align: left;">Партия</th>
+                <th style="padding: 8px; text-align: left;">Остаток</th>
+                <th style="padding: 8px; text-align: left;">Годен до</th>
+            </tr>%s
+        </table>
+        <p>Используйте эти партии в первую очередь или спишите просроченный товар.</p>
+        <p style="color: #666; font-size: 14px; margin-top: 30px;">
+            Это автоматическое уведомление из системы управления рестораном.
+        </p>
+    </div>
+</body>
+</html>
+`, rows.String())
+}
+
 func (s *NotificationService) generateNewHiringHTML(applicantName, position, experience, location string) string {
 	return fmt.Sprintf(`
 <!DOCTYPE html>
@@ -3,16 +3,24 @@ package service
 import (
 	"context"
 	"log"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/menu"
 	"restaurant-management/internal/domain/order"
+	"restaurant-management/internal/domain/table"
 	"time"
 )
 
 type OrderService struct {
-	repo order.Repository
+	repo         order.Repository
+	menuService  menu.Service
+	businessRepo business.Repository
+	tableRepo    table.Repository  // table status follows the orders
+	inventory    inventory.Service // completed orders consume their recipe ingredients
 }
 
-func NewOrderService(repo order.Repository) order.Service {
-	return &OrderService{repo: repo}
+func NewOrderService(repo order.Repository, menuService menu.Service, businessRepo business.Repository, tableRepo table.Repository, inventoryService inventory.Service) order.Service {
+	return &OrderService{repo: repo, menuService: menuService, businessRepo: businessRepo, tableRepo: tableRepo, inventory: inventoryService}
 }
 
 func (s *OrderService) GetActiveOrders(ctx context.Context, businessID int) ([]order.Order, error) {
@@ -20,7 +28,12 @@ func (s *OrderService) GetActiveOrders(ctx context.Context, businessID int) ([]o
 		return nil, order.ErrInvalidOrderData
 	}
 
-	return s.repo.GetActiveOrdersWithItems(ctx, businessID)
+	orders, err := s.repo.GetActiveOrdersWithItems(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	s.setAllergyBanners(ctx, orders, businessID)
+	return orders, nil
 }
 
 func (s *OrderService) GetOrderByID(ctx context.Context, id int, businessID int) (*order.Order, error) {
@@ -31,11 +44,29 @@ func (s *OrderService) GetOrderByID(ctx context.Context, id int, businessID int)
 		return nil, order.ErrInvalidOrderData
 	}
 
-	return s.repo.GetOrderByID(ctx, id, businessID)
+	o, err := s.repo.GetOrderByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	orders := []order.Order{*o}
+	s.setAllergyBanners(ctx, orders, businessID)
+	return &orders[0], nil
 }
 
 func (s *OrderService) CreateOrder(ctx context.Context, req order.CreateOrderRequest, waiterID, businessID int) (*order.Order, error) {
-	if waiterID <= 0 || businessID <= 0 {
+	if waiterID <= 0 {
+		return nil, order.ErrInvalidOrderData
+	}
+
+	return s.createOrder(ctx, req, waiterID, order.OrderSourceStaff, businessID)
+}
+
+func (s *OrderService) CreateGuestOrder(ctx context.Context, req order.CreateOrderRequest, businessID int) (*order.Order, error) {
+	return s.createOrder(ctx, req, 0, order.OrderSourceGuest, businessID)
+}
+
+func (s *OrderService) createOrder(ctx context.Context, req order.CreateOrderRequest, waiterID int, source order.OrderSource, businessID int) (*order.Order, error) {
+	if businessID <= 0 {
 		return nil, order.ErrInvalidOrderData
 	}
 
@@ -43,23 +74,48 @@ func (s *OrderService) CreateOrder(ctx context.Context, req order.CreateOrderReq
 	if req.TableID <= 0 {
 		return nil, order.ErrInvalidOrderData
 	}
-	if len(req.Items) == 0 {
+	if len(req.Items) == 0 && len(req.Bundles) == 0 {
 		return nil, order.ErrInvalidOrderData
 	}
+	allergies, seatAllergies, err := normalizeAllergies(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order occupies its table, so the table must belong to the business
+	if s.tableRepo != nil {
+		if _, err := s.tableRepo.GetBusinessTable(ctx, req.TableID, businessID); err != nil {
+			if err == table.ErrTableNotFound {
+				return nil, order.ErrInvalidTableID
+			}
+			log.Printf("Error loading table %d for a new order: %v", req.TableID, err)
+			return nil, err
+		}
+	}
 
 	// Create order object
 	o := &order.Order{
-		TableID:  req.TableID,
-		WaiterID: waiterID,
-		Status:   order.OrderStatusNew,
-		Comment:  req.Comment,
-		Items:    make([]order.OrderItem, len(req.Items)),
+		TableID:       req.TableID,
+		WaiterID:      waiterID,
+		Status:        order.OrderStatusNew,
+		Source:        source,
+		Comment:       req.Comment,
+		Items:         make([]order.OrderItem, len(req.Items)),
+		Allergies:     allergies,
+		SeatAllergies: seatAllergies,
+	}
+
+	// Only dishes on a currently active menu can be ordered; the menus are loaded once per order
+	offer, err := s.menuOfferAt(ctx, businessID, time.Now())
+	if err != nil {
+		log.Printf("Error loading the menus active for business %d: %v", businessID, err)
+		return nil, err
 	}
 
 	// Calculate total amount and validate items
 	var totalAmount float64
 	for i, item := range req.Items {
-		if item.Quantity <= 0 {
+		if item.Quantity <= 0 || item.Seat < 0 {
 			return nil, order.ErrInvalidOrderData
 		}
 
@@ -69,11 +125,19 @@ func (s *OrderService) CreateOrder(ctx context.Context, req order.CreateOrderReq
 			log.Printf("Error getting dish %d: %v", item.DishID, err)
 			return nil, order.ErrDishNotFound
 		}
+		if dish.BusinessID != businessID {
+			return nil, order.ErrDishNotFound
+		}
 
-		if !dish.IsAvailable {
+		// Hidden dishes are off the waiter and guest menus, so they cannot be ordered either
+		if !dish.IsAvailable || !dish.IsVisible {
 			return nil, order.ErrDishNotAvailable
 		}
 
+		if !offer.offers(dish) {
+			return nil, order.ErrDishNotOnMenu
+		}
+
 		itemTotal := float64(item.Quantity) * dish.Price
 		totalAmount += itemTotal
 
@@ -84,12 +148,67 @@ func (s *OrderService) CreateOrder(ctx context.Context, req order.CreateOrderReq
 			Price:    dish.Price,
 			Total:    itemTotal,
 			Notes:    item.Notes,
+			Seat:     item.Seat,
 		}
 	}
 
+	// Bundles are billed at their own price; their dishes go to the kitchen as items
+	for _, input := range req.Bundles {
+		bundle, err := s.orderBundle(ctx, input, offer, businessID)
+		if err != nil {
+			return nil, err
+		}
+		totalAmount += bundle.Total
+		o.Bundles = append(o.Bundles, *bundle)
+	}
+
 	o.TotalAmount = totalAmount
 
-	return s.repo.CreateOrderAndItems(ctx, o, businessID)
+	// Check the dishes against the guest allergies; the business decides whether a conflict blocks the order
+	warnings, err := s.checkAllergens(ctx, o, businessID)
+	if err != nil {
+		log.Printf("Error checking allergens for table %d: %v", req.TableID, err)
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		b, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+		if err != nil {
+			return nil, err
+		}
+		if b.AllergenPolicy == business.AllergenPolicyBlock {
+			return nil, &order.AllergenConflictError{Warnings: warnings}
+		}
+	}
+
+	created, err := s.repo.CreateOrderAndItems(ctx, o, businessID)
+	if err != nil {
+		return nil, err
+	}
+	created.AllergyWarnings = warnings
+	s.occupyTable(ctx, created, waiterID, businessID)
+	orders := []order.Order{*created}
+	s.setAllergyBanners(ctx, orders, businessID)
+	return &orders[0], nil
+}
+
+// menuOffer holds the menus active when an order is placed. When the business has no
+// enabled menus it is unrestricted and every dish is on offer.
+type menuOffer struct {
+	menus      []menu.Menu
+	restricted bool
+}
+
+func (s *OrderService) menuOfferAt(ctx context.Context, businessID int, at time.Time) (menuOffer, error) {
+	active, restricted, err := s.menuService.ActiveMenusAt(ctx, businessID, at)
+	if err != nil {
+		return menuOffer{}, err
+	}
+	return menuOffer{menus: active, restricted: restricted}, nil
+}
+
+// offers reports whether the dish is on one of the active menus
+func (o menuOffer) offers(dish *order.Dish) bool {
+	return !o.restricted || menusOfferItem(o.menus, menu.MenuItem{ID: dish.ID, CategoryID: dish.CategoryID})
 }
 
 func (s *OrderService) UpdateOrderStatus(ctx context.Context, id int, req order.UpdateOrderStatusRequest, businessID int) error {
@@ -113,6 +232,9 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id int, req order.
 
 	// Update order status
 	o.Status = req.Status
+	if req.Status == order.OrderStatusAccepted && o.WaiterID == 0 {
+		o.WaiterID = req.WaiterID
+	}
 
 	// Set timestamps based on status
 	now := time.Now()
@@ -123,7 +245,19 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id int, req order.
 		o.CancelledAt = &now
 	}
 
-	return s.repo.UpdateOrder(ctx, o)
+	if err := s.repo.UpdateOrder(ctx, o); err != nil {
+		return err
+	}
+
+	if req.Status == order.OrderStatusCompleted && s.inventory != nil {
+		if err := s.inventory.ConsumeOrder(ctx, o.ID, req.WaiterID, businessID); err != nil {
+			log.Printf("Error recording stock consumption of order %d: %v", o.ID, err)
+		}
+	}
+	if req.Status == order.OrderStatusCompleted || req.Status == order.OrderStatusCancelled {
+		s.releaseTable(ctx, o, req.WaiterID, businessID)
+	}
+	return nil
 }
 
 func (s *OrderService) GetOrderStats(ctx context.Context, businessID int) (*order.OrderStats, error) {
@@ -147,7 +281,12 @@ func (s *OrderService) GetKitchenOrders(ctx context.Context, businessID int) ([]
 		return nil, order.ErrInvalidOrderData
 	}
 
-	return s.repo.GetOrdersByStatus(ctx, string(order.OrderStatusPreparing), businessID)
+	orders, err := s.repo.GetOrdersByStatus(ctx, string(order.OrderStatusPreparing), businessID)
+	if err != nil {
+		return nil, err
+	}
+	s.setAllergyBanners(ctx, orders, businessID)
+	return orders, nil
 }
 
 func (s *OrderService) UpdateOrderStatusByCook(ctx context.Context, id int, req order.UpdateOrderStatusRequest, businessID int) error {
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/order"
+	"strings"
+)
+
+// normalizeAllergies maps the table and seat allergies of a new order to allergen codes
+func normalizeAllergies(req order.CreateOrderRequest) ([]string, []order.SeatAllergies, error) {
+	allergies, unknown := canonicalAllergens(req.Allergies)
+	if unknown != "" {
+		return nil, nil, order.ErrUnknownAllergy
+	}
+
+	var seats []order.SeatAllergies
+	seen := make(map[int]bool, len(req.Seats))
+	for _, seat := range req.Seats {
+		if seat.Seat <= 0 || seen[seat.Seat] {
+			return nil, nil, order.ErrInvalidOrderData
+		}
+		seen[seat.Seat] = true
+
+		codes, unknown := canonicalAllergens(seat.Allergies)
+		if unknown != "" {
+			return nil, nil, order.ErrUnknownAllergy
+		}
+		if len(codes) > 0 {
+			seats = append(seats, order.SeatAllergies{Seat: seat.Seat, Allergies: codes})
+		}
+	}
+	return allergies, seats, nil
+}
+
+// checkAllergens matches the declared allergens and recipe ingredients of every item
+// against the guest allergies. Items for a seat are checked against the table and that
+// seat; shared items against everyone at the table. Conflicts are recorded on the items,
+// bundle components included.
+func (s *OrderService) checkAllergens(ctx context.Context, o *order.Order, businessID int) ([]order.AllergenWarning, error) {
+	if len(o.Allergies) == 0 && len(o.SeatAllergies) == 0 {
+		return nil, nil
+	}
+
+	sources := make(map[int]map[string][]string) // dish ID -> allergen -> sources
+	var warnings []order.AllergenWarning
+	for _, item := range newOrderItems(o) {
+		dishSources, ok := sources[item.DishID]
+		if !ok {
+			var err error
+			if dishSources, err = s.allergenSources(ctx, item.DishID, businessID); err != nil {
+				return nil, err
+			}
+			sources[item.DishID] = dishSources
+		}
+
+		for _, allergy := range guestAllergies(o, item.Seat) {
+			found, ok := dishSources[allergy]
+			if !ok {
+				continue
+			}
+			item.AllergenConflicts = append(item.AllergenConflicts, allergy)
+			for _, source := range found {
+				warnings = append(warnings, order.AllergenWarning{
+					DishID:   item.DishID,
+					DishName: item.Name,
+					Seat:     item.Seat,
+					Allergen: allergy,
+					Source:   source,
+				})
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// newOrderItems returns every item of an order about to be created: the dishes
+// ordered on their own and the components of its bundles
+func newOrderItems(o *order.Order) []*order.OrderItem {
+	var items []*order.OrderItem
+	for i := range o.Items {
+		items = append(items, &o.Items[i])
+	}
+	for b := range o.Bundles {
+		for i := range o.Bundles[b].Items {
+			items = append(items, &o.Bundles[b].Items[i])
+		}
+	}
+	return items
+}
+
+// allergenSources lists where each allergen of a dish comes from: "dish" for declared
+// allergens, or the names of recipe ingredients containing it
+func (s *OrderService) allergenSources(ctx context.Context, dishID int, businessID int) (map[string][]string, error) {
+	item, err := s.menuService.GetMenuItemByID(ctx, dishID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, order.ErrDishNotFound
+	}
+	recipe, err := s.menuService.GetRecipe(ctx, dishID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string][]string)
+	for _, allergen := range item.Allergens {
+		sources[allergen] = append(sources[allergen], "dish")
+	}
+	for _, ingredient := range recipe {
+		for _, allergen := range ingredient.Allergens {
+			sources[allergen] = append(sources[allergen], ingredient.Name)
+		}
+	}
+	return sources, nil
+}
+
+// guestAllergies returns the allergies relevant to an item served to a seat, or to
+// the whole table when seat is 0
+func guestAllergies(o *order.Order, seat int) []string {
+	allergies := append([]string{}, o.Allergies...)
+	for _, s := range o.SeatAllergies {
+		if seat != 0 && s.Seat != seat {
+			continue
+		}
+		for _, allergy := range s.Allergies {
+			if !containsString(allergies, allergy) {
+				allergies = append(allergies, allergy)
+			}
+		}
+	}
+	return allergies
+}
+
+// setAllergyBanners fills in the kitchen banner of orders with guest allergies
+func (s *OrderService) setAllergyBanners(ctx context.Context, orders []order.Order, businessID int) {
+	lang := ""
+	var names map[string]string
+	for i := range orders {
+		if len(orders[i].Allergies) == 0 && len(orders[i].SeatAllergies) == 0 {
+			continue
+		}
+		if lang == "" {
+			lang = business.DefaultLanguage
+			if b, err := s.businessRepo.GetBusinessByID(ctx, businessID); err == nil {
+				lang = businessDefaultLanguage(b)
+			}
+			var err error
+			if names, err = s.menuService.CatalogueNames(ctx, lang, businessID); err != nil {
+				log.Printf("Error loading allergen names for business %d: %v", businessID, err)
+			}
+		}
+		orders[i].AllergyBanner = allergyBanner(&orders[i], lang, names)
+	}
+}
+
+// allergyBannerLabels holds the banner wording per language: allergy, seat, check
+var allergyBannerLabels = map[string][3]string{
+	"en": {"ALLERGY", "seat", "CHECK"},
+	"ru": {"АЛЛЕРГИЯ", "место", "ПРОВЕРИТЬ"},
+}
+
+// allergyBanner summarises the guest allergies and conflicting dishes, e.g.
+// "ALLERGY: Milk; seat 2: Peanuts. CHECK: Satay (seat 2): Peanuts"
+func allergyBanner(o *order.Order, lang string, names map[string]string) string {
+	labels, ok := allergyBannerLabels[primaryLanguage(lang)]
+	if !ok {
+		labels = allergyBannerLabels["en"]
+	}
+
+	var parts []string
+	if len(o.Allergies) > 0 {
+		parts = append(parts, allergenNames(o.Allergies, names))
+	}
+	for _, seat := range o.SeatAllergies {
+		parts = append(parts, fmt.Sprintf("%s %d: %s", labels[1], seat.Seat, allergenNames(seat.Allergies, names)))
+	}
+	banner := labels[0] + ": " + strings.Join(parts, "; ")
+
+	var conflicts []string
+	for _, item := range o.Items {
+		if len(item.AllergenConflicts) == 0 {
+			continue
+		}
+		name := item.Name
+		if item.Seat > 0 {
+			name = fmt.Sprintf("%s (%s %d)", name, labels[1], item.Seat)
+		}
+		conflicts = append(conflicts, name+": "+allergenNames(item.AllergenConflicts, names))
+	}
+	if len(conflicts) > 0 {
+		banner += ". " + labels[2] + ": " + strings.Join(conflicts, "; ")
+	}
+	return banner
+}
+
+// allergenNames lists the allergens by name, falling back to the code for unnamed ones
+func allergenNames(codes []string, names map[string]string) string {
+	list := make([]string, 0, len(codes))
+	for _, code := range codes {
+		name, ok := names[code]
+		if !ok {
+			name = code
+		}
+		list = append(list, name)
+	}
+	return strings.Join(list, ", ")
+}
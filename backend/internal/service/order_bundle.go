@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"restaurant-management/internal/domain/menu"
+	"restaurant-management/internal/domain/order"
+)
+
+// orderBundle prices an ordered bundle and expands it into the dishes chosen for its
+// slots, which go to the kitchen as ordinary items. Every slot must get exactly its
+// quantity of choices among the dishes it offers, and every choice must be on one of
+// the menus in offer.
+func (s *OrderService) orderBundle(ctx context.Context, input order.OrderBundleInput, offer menuOffer, businessID int) (*order.OrderBundle, error) {
+	if input.Quantity <= 0 || input.Seat < 0 {
+		return nil, order.ErrInvalidOrderData
+	}
+
+	b, err := s.menuService.GetBundleByID(ctx, input.BundleID, businessID)
+	if err != nil {
+		if err == menu.ErrBundleNotFound {
+			return nil, order.ErrBundleNotFound
+		}
+		return nil, err
+	}
+	if !b.IsAvailable {
+		return nil, order.ErrBundleNotAvailable
+	}
+
+	slots := make(map[int]menu.BundleSlot, len(b.Slots))
+	for _, slot := range b.Slots {
+		slots[slot.ID] = slot
+	}
+
+	chosen := make(map[int]int, len(b.Slots))
+	components := make([]order.OrderItem, 0, len(input.Choices))
+	listPrices := make([]float64, 0, len(input.Choices))
+	for _, choice := range input.Choices {
+		slot, ok := slots[choice.SlotID]
+		if !ok {
+			return nil, order.ErrInvalidBundleChoice
+		}
+
+		dish, err := s.repo.GetDishByID(ctx, choice.DishID)
+		if err != nil {
+			log.Printf("Error getting dish %d for bundle %d: %v", choice.DishID, b.ID, err)
+			return nil, order.ErrDishNotFound
+		}
+		if !slot.Offers(dish.ID, dish.CategoryID) {
+			return nil, order.ErrInvalidBundleChoice
+		}
+		if !dish.IsAvailable || !dish.IsVisible {
+			return nil, order.ErrDishNotAvailable
+		}
+		// A component follows the menu schedule like a dish ordered on its own
+		if !offer.offers(dish) {
+			return nil, order.ErrDishNotOnMenu
+		}
+
+		chosen[slot.ID]++
+		components = append(components, order.OrderItem{
+			DishID:   dish.ID,
+			Name:     dish.Name,
+			Quantity: input.Quantity,
+			Notes:    choice.Notes,
+			Seat:     input.Seat,
+		})
+		listPrices = append(listPrices, dish.Price)
+	}
+	for _, slot := range b.Slots {
+		if chosen[slot.ID] != slot.Quantity {
+			return nil, order.ErrInvalidBundleChoice
+		}
+	}
+
+	for i, share := range allocateBundlePrice(b.Price, listPrices) {
+		components[i].Price = share
+		components[i].Total = roundMoney(share * float64(input.Quantity))
+	}
+
+	return &order.OrderBundle{
+		BundleID: b.ID,
+		Name:     b.Name,
+		Quantity: input.Quantity,
+		Price:    b.Price,
+		Total:    roundMoney(b.Price * float64(input.Quantity)),
+		Seat:     input.Seat,
+		Notes:    input.Notes,
+		Items:    components,
+	}, nil
+}
+
+// allocateBundlePrice splits a bundle price over its components in proportion to their
+// list prices, so that sales reports credit each dish with its share of the revenue.
+// Shares are rounded down to the cent and the remainder goes to the last component,
+// so they always add up to the bundle price.
+func allocateBundlePrice(price float64, listPrices []float64) []float64 {
+	shares := make([]float64, len(listPrices))
+	if len(listPrices) == 0 {
+		return shares
+	}
+
+	weights := listPrices
+	var total float64
+	for _, p := range listPrices {
+		total += p
+	}
+	if total <= 0 {
+		// Nothing to weigh by: split evenly
+		weights = make([]float64, len(listPrices))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+
+	cents := int64(math.Round(price * 100))
+	remaining := cents
+	for i := range weights[:len(weights)-1] {
+		share := int64(math.Floor(float64(cents) * weights[i] / total))
+		shares[i] = float64(share) / 100
+		remaining -= share
+	}
+	shares[len(shares)-1] = float64(remaining) / 100
+	return shares
+}
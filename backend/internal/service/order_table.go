@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"log"
+	"restaurant-management/internal/domain/order"
+	"restaurant-management/internal/domain/table"
+	"time"
+)
+
+// occupyTable marks the table of a new order occupied. A reserved table keeps its
+// reservation time so the stay can be matched to the booking.
+func (s *OrderService) occupyTable(ctx context.Context, o *order.Order, changedBy int, businessID int) {
+	if s.tableRepo == nil || o.TableID == 0 {
+		return
+	}
+
+	t, err := s.tableRepo.GetBusinessTable(ctx, o.TableID, businessID)
+	if err != nil || t == nil {
+		log.Printf("Error loading table %d of order %d: %v", o.TableID, o.ID, err)
+		return
+	}
+	if t.Status == table.TableStatusOccupied {
+		return
+	}
+
+	var reservedAt *time.Time
+	if t.Status == table.TableStatusReserved {
+		reservedAt = t.ReservedAt
+	}
+	now := time.Now()
+	audit := table.StatusAudit{ChangedBy: changedBy}
+	if err := s.tableRepo.UpdateTableStatusWithTimes(ctx, t.ID, string(table.TableStatusOccupied), reservedAt, &now, audit); err != nil {
+		log.Printf("Error occupying table %d for order %d: %v", t.ID, o.ID, err)
+	}
+}
+
+// releaseTable sends an occupied table to cleaning once its last open order is paid.
+// When the last order was cancelled the table only goes to cleaning if something was
+// paid during the stay; otherwise the guests are likely still seated. Tables already
+// moved by hand are left alone.
+func (s *OrderService) releaseTable(ctx context.Context, o *order.Order, changedBy int, businessID int) {
+	if s.tableRepo == nil || o.TableID == 0 {
+		return
+	}
+
+	last, err := s.repo.IsLastActiveOrderForTable(ctx, o.TableID, o.ID)
+	if err != nil || !last {
+		return
+	}
+
+	t, err := s.tableRepo.GetBusinessTable(ctx, o.TableID, businessID)
+	if err != nil || t == nil {
+		log.Printf("Error loading table %d of order %d: %v", o.TableID, o.ID, err)
+		return
+	}
+	if t.Status != table.TableStatusOccupied {
+		return
+	}
+
+	if o.Status == order.OrderStatusCancelled {
+		since := o.CreatedAt
+		if t.OccupiedAt != nil {
+			since = *t.OccupiedAt
+		}
+		paid, err := s.tableRepo.HasCompletedOrdersSince(ctx, t.ID, since)
+		if err != nil || !paid {
+			return
+		}
+	}
+
+	audit := table.StatusAudit{ChangedBy: changedBy}
+	if err := s.tableRepo.UpdateTableStatusWithTimes(ctx, t.ID, string(table.TableStatusNeedsCleaning), nil, nil, audit); err != nil {
+		log.Printf("Error releasing table %d after order %d: %v", t.ID, o.ID, err)
+	}
+}
@@ -129,3 +129,22 @@ func (s *RequestService) Delete(ctx context.Context, id int, businessID int) err
 	log.Printf("Successfully deleted request %d for business %d", id, businessID)
 	return nil
 }
+
+func (s *RequestService) Approve(ctx context.Context, id int, businessID int) (*request.Request, error) {
+	existing, err := s.GetByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != request.StatusDraft {
+		return nil, request.ErrNotDraft
+	}
+
+	approved, err := s.requestRepo.Update(ctx, id, request.UpdateRequestRequest{Status: request.StatusPending}, businessID)
+	if err != nil {
+		log.Printf("Error approving request %d for business %d: %v", id, businessID, err)
+		return nil, err
+	}
+
+	log.Printf("Successfully approved request %d for business %d", id, businessID)
+	return approved, nil
+}
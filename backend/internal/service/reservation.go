@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/reservation"
+	"restaurant-management/internal/domain/table"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ReservationService struct {
+	repo            reservation.Repository
+	tableRepo       table.Repository
+	businessRepo    business.Repository
+	defaultDuration time.Duration
+	holdBefore      time.Duration
+	notifier        reservation.GuestNotifier
+}
+
+// NewReservationService creates the reservation service. Bookings without a duration last
+// defaultDuration; their tables are switched to reserved holdBefore the start. The notifier
+// tells waitlisted guests their table is ready and may be nil.
+func NewReservationService(
+	repo reservation.Repository,
+	tableRepo table.Repository,
+	businessRepo business.Repository,
+	defaultDuration time.Duration,
+	holdBefore time.Duration,
+	notifier reservation.GuestNotifier,
+) reservation.Service {
+	if defaultDuration <= 0 {
+		defaultDuration = reservation.DefaultDuration
+	}
+	return &ReservationService{
+		repo:            repo,
+		tableRepo:       tableRepo,
+		businessRepo:    businessRepo,
+		defaultDuration: defaultDuration,
+		holdBefore:      holdBefore,
+		notifier:        notifier,
+	}
+}
+
+func (s *ReservationService) GetBook(ctx context.Context, date string, businessID int) (*reservation.Book, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidReservation
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	day := time.Now().In(loc)
+	if date != "" {
+		parsed, err := time.ParseInLocation(menuDateLayout, date, loc)
+		if err != nil {
+			return nil, reservation.ErrInvalidReservation
+		}
+		day = parsed
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+
+	reservations, err := s.repo.GetReservations(ctx, from, from.AddDate(0, 0, 1), businessID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.repo.GetOpeningHours(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	book := &reservation.Book{
+		Date:         from.Format(menuDateLayout),
+		Reservations: reservations,
+		OpeningHours: []reservation.OpeningHours{},
+	}
+	for i, r := range reservations {
+		book.Reservations[i].StartsAt = r.StartsAt.In(loc)
+		book.Reservations[i].EndsAt = r.EndsAt.In(loc)
+		if r.Active() {
+			book.Covers += r.PartySize
+		}
+	}
+	for _, h := range hours {
+		if time.Weekday(h.DayOfWeek) == from.Weekday() {
+			book.OpeningHours = append(book.OpeningHours, h)
+		}
+	}
+	return book, nil
+}
+
+func (s *ReservationService) GetReservation(ctx context.Context, id int, businessID int) (*reservation.Reservation, error) {
+	if id <= 0 {
+		return nil, reservation.ErrReservationNotFound
+	}
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidReservation
+	}
+
+	r, err := s.repo.GetReservationByID(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, reservation.ErrReservationNotFound
+	}
+	return r, nil
+}
+
+func (s *ReservationService) CreateReservation(ctx context.Context, req reservation.ReservationCreate, businessID int) (*reservation.Reservation, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidReservation
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	startsAt, err := parseReservationTime(req.StartsAt, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	r := reservation.Reservation{
+		BusinessID: businessID,
+		GuestName:  req.GuestName,
+		Phone:      req.Phone,
+		PartySize:  req.PartySize,
+		StartsAt:   startsAt,
+		Duration:   req.Duration,
+		TableIDs:   req.TableIDs,
+		Notes:      req.Notes,
+	}
+	if err := s.validateReservation(ctx, &r, loc); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateReservation(ctx, r)
+}
+
+func (s *ReservationService) UpdateReservation(ctx context.Context, id int, req reservation.ReservationUpdate, businessID int) (*reservation.Reservation, error) {
+	r, err := s.GetReservation(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	// Seated, finished and cancelled reservations are history
+	if r.Status != reservation.StatusBooked {
+		return nil, reservation.ErrInvalidStatus
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	if req.GuestName != nil {
+		r.GuestName = *req.GuestName
+	}
+	if req.Phone != nil {
+		r.Phone = *req.Phone
+	}
+	if req.PartySize != nil {
+		r.PartySize = *req.PartySize
+	}
+	if req.StartsAt != nil {
+		if r.StartsAt, err = parseReservationTime(*req.StartsAt, loc); err != nil {
+			return nil, err
+		}
+	}
+	if req.Duration != nil {
+		r.Duration = *req.Duration
+	}
+	if req.TableIDs != nil {
+		r.TableIDs = *req.TableIDs
+	}
+	if req.Notes != nil {
+		r.Notes = *req.Notes
+	}
+	if err := s.validateReservation(ctx, r, loc); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateReservation(ctx, *r)
+}
+
+func (s *ReservationService) UpdateStatus(ctx context.Context, id int, req reservation.StatusUpdateRequest, businessID int) (*reservation.Reservation, error) {
+	r, err := s.GetReservation(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowedReservationTransition(r.Status, req.Status) {
+		return nil, reservation.ErrInvalidStatus
+	}
+
+	if err := s.repo.UpdateReservationStatus(ctx, id, req.Status, businessID); err != nil {
+		return nil, err
+	}
+
+	switch req.Status {
+	case reservation.StatusSeated:
+		now := time.Now()
+		startsAt := r.StartsAt
+		for _, tableID := range r.TableIDs {
+			audit := table.StatusAudit{ChangedBy: req.ChangedBy, PartySize: r.PartySize}
+			if err := s.tableRepo.UpdateTableStatusWithTimes(ctx, tableID, string(table.TableStatusOccupied), &startsAt, &now, audit); err != nil {
+				return nil, err
+			}
+		}
+	case reservation.StatusCancelled, reservation.StatusNoShow:
+		if err := s.repo.ReleaseTables(ctx, *r); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetReservation(ctx, id, businessID)
+}
+
+func (s *ReservationService) GetOpeningHours(ctx context.Context, businessID int) ([]reservation.OpeningHours, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidReservation
+	}
+
+	return s.repo.GetOpeningHours(ctx, businessID)
+}
+
+func (s *ReservationService) SetOpeningHours(ctx context.Context, hours []reservation.OpeningHours, businessID int) ([]reservation.OpeningHours, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidReservation
+	}
+
+	for _, h := range hours {
+		if h.DayOfWeek < 0 || h.DayOfWeek > 6 {
+			return nil, reservation.ErrInvalidOpeningHours
+		}
+		if _, err := time.Parse(menuClockLayout, h.OpensAt); err != nil {
+			return nil, reservation.ErrInvalidOpeningHours
+		}
+		if _, err := time.Parse(menuClockLayout, h.ClosesAt); err != nil {
+			return nil, reservation.ErrInvalidOpeningHours
+		}
+	}
+
+	if err := s.repo.SetOpeningHours(ctx, hours, businessID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOpeningHours(ctx, businessID)
+}
+
+func (s *ReservationService) HoldUpcomingTables(ctx context.Context) (int, error) {
+	return s.repo.HoldTables(ctx, time.Now(), s.holdBefore)
+}
+
+// validateReservation normalises a reservation and checks it against the assigned tables and
+// the opening hours. Conflicts with other bookings are checked when it is saved.
+func (s *ReservationService) validateReservation(ctx context.Context, r *reservation.Reservation, loc *time.Location) error {
+	r.GuestName = strings.TrimSpace(r.GuestName)
+	r.Phone = strings.TrimSpace(r.Phone)
+	r.Notes = strings.TrimSpace(r.Notes)
+	if r.GuestName == "" || r.Phone == "" || r.PartySize <= 0 {
+		return reservation.ErrInvalidReservation
+	}
+
+	duration := time.Duration(r.Duration) * time.Minute
+	if r.Duration == 0 {
+		duration = s.defaultDuration
+	}
+	if duration <= 0 || duration > 24*time.Hour {
+		return reservation.ErrInvalidReservation
+	}
+	r.Duration = int(duration / time.Minute)
+	r.EndsAt = r.StartsAt.Add(duration)
+	if !r.EndsAt.After(time.Now()) {
+		return reservation.ErrInvalidReservation
+	}
+
+	seen := make(map[int]bool, len(r.TableIDs))
+	tableIDs := []int{}
+	seats := 0
+	for _, tableID := range r.TableIDs {
+		if seen[tableID] {
+			continue
+		}
+		seen[tableID] = true
+
+		t, err := s.tableRepo.GetBusinessTable(ctx, tableID, r.BusinessID)
+		if err == table.ErrTableNotFound {
+			return reservation.ErrTableNotFound
+		}
+		if err != nil {
+			return err
+		}
+		seats += t.Seats
+		tableIDs = append(tableIDs, tableID)
+	}
+	sort.Ints(tableIDs)
+	r.TableIDs = tableIDs
+	if len(tableIDs) > 0 && seats < r.PartySize {
+		return reservation.ErrNotEnoughSeats
+	}
+
+	hours, err := s.repo.GetOpeningHours(ctx, r.BusinessID)
+	if err != nil {
+		return err
+	}
+	if len(hours) > 0 && !withinOpeningHours(hours, r.StartsAt.In(loc), r.EndsAt.In(loc)) {
+		return reservation.ErrOutsideOpeningHours
+	}
+	return nil
+}
+
+// parseReservationTime accepts RFC 3339 or a local YYYY-MM-DDTHH:MM time of the business
+func parseReservationTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(menuPreviewLayout, value, loc)
+	if err != nil {
+		return time.Time{}, reservation.ErrInvalidReservation
+	}
+	return t, nil
+}
+
+// withinOpeningHours reports whether [start, end] lies in a single opening period. A period
+// that runs past midnight belongs to the day it opened, so the day before start is checked too.
+func withinOpeningHours(hours []reservation.OpeningHours, start, end time.Time) bool {
+	for _, h := range hours {
+		for _, day := range []time.Time{start.AddDate(0, 0, -1), start} {
+			if time.Weekday(h.DayOfWeek) != day.Weekday() {
+				continue
+			}
+			midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, start.Location())
+			opens := midnight.Add(time.Duration(clockMinutes(h.OpensAt)) * time.Minute)
+			closes := midnight.Add(time.Duration(clockMinutes(h.ClosesAt)) * time.Minute)
+			if !closes.After(opens) {
+				closes = closes.AddDate(0, 0, 1)
+			}
+			if !start.Before(opens) && !end.After(closes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func allowedReservationTransition(from, to string) bool {
+	switch from {
+	case reservation.StatusBooked:
+		return to == reservation.StatusSeated || to == reservation.StatusCancelled || to == reservation.StatusNoShow
+	case reservation.StatusSeated:
+		return to == reservation.StatusCompleted
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"restaurant-management/internal/domain/reservation"
+	"restaurant-management/internal/domain/table"
+	"strings"
+	"time"
+)
+
+// turnTimeHistory is how far back turn times are learned from
+const turnTimeHistory = 90 * 24 * time.Hour
+
+func (s *ReservationService) GetWaitlist(ctx context.Context, businessID int) ([]reservation.WaitlistEntry, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidWaitlistEntry
+	}
+
+	entries, err := s.repo.GetWaitlist(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.estimateWaits(ctx, entries, businessID); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *ReservationService) AddToWaitlist(ctx context.Context, req reservation.WaitlistCreate, businessID int) (*reservation.WaitlistEntry, error) {
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidWaitlistEntry
+	}
+
+	entry := reservation.WaitlistEntry{
+		BusinessID: businessID,
+		GuestName:  strings.TrimSpace(req.GuestName),
+		Phone:      strings.TrimSpace(req.Phone),
+		PartySize:  req.PartySize,
+		Notes:      strings.TrimSpace(req.Notes),
+	}
+	if entry.GuestName == "" || entry.PartySize <= 0 {
+		return nil, reservation.ErrInvalidWaitlistEntry
+	}
+
+	created, err := s.repo.CreateWaitlistEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return s.waitlistEntry(ctx, created.ID, businessID)
+}
+
+func (s *ReservationService) NotifyWaitlistEntry(ctx context.Context, id int, businessID int) (*reservation.WaitlistEntry, error) {
+	entry, err := s.waitlistEntry(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Waiting() {
+		return nil, reservation.ErrInvalidStatus
+	}
+
+	// Without a notifier the host calls the guest and this only records it
+	if s.notifier != nil && entry.Phone != "" {
+		if err := s.notifier.NotifyTableReady(ctx, *entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateWaitlistStatus(ctx, id, reservation.WaitlistNotified, nil, businessID); err != nil {
+		return nil, err
+	}
+	return s.waitlistEntry(ctx, id, businessID)
+}
+
+func (s *ReservationService) SeatWaitlistEntry(ctx context.Context, id int, req reservation.SeatRequest, businessID int) (*reservation.WaitlistEntry, error) {
+	entry, err := s.waitlistEntry(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Waiting() {
+		return nil, reservation.ErrInvalidStatus
+	}
+	if len(req.TableIDs) == 0 {
+		return nil, reservation.ErrInvalidWaitlistEntry
+	}
+
+	for _, tableID := range req.TableIDs {
+		if _, err := s.tableRepo.GetBusinessTable(ctx, tableID, businessID); err != nil {
+			if err == table.ErrTableNotFound {
+				return nil, reservation.ErrTableNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateWaitlistStatus(ctx, id, reservation.WaitlistSeated, req.TableIDs, businessID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	audit := table.StatusAudit{ChangedBy: req.ChangedBy, PartySize: entry.PartySize}
+	for _, tableID := range req.TableIDs {
+		if err := s.tableRepo.UpdateTableStatusWithTimes(ctx, tableID, string(table.TableStatusOccupied), nil, &now, audit); err != nil {
+			return nil, err
+		}
+	}
+	return s.waitlistEntry(ctx, id, businessID)
+}
+
+func (s *ReservationService) CloseWaitlistEntry(ctx context.Context, id int, status string, businessID int) (*reservation.WaitlistEntry, error) {
+	if status != reservation.WaitlistNoShow && status != reservation.WaitlistLeft {
+		return nil, reservation.ErrInvalidStatus
+	}
+
+	entry, err := s.waitlistEntry(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Waiting() {
+		return nil, reservation.ErrInvalidStatus
+	}
+
+	if err := s.repo.UpdateWaitlistStatus(ctx, id, status, nil, businessID); err != nil {
+		return nil, err
+	}
+	return s.waitlistEntry(ctx, id, businessID)
+}
+
+// waitlistEntry returns an entry, with its queue position and estimate while it is waiting
+func (s *ReservationService) waitlistEntry(ctx context.Context, id int, businessID int) (*reservation.WaitlistEntry, error) {
+	if id <= 0 {
+		return nil, reservation.ErrWaitlistEntryNotFound
+	}
+	if businessID <= 0 {
+		return nil, reservation.ErrInvalidWaitlistEntry
+	}
+
+	entry, err := s.repo.GetWaitlistEntry(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, reservation.ErrWaitlistEntryNotFound
+	}
+	if !entry.Waiting() {
+		return entry, nil
+	}
+
+	waiting, err := s.GetWaitlist(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range waiting {
+		if e.ID == id {
+			return &e, nil
+		}
+	}
+	return entry, nil
+}
+
+// estimateWaits fills in the queue position and estimated wait of each waiting party
+func (s *ReservationService) estimateWaits(ctx context.Context, entries []reservation.WaitlistEntry, businessID int) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	tables, err := s.tableRepo.GetAllTables(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	turns, err := s.repo.GetTurnTimes(ctx, now.Add(-turnTimeHistory), businessID)
+	if err != nil {
+		return err
+	}
+	// Reservations that started up to a day ago may still hold their tables
+	upcoming, err := s.repo.GetReservations(ctx, now.Add(-24*time.Hour), now.Add(24*time.Hour), businessID)
+	if err != nil {
+		return err
+	}
+
+	estimateWaits(entries, tables, upcoming, turns, now)
+	return nil
+}
+
+// estimateWaits simulates the queue: each party in turn takes the single table that seats it
+// and frees up first, which then stays busy for its turn time. When a table frees up comes
+// from its occupancy and the bookings on it.
+func estimateWaits(entries []reservation.WaitlistEntry, tables []table.Table, reservations []reservation.Reservation, turns map[int]time.Duration, now time.Time) {
+	freeAt := make([]time.Time, len(tables))
+	for i, t := range tables {
+		freeAt[i] = tableFreeAt(t, reservations, turnTime(turns, t.Seats), now)
+	}
+
+	for i := range entries {
+		entries[i].Position = i + 1
+
+		best := -1
+		for j, t := range tables {
+			if t.Seats >= entries[i].PartySize && (best < 0 || freeAt[j].Before(freeAt[best])) {
+				best = j
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		wait := int(freeAt[best].Sub(now) / time.Minute)
+		entries[i].EstimatedWait = &wait
+		turn := turnTime(turns, tables[best].Seats)
+		freeAt[best] = bookedUntil(tables[best].ID, freeAt[best].Add(turn), turn, reservations)
+	}
+}
+
+// tableFreeAt estimates when a table can next take a walk-in party
+func tableFreeAt(t table.Table, reservations []reservation.Reservation, turn time.Duration, now time.Time) time.Time {
+	freeAt := now
+	switch t.Status {
+	case table.TableStatusOccupied:
+		if t.OccupiedAt != nil && t.OccupiedAt.Add(turn).After(now) {
+			freeAt = t.OccupiedAt.Add(turn)
+		}
+	case table.TableStatusReserved:
+		if t.ReservedAt != nil && t.ReservedAt.Add(turn).After(now) {
+			freeAt = t.ReservedAt.Add(turn)
+		}
+	}
+	return bookedUntil(t.ID, freeAt, turn, reservations)
+}
+
+// bookedUntil moves a walk-in seating at a table past every active booking it would run into
+func bookedUntil(tableID int, from time.Time, turn time.Duration, reservations []reservation.Reservation) time.Time {
+	for moved := true; moved; {
+		moved = false
+		for _, r := range reservations {
+			if !r.Active() || !r.EndsAt.After(from) || !r.StartsAt.Before(from.Add(turn)) {
+				continue
+			}
+			for _, id := range r.TableIDs {
+				if id == tableID {
+					from = r.EndsAt
+					moved = true
+					break
+				}
+			}
+		}
+	}
+	return from
+}
+
+// turnTime returns the learned turn time of tables with the given seats, else of the closest
+// larger tables, else the default
+func turnTime(turns map[int]time.Duration, seats int) time.Duration {
+	if turn, ok := turns[seats]; ok {
+		return turn
+	}
+	best := 0
+	for s := range turns {
+		if s > seats && (best == 0 || s < best) {
+			best = s
+		}
+	}
+	if best > 0 {
+		return turns[best]
+	}
+	return reservation.DefaultTurnTime
+}
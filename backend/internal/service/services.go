@@ -2,16 +2,20 @@ package service
 
 import (
 	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/guest"
 	"restaurant-management/internal/domain/inventory"
+	"restaurant-management/internal/domain/media"
 	"restaurant-management/internal/domain/menu"
 	"restaurant-management/internal/domain/notification"
 	"restaurant-management/internal/domain/order"
 	"restaurant-management/internal/domain/request"
+	"restaurant-management/internal/domain/reservation"
 	"restaurant-management/internal/domain/shift"
 	"restaurant-management/internal/domain/supplier"
 	"restaurant-management/internal/domain/table"
 	"restaurant-management/internal/domain/user"
 	"restaurant-management/internal/domain/waiter"
+	"time"
 )
 
 // Services contains all application services
@@ -27,6 +31,9 @@ type Services struct {
 	Request      request.Service
 	Waiter       waiter.Service
 	Notification notification.Service
+	Media        media.Service
+	Guest        guest.Service
+	Reservation  reservation.Service
 }
 
 // NewServices creates a new instance of Services with all dependencies
@@ -42,23 +49,42 @@ func NewServices(
 	requestRepo request.Repository,
 	waiterRepo waiter.Repository,
 	notificationRepo notification.Repository,
+	reservationRepo reservation.Repository,
 	emailService notification.EmailService,
+	mediaService media.Service,
 	jwtKey string,
+	publicBaseURL string,
+	guestSessionTTL time.Duration,
+	reservationDuration time.Duration,
+	reservationHoldBefore time.Duration,
+	guestNotifier reservation.GuestNotifier,
 ) *Services {
 	// Initialize user service first since notification service depends on it
 	userService := NewUserService(userRepo, jwtKey)
 
+	// Completed orders take their ingredients out of stock, and recipes convert
+	// their quantities to the stock unit
+	inventoryService := NewInventoryService(inventoryRepo, businessRepo, mediaService)
+
+	// Initialize menu service before order service, which checks the active menu
+	menuService := NewMenuService(menuRepo, businessRepo, mediaService, inventoryService)
+
+	orderService := NewOrderService(orderRepo, menuService, businessRepo, tableRepo, inventoryService)
+
 	return &Services{
-		Business:     NewBusinessService(businessRepo),
+		Business:     NewBusinessService(businessRepo, mediaService),
 		User:         userService,
-		Menu:         NewMenuService(menuRepo),
-		Order:        NewOrderService(orderRepo),
-		Table:        NewTableService(tableRepo),
-		Inventory:    NewInventoryService(inventoryRepo),
+		Menu:         menuService,
+		Order:        orderService,
+		Table:        NewTableService(tableRepo, businessRepo, reservationRepo),
+		Inventory:    inventoryService,
 		Shift:        NewShiftService(shiftRepo),
 		Supplier:     NewSupplierService(supplierRepo),
 		Request:      NewRequestService(requestRepo),
 		Waiter:       NewWaiterService(waiterRepo),
 		Notification: NewNotificationService(notificationRepo, emailService, userService),
+		Media:        mediaService,
+		Guest:        NewGuestService(tableRepo, businessRepo, menuService, orderService, jwtKey, publicBaseURL, guestSessionTTL),
+		Reservation:  NewReservationService(reservationRepo, tableRepo, businessRepo, reservationDuration, reservationHoldBefore, guestNotifier),
 	}
 }
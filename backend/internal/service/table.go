@@ -2,16 +2,20 @@ package service
 
 import (
 	"context"
+	"restaurant-management/internal/domain/business"
+	"restaurant-management/internal/domain/reservation"
 	"restaurant-management/internal/domain/table"
 	"time"
 )
 
 type TableService struct {
-	repo table.Repository
+	repo            table.Repository
+	businessRepo    business.Repository
+	reservationRepo reservation.Repository // opening hours for utilisation reports
 }
 
-func NewTableService(repo table.Repository) table.Service {
-	return &TableService{repo: repo}
+func NewTableService(repo table.Repository, businessRepo business.Repository, reservationRepo reservation.Repository) table.Service {
+	return &TableService{repo: repo, businessRepo: businessRepo, reservationRepo: reservationRepo}
 }
 
 func (s *TableService) GetTables(ctx context.Context, businessID int) ([]table.Table, error) {
@@ -40,9 +44,10 @@ func (s *TableService) UpdateTableStatus(ctx context.Context, tableID int, req t
 
 	// Validate status
 	validStatuses := map[string]bool{
-		"free":     true,
-		"occupied": true,
-		"reserved": true,
+		"free":           true,
+		"occupied":       true,
+		"reserved":       true,
+		"needs_cleaning": true,
 	}
 	if !validStatuses[req.Status] {
 		return table.ErrInvalidTableData
@@ -55,8 +60,8 @@ func (s *TableService) UpdateTableStatus(ctx context.Context, tableID int, req t
 	}
 
 	// Business rules for status transitions
-	if req.Status == "free" {
-		// Check if table has active orders before marking as free
+	if req.Status == "free" || req.Status == "needs_cleaning" {
+		// Check if table has active orders before marking as free or waiting for cleaning
 		hasActiveOrders, err := s.repo.TableHasActiveOrders(ctx, tableID)
 		if err != nil {
 			return err
@@ -81,13 +86,17 @@ func (s *TableService) UpdateTableStatus(ctx context.Context, tableID int, req t
 		reservedAt = &now
 		// Clear occupied_at when reserving (future reservation)
 		occupiedAt = nil
-	case table.TableStatusFree:
-		// Clear both timestamps when table becomes free
+	case table.TableStatusFree, table.TableStatusNeedsCleaning:
+		// Clear both timestamps when the guests have left
 		reservedAt = nil
 		occupiedAt = nil
 	}
 
-	return s.repo.UpdateTableStatusWithTimes(ctx, tableID, req.Status, reservedAt, occupiedAt)
+	audit := table.StatusAudit{ChangedBy: req.ChangedBy}
+	if table.TableStatus(req.Status) == table.TableStatusOccupied && req.PartySize > 0 {
+		audit.PartySize = req.PartySize
+	}
+	return s.repo.UpdateTableStatusWithTimes(ctx, tableID, req.Status, reservedAt, occupiedAt, audit)
 }
 
 func (s *TableService) GetTableStats(ctx context.Context, businessID int) (*table.TableStats, error) {
@@ -97,3 +106,7 @@ func (s *TableService) GetTableStats(ctx context.Context, businessID int) (*tabl
 
 	return s.repo.GetTableStats(ctx, businessID)
 }
+
+func (s *TableService) FreeCleanedTables(ctx context.Context, now time.Time) (int, error) {
+	return s.repo.FreeCleanedTables(ctx, now)
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/table"
+	"strings"
+)
+
+func (s *TableService) CreateTable(ctx context.Context, t table.TableCreate, businessID int) (*table.Table, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Shape == "" {
+		t.Shape = table.TableShapeSquare
+	}
+	if t.Width == 0 {
+		t.Width = table.DefaultTableWidth
+	}
+	if t.Height == 0 {
+		t.Height = table.DefaultTableHeight
+	}
+	t.Rotation = normalizeRotation(t.Rotation)
+	if t.Number <= 0 || t.Seats <= 0 || !validTablePlacement(t.Shape, t.X, t.Y, t.Width, t.Height) {
+		return nil, table.ErrInvalidTableData
+	}
+
+	if err := s.checkTableNumber(ctx, 0, t.Number, businessID); err != nil {
+		return nil, err
+	}
+	if err := s.checkZone(ctx, t.ZoneID, businessID); err != nil {
+		return nil, err
+	}
+
+	t.BusinessID = businessID
+	return s.repo.CreateTable(ctx, t)
+}
+
+func (s *TableService) UpdateTable(ctx context.Context, id int, t table.TableUpdate, businessID int) (*table.Table, error) {
+	if id <= 0 {
+		return nil, table.ErrTableNotFound
+	}
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	existing, err := s.repo.GetBusinessTable(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the table as it will be after the update
+	shape, x, y, width, height := existing.Shape, existing.X, existing.Y, existing.Width, existing.Height
+	if t.Shape != nil {
+		shape = *t.Shape
+	}
+	if t.X != nil {
+		x = *t.X
+	}
+	if t.Y != nil {
+		y = *t.Y
+	}
+	if t.Width != nil {
+		width = *t.Width
+	}
+	if t.Height != nil {
+		height = *t.Height
+	}
+	if !validTablePlacement(shape, x, y, width, height) {
+		return nil, table.ErrInvalidTableData
+	}
+	if t.Rotation != nil {
+		rotation := normalizeRotation(*t.Rotation)
+		t.Rotation = &rotation
+	}
+	if t.Name != nil {
+		name := strings.TrimSpace(*t.Name)
+		t.Name = &name
+	}
+	if t.Seats != nil && *t.Seats <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	if t.Number != nil {
+		if *t.Number <= 0 {
+			return nil, table.ErrInvalidTableData
+		}
+		if err := s.checkTableNumber(ctx, id, *t.Number, businessID); err != nil {
+			return nil, err
+		}
+	}
+	if t.ZoneID != nil {
+		if err := s.checkZone(ctx, *t.ZoneID, businessID); err != nil {
+			return nil, err
+		}
+	}
+
+	t.BusinessID = businessID
+	return s.repo.UpdateTable(ctx, id, t)
+}
+
+func (s *TableService) DeleteTable(ctx context.Context, id int, businessID int) error {
+	if id <= 0 {
+		return table.ErrTableNotFound
+	}
+	if businessID <= 0 {
+		return table.ErrInvalidTableData
+	}
+
+	if _, err := s.repo.GetBusinessTable(ctx, id, businessID); err != nil {
+		return err
+	}
+
+	hasActiveOrders, err := s.repo.TableHasActiveOrders(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasActiveOrders {
+		return table.ErrTableHasActiveOrders
+	}
+
+	return s.repo.DeleteTable(ctx, id, businessID)
+}
+
+func (s *TableService) GetZones(ctx context.Context, businessID int) ([]table.Zone, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	return s.repo.GetZones(ctx, businessID)
+}
+
+func (s *TableService) CreateZone(ctx context.Context, z table.ZoneCreate, businessID int) (*table.Zone, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	z.Name = strings.TrimSpace(z.Name)
+	if z.Name == "" || z.Width < 0 || z.Height < 0 {
+		return nil, table.ErrInvalidZoneData
+	}
+
+	z.BusinessID = businessID
+	return s.repo.CreateZone(ctx, z)
+}
+
+func (s *TableService) UpdateZone(ctx context.Context, id int, z table.ZoneUpdate, businessID int) (*table.Zone, error) {
+	if id <= 0 {
+		return nil, table.ErrZoneNotFound
+	}
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	if z.Name != nil {
+		name := strings.TrimSpace(*z.Name)
+		if name == "" {
+			return nil, table.ErrInvalidZoneData
+		}
+		z.Name = &name
+	}
+	if (z.Width != nil && *z.Width < 0) || (z.Height != nil && *z.Height < 0) {
+		return nil, table.ErrInvalidZoneData
+	}
+
+	z.BusinessID = businessID
+	return s.repo.UpdateZone(ctx, id, z)
+}
+
+func (s *TableService) DeleteZone(ctx context.Context, id int, businessID int) error {
+	if id <= 0 {
+		return table.ErrZoneNotFound
+	}
+	if businessID <= 0 {
+		return table.ErrInvalidTableData
+	}
+
+	return s.repo.DeleteZone(ctx, id, businessID)
+}
+
+func (s *TableService) GetFloorPlan(ctx context.Context, businessID int) (*table.FloorPlan, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	zones, err := s.repo.GetZones(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := s.repo.GetAllTables(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneIndex := make(map[int]int, len(zones))
+	for i, zone := range zones {
+		zoneIndex[zone.ID] = i
+		zones[i].Tables = []table.Table{}
+	}
+
+	plan := &table.FloorPlan{BusinessID: businessID, Zones: zones, Tables: []table.Table{}}
+	for _, t := range tables {
+		if i, ok := zoneIndex[t.ZoneID]; ok {
+			plan.Zones[i].Tables = append(plan.Zones[i].Tables, t)
+		} else {
+			plan.Tables = append(plan.Tables, t)
+		}
+	}
+	return plan, nil
+}
+
+// checkTableNumber makes sure no other table of the business uses the number
+func (s *TableService) checkTableNumber(ctx context.Context, id, number, businessID int) error {
+	tables, err := s.repo.GetAllTables(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		if t.Number == number && t.ID != id {
+			return table.ErrTableNumberTaken
+		}
+	}
+	return nil
+}
+
+// checkZone makes sure a table is placed in a zone of the business; 0 means no zone
+func (s *TableService) checkZone(ctx context.Context, zoneID, businessID int) error {
+	if zoneID < 0 {
+		return table.ErrZoneNotFound
+	}
+	if zoneID == 0 {
+		return nil
+	}
+
+	zone, err := s.repo.GetZoneByID(ctx, zoneID, businessID)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return table.ErrZoneNotFound
+	}
+	return nil
+}
+
+func validTablePlacement(shape table.TableShape, x, y, width, height float64) bool {
+	switch shape {
+	case table.TableShapeRound, table.TableShapeSquare, table.TableShapeRectangle:
+	default:
+		return false
+	}
+	return x >= 0 && y >= 0 && width > 0 && height > 0
+}
+
+// normalizeRotation maps any angle to [0, 360)
+func normalizeRotation(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-management/internal/domain/reservation"
+	"restaurant-management/internal/domain/table"
+	"sort"
+	"time"
+)
+
+const defaultTurnTimeDays = 30
+
+func (s *TableService) GetStatusHistory(ctx context.Context, query table.StatusHistoryQuery, businessID int) ([]table.StatusChange, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+	if query.TableID < 0 || query.Limit < 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	filter := table.StatusHistoryFilter{TableID: query.TableID, Limit: query.Limit}
+	if filter.Limit == 0 {
+		filter.Limit = table.DefaultHistoryLimit
+	}
+	if filter.Limit > table.MaxHistoryLimit {
+		filter.Limit = table.MaxHistoryLimit
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	if query.From != "" {
+		from, err := time.ParseInLocation(menuDateLayout, query.From, loc)
+		if err != nil {
+			return nil, table.ErrInvalidReportPeriod
+		}
+		filter.From = from
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation(menuDateLayout, query.To, loc)
+		if err != nil {
+			return nil, table.ErrInvalidReportPeriod
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, table.ErrInvalidReportPeriod
+	}
+
+	history, err := s.repo.GetStatusHistory(ctx, filter, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []table.StatusChange{}
+	}
+	return history, nil
+}
+
+// GetTurnTimeReport builds turn and dwell times from the status history. A turn is one
+// stay at a table, from the moment it became occupied to its next status change.
+func (s *TableService) GetTurnTimeReport(ctx context.Context, query table.TurnTimeQuery, businessID int) (*table.TurnTimeReport, error) {
+	if businessID <= 0 {
+		return nil, table.ErrInvalidTableData
+	}
+
+	loc := businessLocation(ctx, s.businessRepo, businessID)
+	from, to, err := parsePeriod(query.From, query.To, defaultTurnTimeDays, loc)
+	if err != nil {
+		return nil, table.ErrInvalidReportPeriod
+	}
+
+	tables, err := s.repo.GetAllTables(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	occupancies, err := s.repo.GetOccupancies(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+	revenue, err := s.repo.GetTableRevenue(ctx, from, to, businessID)
+	if err != nil {
+		return nil, err
+	}
+	var hours []reservation.OpeningHours
+	if s.reservationRepo != nil {
+		if hours, err = s.reservationRepo.GetOpeningHours(ctx, businessID); err != nil {
+			return nil, err
+		}
+	}
+	openHours := openMinutes(hours, from, to) / 60
+
+	report := &table.TurnTimeReport{
+		From:       from,
+		To:         to,
+		OpenHours:  round2(openHours),
+		DwellTimes: []table.DwellTime{},
+		Tables:     make([]table.TableUtilisation, 0, len(tables)),
+	}
+
+	byTable := make(map[int][]table.Occupancy)
+	type dwellKey struct {
+		partySize int
+		dayPart   string
+	}
+	dwell := make(map[dwellKey][]float64)
+	var totalMinutes float64
+	for _, o := range occupancies {
+		minutes := o.End.Sub(o.Start).Minutes()
+		byTable[o.TableID] = append(byTable[o.TableID], o)
+		key := dwellKey{o.PartySize, dayPart(o.Start.In(loc))}
+		dwell[key] = append(dwell[key], minutes)
+		totalMinutes += minutes
+	}
+
+	var seats int
+	var occupiedMinutes float64
+	for _, t := range tables {
+		u := table.TableUtilisation{TableID: t.ID, Number: t.Number, Seats: t.Seats, Revenue: roundMoney(revenue[t.ID])}
+		for _, o := range byTable[t.ID] {
+			u.Turns++
+			u.OccupiedMinutes += o.End.Sub(o.Start).Minutes()
+		}
+		if u.Turns > 0 {
+			u.AverageTurnMinutes = round2(u.OccupiedMinutes / float64(u.Turns))
+		}
+		if openHours > 0 {
+			u.Utilisation = round2(math.Min(u.OccupiedMinutes/(openHours*60), 1))
+			if t.Seats > 0 {
+				u.RevenuePerSeatHour = roundMoney(revenue[t.ID] / (float64(t.Seats) * openHours))
+			}
+		}
+		occupiedMinutes += u.OccupiedMinutes
+		u.OccupiedMinutes = round2(u.OccupiedMinutes)
+
+		seats += t.Seats
+		report.Turns += u.Turns
+		report.Revenue += revenue[t.ID]
+		report.Tables = append(report.Tables, u)
+	}
+
+	if len(occupancies) > 0 {
+		report.AverageTurnMinutes = round2(totalMinutes / float64(len(occupancies)))
+	}
+	if openHours > 0 && len(tables) > 0 {
+		report.Utilisation = round2(math.Min(occupiedMinutes/(openHours*60*float64(len(tables))), 1))
+	}
+	report.SeatHours = round2(float64(seats) * openHours)
+	if report.SeatHours > 0 {
+		report.RevenuePerSeatHour = roundMoney(report.Revenue / (float64(seats) * openHours))
+	}
+	report.Revenue = roundMoney(report.Revenue)
+
+	for key, stays := range dwell {
+		var sum float64
+		for _, minutes := range stays {
+			sum += minutes
+		}
+		report.DwellTimes = append(report.DwellTimes, table.DwellTime{
+			PartySize:      key.partySize,
+			DayPart:        key.dayPart,
+			Turns:          len(stays),
+			AverageMinutes: round2(sum / float64(len(stays))),
+		})
+	}
+	sort.Slice(report.DwellTimes, func(i, j int) bool {
+		a, b := report.DwellTimes[i], report.DwellTimes[j]
+		if a.PartySize != b.PartySize {
+			return a.PartySize < b.PartySize
+		}
+		return dayPartOrder[a.DayPart] < dayPartOrder[b.DayPart]
+	})
+
+	return report, nil
+}
+
+var dayPartOrder = map[string]int{
+	table.DayPartBreakfast: 0,
+	table.DayPartLunch:     1,
+	table.DayPartDinner:    2,
+	table.DayPartLate:      3,
+}
+
+func dayPart(seated time.Time) string {
+	switch hour := seated.Hour(); {
+	case hour >= 5 && hour < 11:
+		return table.DayPartBreakfast
+	case hour >= 11 && hour < 16:
+		return table.DayPartLunch
+	case hour >= 16 && hour < 22:
+		return table.DayPartDinner
+	default:
+		return table.DayPartLate
+	}
+}
+
+// openMinutes counts the minutes of [from, to) inside the opening hours. Without
+// opening hours the business counts as always open.
+func openMinutes(hours []reservation.OpeningHours, from, to time.Time) float64 {
+	if len(hours) == 0 {
+		return to.Sub(from).Minutes()
+	}
+
+	var total float64
+	// Start a day early for periods that run past midnight into the first day
+	for day := from.AddDate(0, 0, -1); day.Before(to); day = day.AddDate(0, 0, 1) {
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, from.Location())
+		for _, h := range hours {
+			if time.Weekday(h.DayOfWeek) != day.Weekday() {
+				continue
+			}
+			opens := midnight.Add(time.Duration(clockMinutes(h.OpensAt)) * time.Minute)
+			closes := midnight.Add(time.Duration(clockMinutes(h.ClosesAt)) * time.Minute)
+			if !closes.After(opens) {
+				closes = closes.AddDate(0, 0, 1)
+			}
+			if opens.Before(from) {
+				opens = from
+			}
+			if closes.After(to) {
+				closes = to
+			}
+			if closes.After(opens) {
+				total += closes.Sub(opens).Minutes()
+			}
+		}
+	}
+	return total
+}
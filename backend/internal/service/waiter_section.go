@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-management/internal/domain/waiter"
+)
+
+func (s *WaiterService) GetSections(ctx context.Context, shiftID int, businessID int) ([]waiter.Section, error) {
+	if shiftID <= 0 {
+		return nil, waiter.ErrInvalidSection
+	}
+	if businessID <= 0 {
+		return nil, fmt.Errorf("invalid business ID: %d", businessID)
+	}
+
+	sections, err := s.waiterRepo.GetSections(ctx, shiftID, businessID)
+	if err != nil {
+		log.Printf("Error retrieving sections for shift %d: %v", shiftID, err)
+		return nil, err
+	}
+
+	if sections == nil {
+		sections = []waiter.Section{}
+	}
+
+	return sections, nil
+}
+
+// SetSection replaces the tables and zones a waiter covers during a shift.
+// An empty section takes the waiter off the floor plan for that shift.
+func (s *WaiterService) SetSection(ctx context.Context, section waiter.Section, businessID int) (*waiter.Section, error) {
+	if section.ShiftID <= 0 || section.WaiterID <= 0 {
+		return nil, waiter.ErrInvalidSection
+	}
+	if businessID <= 0 {
+		return nil, fmt.Errorf("invalid business ID: %d", businessID)
+	}
+
+	var err error
+	if section.TableIDs, err = uniqueSectionIDs(section.TableIDs); err != nil {
+		return nil, err
+	}
+	if section.ZoneIDs, err = uniqueSectionIDs(section.ZoneIDs); err != nil {
+		return nil, err
+	}
+
+	onShift, err := s.waiterRepo.IsOnShift(ctx, section.ShiftID, section.WaiterID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if !onShift {
+		return nil, waiter.ErrWaiterNotOnShift
+	}
+
+	sections, err := s.waiterRepo.GetSections(ctx, section.ShiftID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range sections {
+		if other.WaiterID == section.WaiterID {
+			continue
+		}
+		if sectionOverlaps(other.TableIDs, section.TableIDs) || sectionOverlaps(other.ZoneIDs, section.ZoneIDs) {
+			return nil, waiter.ErrSectionConflict
+		}
+	}
+
+	if err := s.waiterRepo.ReplaceSection(ctx, section, businessID); err != nil {
+		log.Printf("Error setting section of waiter %d for shift %d: %v", section.WaiterID, section.ShiftID, err)
+		return nil, err
+	}
+
+	return &section, nil
+}
+
+// CheckTableSection returns the owner of the table when it is in another
+// waiter's current section, and nil when the waiter may serve it freely
+func (s *WaiterService) CheckTableSection(ctx context.Context, tableID int, waiterID int, businessID int) (*waiter.SectionOwner, error) {
+	if tableID <= 0 || waiterID <= 0 {
+		return nil, nil
+	}
+
+	owner, err := s.waiterRepo.GetTableOwner(ctx, tableID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil || owner.WaiterID == waiterID {
+		return nil, nil
+	}
+
+	return owner, nil
+}
+
+// Handoff passes a waiter's current section and open orders to a colleague
+func (s *WaiterService) Handoff(ctx context.Context, req waiter.HandoffRequest, businessID int) (*waiter.HandoffResult, error) {
+	if req.FromWaiterID <= 0 || req.ToWaiterID <= 0 || req.FromWaiterID == req.ToWaiterID {
+		return nil, waiter.ErrInvalidHandoff
+	}
+	if businessID <= 0 {
+		return nil, fmt.Errorf("invalid business ID: %d", businessID)
+	}
+
+	result, err := s.waiterRepo.Handoff(ctx, req, businessID)
+	if err != nil {
+		log.Printf("Error handing off waiter %d to waiter %d: %v", req.FromWaiterID, req.ToWaiterID, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// uniqueSectionIDs drops duplicates and rejects non-positive IDs
+func uniqueSectionIDs(ids []int) ([]int, error) {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, waiter.ErrInvalidSection
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+func sectionOverlaps(a, b []int) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+-- Canonical allergens and dietary tags: dishes store catalogue codes (see internal/domain/menu/allergen.go)
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS dietary_tags TEXT[];
+
+-- Free-text allergens that cannot be mapped are kept here for manual review
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS legacy_allergens TEXT[];
+
+CREATE TEMPORARY TABLE allergen_aliases (alias TEXT PRIMARY KEY, code TEXT NOT NULL);
+INSERT INTO allergen_aliases (alias, code) VALUES
+    ('gluten', 'gluten'),
+    ('cereals containing gluten', 'gluten'),
+    ('злаки, содержащие глютен', 'gluten'),
+    ('wheat', 'gluten'),
+    ('rye', 'gluten'),
+    ('barley', 'gluten'),
+    ('oats', 'gluten'),
+    ('spelt', 'gluten'),
+    ('kamut', 'gluten'),
+    ('глютен', 'gluten'),
+    ('пшеница', 'gluten'),
+    ('рожь', 'gluten'),
+    ('ячмень', 'gluten'),
+    ('овёс', 'gluten'),
+    ('овес', 'gluten'),
+    ('crustaceans', 'crustaceans'),
+    ('ракообразные', 'crustaceans'),
+    ('crustacean', 'crustaceans'),
+    ('shellfish', 'crustaceans'),
+    ('shrimp', 'crustaceans'),
+    ('prawn', 'crustaceans'),
+    ('prawns', 'crustaceans'),
+    ('crab', 'crustaceans'),
+    ('lobster', 'crustaceans'),
+    ('креветки', 'crustaceans'),
+    ('краб', 'crustaceans'),
+    ('омар', 'crustaceans'),
+    ('eggs', 'eggs'),
+    ('яйца', 'eggs'),
+    ('egg', 'eggs'),
+    ('яйцо', 'eggs'),
+    ('fish', 'fish'),
+    ('рыба', 'fish'),
+    ('peanuts', 'peanuts'),
+    ('арахис', 'peanuts'),
+    ('peanut', 'peanuts'),
+    ('groundnut', 'peanuts'),
+    ('soybeans', 'soybeans'),
+    ('соя', 'soybeans'),
+    ('soy', 'soybeans'),
+    ('soya', 'soybeans'),
+    ('soybean', 'soybeans'),
+    ('milk', 'milk'),
+    ('молоко', 'milk'),
+    ('dairy', 'milk'),
+    ('lactose', 'milk'),
+    ('cheese', 'milk'),
+    ('butter', 'milk'),
+    ('cream', 'milk'),
+    ('молочные продукты', 'milk'),
+    ('лактоза', 'milk'),
+    ('сыр', 'milk'),
+    ('nuts', 'nuts'),
+    ('tree nuts', 'nuts'),
+    ('орехи', 'nuts'),
+    ('nut', 'nuts'),
+    ('almond', 'nuts'),
+    ('almonds', 'nuts'),
+    ('hazelnut', 'nuts'),
+    ('hazelnuts', 'nuts'),
+    ('walnut', 'nuts'),
+    ('walnuts', 'nuts'),
+    ('cashew', 'nuts'),
+    ('cashews', 'nuts'),
+    ('pecan', 'nuts'),
+    ('pistachio', 'nuts'),
+    ('pistachios', 'nuts'),
+    ('macadamia', 'nuts'),
+    ('миндаль', 'nuts'),
+    ('фундук', 'nuts'),
+    ('грецкий орех', 'nuts'),
+    ('celery', 'celery'),
+    ('сельдерей', 'celery'),
+    ('mustard', 'mustard'),
+    ('горчица', 'mustard'),
+    ('sesame', 'sesame'),
+    ('sesame seeds', 'sesame'),
+    ('кунжут', 'sesame'),
+    ('sulphites', 'sulphites'),
+    ('sulphur dioxide and sulphites', 'sulphites'),
+    ('диоксид серы и сульфиты', 'sulphites'),
+    ('sulphite', 'sulphites'),
+    ('sulfites', 'sulphites'),
+    ('sulfite', 'sulphites'),
+    ('sulphur dioxide', 'sulphites'),
+    ('sulfur dioxide', 'sulphites'),
+    ('сульфиты', 'sulphites'),
+    ('lupin', 'lupin'),
+    ('люпин', 'lupin'),
+    ('lupine', 'lupin'),
+    ('molluscs', 'molluscs'),
+    ('моллюски', 'molluscs'),
+    ('mollusc', 'molluscs'),
+    ('mollusks', 'molluscs'),
+    ('mollusk', 'molluscs'),
+    ('squid', 'molluscs'),
+    ('octopus', 'molluscs'),
+    ('mussels', 'molluscs'),
+    ('oysters', 'molluscs'),
+    ('clams', 'molluscs'),
+    ('scallops', 'molluscs'),
+    ('кальмар', 'molluscs'),
+    ('мидии', 'molluscs');
+
+-- Allergen and dietary tag names per business and language. They cover languages the
+-- built-in catalogue has no name for and override the built-in names.
+CREATE TABLE IF NOT EXISTS catalogue_translations (
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    code VARCHAR(32) NOT NULL,
+    language VARCHAR(16) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (business_id, code, language)
+);
+
+-- Keep the translated allergens of dishes: each translated name is paired with the base
+-- allergen at the same position and becomes the business's name for its code
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_name = 'menu_translations' AND column_name = 'allergens') THEN
+        INSERT INTO catalogue_translations (business_id, code, language, name)
+        SELECT DISTINCT ON (t.business_id, m.code, t.language) t.business_id, m.code, t.language, trim(p.name)
+        FROM menu_translations t
+        JOIN dishes d ON d.id = t.entity_id
+        CROSS JOIN LATERAL unnest(d.allergens, t.allergens) AS p(base, name)
+        JOIN allergen_aliases m ON m.alias = lower(trim(p.base))
+        WHERE t.entity_type = 'dish' AND trim(p.name) <> ''
+        ORDER BY t.business_id, m.code, t.language, t.updated_at DESC
+        ON CONFLICT DO NOTHING;
+    END IF;
+END $$;
+
+UPDATE dishes d
+SET legacy_allergens = (
+        SELECT array_agg(DISTINCT trim(a))
+        FROM unnest(d.allergens) AS a
+        WHERE trim(a) <> '' AND NOT EXISTS (SELECT 1 FROM allergen_aliases WHERE alias = lower(trim(a)))
+    ),
+    allergens = (
+        SELECT COALESCE(array_agg(DISTINCT m.code), '{}')
+        FROM unnest(d.allergens) AS a
+        JOIN allergen_aliases m ON m.alias = lower(trim(a))
+    )
+WHERE d.allergens IS NOT NULL;
+
+DROP TABLE allergen_aliases;
+
+-- Allergens are named from the catalogue, so dish translations no longer carry them
+ALTER TABLE menu_translations DROP COLUMN IF EXISTS allergens;
+
+CREATE INDEX IF NOT EXISTS idx_dishes_allergens ON dishes USING GIN (allergens);
+CREATE INDEX IF NOT EXISTS idx_dishes_dietary_tags ON dishes USING GIN (dietary_tags);
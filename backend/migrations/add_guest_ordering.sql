@@ -0,0 +1,8 @@
+-- Guest ordering from table QR codes
+-- Bumping qr_version invalidates the printed codes of a table and the guest sessions opened from them
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS qr_version INTEGER NOT NULL DEFAULT 1;
+
+-- Guest orders have no waiter until one accepts them
+ALTER TABLE orders ALTER COLUMN waiter_id DROP NOT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS source VARCHAR(10) NOT NULL DEFAULT 'staff'
+    CHECK (source IN ('staff', 'guest'));
@@ -0,0 +1,6 @@
+-- Costs for menu engineering
+-- A manual per-portion cost on a dish overrides the cost computed from its recipe
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS cost DECIMAL(10,2) CHECK (cost >= 0);
+
+-- Cost of one unit of an inventory item, used to cost recipes
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(10,4) CHECK (unit_cost >= 0);
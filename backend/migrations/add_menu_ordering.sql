@@ -0,0 +1,24 @@
+-- Menu hierarchy, display order and visibility
+-- A category may sit below another one; removing the parent moves its children to the top level
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES categories(id) ON DELETE SET NULL;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS is_visible BOOLEAN NOT NULL DEFAULT true;
+
+-- Dishes are ordered within their category; hidden dishes stay orderable by staff but are left off curated menus
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS is_visible BOOLEAN NOT NULL DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_sort_order ON categories(business_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_dishes_sort_order ON dishes(category_id, sort_order);
+
+-- Keep the current alphabetical order as the starting layout
+UPDATE categories c
+SET sort_order = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY business_id ORDER BY name) - 1 AS position FROM categories) o
+WHERE c.id = o.id;
+
+UPDATE dishes d
+SET sort_order = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY category_id ORDER BY name) - 1 AS position FROM dishes) o
+WHERE d.id = o.id;
@@ -0,0 +1,47 @@
+-- Full-text and typo-tolerant menu search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Search document of a dish: its name weighs most, then its category, then its description.
+-- The 'simple' configuration keeps dish names as written (no stemming), so prefixes such as
+-- "carbo" match "carbonara".
+CREATE OR REPLACE FUNCTION dish_search_vector(dish_name TEXT, dish_description TEXT, dish_category_id INTEGER)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(dish_name, '')), 'A') ||
+           setweight(to_tsvector('simple', COALESCE((SELECT name FROM categories WHERE id = dish_category_id), '')), 'B') ||
+           setweight(to_tsvector('simple', COALESCE(dish_description, '')), 'C');
+$$ LANGUAGE sql STABLE;
+
+-- A generated column cannot read the category name, so triggers keep the vector current instead
+ALTER TABLE dishes ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION dishes_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := dish_search_vector(NEW.name, NEW.description, NEW.category_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS dishes_search_vector ON dishes;
+CREATE TRIGGER dishes_search_vector
+    BEFORE INSERT OR UPDATE OF name, description, category_id ON dishes
+    FOR EACH ROW EXECUTE FUNCTION dishes_search_vector_update();
+
+CREATE OR REPLACE FUNCTION categories_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    UPDATE dishes
+    SET search_vector = dish_search_vector(name, description, category_id)
+    WHERE category_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS categories_search_vector ON categories;
+CREATE TRIGGER categories_search_vector
+    AFTER UPDATE OF name ON categories
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION categories_search_vector_update();
+
+UPDATE dishes SET search_vector = dish_search_vector(name, description, category_id);
+
+CREATE INDEX IF NOT EXISTS idx_dishes_search_vector ON dishes USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_dishes_name_trgm ON dishes USING GIN (name gin_trgm_ops);
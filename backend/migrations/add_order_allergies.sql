@@ -0,0 +1,23 @@
+-- Guest allergy checks on orders: ingredient allergens, dish recipes and the business policy
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS allergen_policy VARCHAR(10) NOT NULL DEFAULT 'warn'
+    CHECK (allergen_policy IN ('warn', 'block'));
+
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS allergens TEXT[];
+
+-- Recipe quantities are per portion, in the unit of the inventory item
+CREATE TABLE IF NOT EXISTS dish_ingredients (
+    dish_id INTEGER NOT NULL REFERENCES dishes(id) ON DELETE CASCADE,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    quantity DECIMAL(10,3) NOT NULL CHECK (quantity > 0),
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    PRIMARY KEY (dish_id, inventory_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dish_ingredients_inventory ON dish_ingredients(inventory_id);
+
+-- Allergies recorded by the waiter: for the whole table and per seat ([{"seat": 2, "allergies": ["peanuts"]}])
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS allergies TEXT[];
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS seat_allergies JSONB;
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS seat INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS allergen_conflicts TEXT[];
@@ -0,0 +1,8 @@
+-- Table status derived from orders: a table goes to needs_cleaning when its last order
+-- is paid and, if the business sets a delay, back to free automatically
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS cleaning_since TIMESTAMPTZ;
+
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS table_auto_free_minutes INTEGER NOT NULL DEFAULT 0
+    CHECK (table_auto_free_minutes >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_tables_needs_cleaning ON tables(cleaning_since) WHERE status = 'needs_cleaning';
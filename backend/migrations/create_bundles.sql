@@ -0,0 +1,42 @@
+-- Combo and set-menu bundles
+CREATE TABLE IF NOT EXISTS bundles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT DEFAULT '',
+    price DECIMAL(10, 2) NOT NULL CHECK (price > 0),
+    is_available BOOLEAN NOT NULL DEFAULT true,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- A slot offers the dishes of a category and/or a list of dishes
+CREATE TABLE IF NOT EXISTS bundle_slots (
+    id SERIAL PRIMARY KEY,
+    bundle_id INTEGER NOT NULL REFERENCES bundles(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    dish_ids INTEGER[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_slots_bundle ON bundle_slots(bundle_id);
+
+-- Bundle lines of an order, billed at the bundle price. The components are order_items
+-- pointing at their line, priced at their share of the bundle price.
+CREATE TABLE IF NOT EXISTS order_bundles (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    bundle_id INTEGER REFERENCES bundles(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    price DECIMAL(10, 2) NOT NULL,
+    seat INTEGER NOT NULL DEFAULT 0,
+    notes TEXT DEFAULT '',
+    business_id INTEGER REFERENCES businesses(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_bundles_order ON order_bundles(order_id);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS order_bundle_id INTEGER REFERENCES order_bundles(id) ON DELETE CASCADE;
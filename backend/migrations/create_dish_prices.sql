@@ -0,0 +1,22 @@
+-- Dish price history and scheduled price changes
+-- Applied rows are the history of a dish price; rows without applied_at are scheduled
+-- and become the dish price once effective_from has passed
+CREATE TABLE IF NOT EXISTS dish_prices (
+    id SERIAL PRIMARY KEY,
+    dish_id INTEGER NOT NULL REFERENCES dishes(id) ON DELETE CASCADE,
+    business_id INTEGER REFERENCES businesses(id) ON DELETE CASCADE,
+    price DECIMAL(10, 2) NOT NULL CHECK (price > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dish_prices_dish ON dish_prices(dish_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_dish_prices_pending ON dish_prices(effective_from) WHERE applied_at IS NULL;
+
+-- Start the history of existing dishes with their current price
+INSERT INTO dish_prices (dish_id, business_id, price, effective_from, applied_at)
+SELECT d.id, d.business_id, d.price, COALESCE(d.created_at, NOW()), COALESCE(d.created_at, NOW())
+FROM dishes d
+WHERE d.price > 0 AND NOT EXISTS (SELECT 1 FROM dish_prices p WHERE p.dish_id = d.id);
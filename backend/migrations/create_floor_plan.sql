@@ -0,0 +1,32 @@
+-- Table management and floor plan
+-- Zones are named areas of the floor (hall, terrace, bar) with their own plan size
+CREATE TABLE IF NOT EXISTS floor_zones (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    width DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (width >= 0),
+    height DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (height >= 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_floor_zones_business_id ON floor_zones(business_id, sort_order);
+
+-- Placement of a table; positions are relative to its zone
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS name VARCHAR(50);
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS zone_id INTEGER REFERENCES floor_zones(id) ON DELETE SET NULL;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS shape VARCHAR(20) NOT NULL DEFAULT 'square'
+    CHECK (shape IN ('round', 'square', 'rectangle'));
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS pos_x DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS pos_y DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS width DOUBLE PRECISION NOT NULL DEFAULT 80;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS height DOUBLE PRECISION NOT NULL DEFAULT 80;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS rotation DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tables_zone_id ON tables(zone_id);
+
+-- Deleting a table must not take its order history with it
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_table_id_fkey;
+ALTER TABLE orders ADD CONSTRAINT orders_table_id_fkey
+    FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE SET NULL;
@@ -0,0 +1,27 @@
+-- Inventory costing. A business values its stock by weighted average cost or FIFO;
+-- receipts may carry the price paid, which moves the average and opens a FIFO layer.
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS costing_method VARCHAR(10) NOT NULL DEFAULT 'average'
+    CHECK (costing_method IN ('average', 'fifo'));
+
+-- FIFO layers: stock brought in by one movement at one cost, used up oldest first
+CREATE TABLE IF NOT EXISTS inventory_cost_layers (
+    id SERIAL PRIMARY KEY,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    movement_id INTEGER REFERENCES inventory_movements(id) ON DELETE SET NULL,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity > 0), -- stock unit of the item
+    remaining NUMERIC(12,3) NOT NULL CHECK (remaining >= 0),
+    unit_cost DECIMAL(10,4), -- NULL when the stock came in without a known cost
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_cost_layers_open ON inventory_cost_layers(inventory_id, id) WHERE remaining > 0;
+
+-- Stock already on hand opens one layer at the current unit cost
+INSERT INTO inventory_cost_layers (inventory_id, quantity, remaining, unit_cost)
+SELECT i.id, i.quantity, i.quantity, i.unit_cost
+FROM inventory i
+WHERE i.quantity > 0
+  AND NOT EXISTS (SELECT 1 FROM inventory_cost_layers l WHERE l.inventory_id = i.id);
+
+-- Every movement also keeps its unit cost under FIFO; unit_cost holds the weighted average
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS fifo_cost DECIMAL(10,4);
@@ -0,0 +1,37 @@
+-- Lots. Every receipt brings its stock in as a lot with an optional number, supplier and
+-- expiry date; stock leaves the lots first-expiring-first-out.
+CREATE TABLE IF NOT EXISTS inventory_lots (
+    id SERIAL PRIMARY KEY,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    lot_number VARCHAR(50),
+    supplier_id INTEGER REFERENCES suppliers(id) ON DELETE SET NULL,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity > 0), -- stock unit of the item
+    remaining NUMERIC(12,3) NOT NULL CHECK (remaining >= 0),
+    expires_on DATE, -- NULL for stock that does not expire
+    expiry_alerted_at TIMESTAMPTZ, -- set once managers were told the lot expires soon
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_lots_open ON inventory_lots(inventory_id, expires_on) WHERE remaining > 0;
+CREATE INDEX IF NOT EXISTS idx_inventory_lots_expiry ON inventory_lots(business_id, expires_on) WHERE remaining > 0;
+
+-- Receipts point at the lot they opened; outflows may name the lot drawn first
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS lot_id INTEGER REFERENCES inventory_lots(id) ON DELETE SET NULL;
+
+-- How much of each lot every outflow took
+CREATE TABLE IF NOT EXISTS inventory_lot_draws (
+    movement_id INTEGER NOT NULL REFERENCES inventory_movements(id) ON DELETE CASCADE,
+    lot_id INTEGER NOT NULL REFERENCES inventory_lots(id) ON DELETE CASCADE,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (movement_id, lot_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_lot_draws_lot ON inventory_lot_draws(lot_id);
+
+-- Stock already on hand becomes one lot without an expiry date
+INSERT INTO inventory_lots (inventory_id, business_id, quantity, remaining)
+SELECT i.id, i.business_id, i.quantity, i.quantity
+FROM inventory i
+WHERE i.quantity > 0 AND i.business_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM inventory_lots l WHERE l.inventory_id = i.id);
@@ -0,0 +1,46 @@
+-- Append-only stock ledger. inventory.quantity is kept in sync with the running
+-- balance in the same transaction as each movement.
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id SERIAL PRIMARY KEY,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL
+        CHECK (type IN ('receipt', 'consumption', 'waste', 'adjustment', 'transfer')),
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity <> 0), -- signed change of the stock
+    balance_after NUMERIC(12,3) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    order_id INTEGER REFERENCES orders(id) ON DELETE SET NULL,
+    request_id INTEGER REFERENCES requests(id) ON DELETE SET NULL,
+    transfer_item_id INTEGER REFERENCES inventory(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_item ON inventory_movements(inventory_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_order ON inventory_movements(order_id) WHERE order_id IS NOT NULL;
+
+-- The ledger is append-only. Changes cascading from deleted items, orders or users
+-- run inside a foreign key trigger and are let through.
+CREATE OR REPLACE FUNCTION forbid_movement_change() RETURNS trigger AS $$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        IF TG_OP = 'DELETE' THEN
+            RETURN OLD;
+        END IF;
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS inventory_movements_append_only ON inventory_movements;
+CREATE TRIGGER inventory_movements_append_only
+    BEFORE UPDATE OR DELETE ON inventory_movements
+    FOR EACH ROW EXECUTE FUNCTION forbid_movement_change();
+
+-- Open the ledger of existing items with their current stock
+INSERT INTO inventory_movements (inventory_id, business_id, type, quantity, balance_after, reason)
+SELECT i.id, i.business_id, 'adjustment', i.quantity, i.quantity, 'Opening stock'
+FROM inventory i
+WHERE i.quantity <> 0 AND i.business_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM inventory_movements m WHERE m.inventory_id = i.id);
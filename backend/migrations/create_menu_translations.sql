@@ -0,0 +1,19 @@
+-- Multi-language menus: business languages and translated category/dish texts
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS default_language VARCHAR(16) NOT NULL DEFAULT 'ru';
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS supported_languages TEXT[] NOT NULL DEFAULT '{ru}';
+
+-- Base texts in categories/dishes are in the default language; missing fields fall back to them
+CREATE TABLE IF NOT EXISTS menu_translations (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('category', 'dish')),
+    entity_id INTEGER NOT NULL,
+    language VARCHAR(16) NOT NULL,
+    name VARCHAR(255),
+    description TEXT,
+    allergens TEXT[],
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (entity_type, entity_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS idx_menu_translations_business_language ON menu_translations(business_id, language);
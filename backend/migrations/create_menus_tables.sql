@@ -0,0 +1,39 @@
+-- Scheduled menus (dayparts: breakfast, lunch, dinner, seasonal)
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+CREATE TABLE IF NOT EXISTS menus (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS menu_categories (
+    menu_id INTEGER NOT NULL REFERENCES menus(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (menu_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS menu_dishes (
+    menu_id INTEGER NOT NULL REFERENCES menus(id) ON DELETE CASCADE,
+    dish_id INTEGER NOT NULL REFERENCES dishes(id) ON DELETE CASCADE,
+    PRIMARY KEY (menu_id, dish_id)
+);
+
+-- Empty columns place no restriction: no days = every day, no times = all day, no dates = always
+CREATE TABLE IF NOT EXISTS menu_schedules (
+    id SERIAL PRIMARY KEY,
+    menu_id INTEGER NOT NULL REFERENCES menus(id) ON DELETE CASCADE,
+    days_of_week INTEGER[],
+    start_time TIME,
+    end_time TIME,
+    start_date DATE,
+    end_date DATE
+);
+
+CREATE INDEX IF NOT EXISTS idx_menus_business ON menus(business_id);
+CREATE INDEX IF NOT EXISTS idx_menu_schedules_menu ON menu_schedules(menu_id);
+CREATE INDEX IF NOT EXISTS idx_menu_dishes_dish ON menu_dishes(dish_id);
@@ -0,0 +1,24 @@
+-- Reordering. Items get a par level and are linked to the suppliers selling them;
+-- low stock is turned into draft requests, whose lines record what was ordered so
+-- the same stock is not ordered twice.
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS par_quantity NUMERIC(12,3) NOT NULL DEFAULT 0 CHECK (par_quantity >= 0);
+
+CREATE TABLE IF NOT EXISTS inventory_suppliers (
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    preferred BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (inventory_id, supplier_id)
+);
+
+-- At most one preferred supplier per item
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inventory_suppliers_preferred ON inventory_suppliers(inventory_id) WHERE preferred;
+CREATE INDEX IF NOT EXISTS idx_inventory_suppliers_supplier ON inventory_suppliers(supplier_id);
+
+CREATE TABLE IF NOT EXISTS request_lines (
+    request_id INTEGER NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity > 0), -- stock unit of the item
+    PRIMARY KEY (request_id, inventory_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_lines_inventory ON request_lines(inventory_id);
@@ -0,0 +1,39 @@
+-- Table reservations
+CREATE TABLE IF NOT EXISTS reservations (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    guest_name VARCHAR(100) NOT NULL,
+    phone VARCHAR(30) NOT NULL,
+    party_size INTEGER NOT NULL CHECK (party_size > 0),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'booked'
+        CHECK (status IN ('booked', 'seated', 'completed', 'cancelled', 'no_show')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_business_starts_at ON reservations(business_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_booked ON reservations(starts_at) WHERE status = 'booked';
+
+-- Tables assigned to a reservation; several rows combine tables for a large party
+CREATE TABLE IF NOT EXISTS reservation_tables (
+    reservation_id INTEGER NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    table_id INTEGER NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+    PRIMARY KEY (reservation_id, table_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_tables_table_id ON reservation_tables(table_id);
+
+-- Opening periods bookings must fit in; closes_at at or before opens_at runs past midnight
+CREATE TABLE IF NOT EXISTS opening_hours (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_opening_hours_business_id ON opening_hours(business_id);
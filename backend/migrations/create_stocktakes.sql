@@ -0,0 +1,39 @@
+-- Stocktake sessions. The system quantity of each item is taken when the session
+-- opens; approving it posts counted minus system quantity as an adjustment.
+CREATE TABLE IF NOT EXISTS stocktakes (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    category VARCHAR(100), -- NULL counts every item
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'approved', 'cancelled')),
+    note TEXT,
+    opened_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    approved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+-- One open session per business at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stocktakes_open ON stocktakes(business_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_stocktakes_closed ON stocktakes(business_id, closed_at) WHERE status = 'approved';
+
+CREATE TABLE IF NOT EXISTS stocktake_lines (
+    stocktake_id INTEGER NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    system_quantity NUMERIC(12,3) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    unit_cost DECIMAL(10,4), -- kept on approval for the variance report
+    PRIMARY KEY (stocktake_id, inventory_id)
+);
+
+-- One count per counter and item; several counters add up
+CREATE TABLE IF NOT EXISTS stocktake_counts (
+    id SERIAL PRIMARY KEY,
+    stocktake_id INTEGER NOT NULL,
+    inventory_id INTEGER NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity >= 0),
+    counted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (stocktake_id, inventory_id) REFERENCES stocktake_lines(stocktake_id, inventory_id) ON DELETE CASCADE,
+    UNIQUE (stocktake_id, inventory_id, user_id)
+);
@@ -0,0 +1,42 @@
+-- Table status history. Every status change is recorded by a trigger; the application
+-- leaves the user and the seated party size on the row for the trigger to pick up.
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS status_changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tables ADD COLUMN IF NOT EXISTS party_size INTEGER;
+
+CREATE TABLE IF NOT EXISTS table_status_history (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER,
+    table_id INTEGER NOT NULL, -- no foreign key: the history outlives a deleted table
+    from_status VARCHAR(20), -- NULL for a newly created table
+    to_status VARCHAR(20) NOT NULL,
+    party_size INTEGER,
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL, -- NULL for system changes
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE table_status_history DROP CONSTRAINT IF EXISTS table_status_history_table_id_fkey;
+
+CREATE INDEX IF NOT EXISTS idx_table_status_history_business ON table_status_history(business_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_table_status_history_table ON table_status_history(table_id, changed_at);
+
+CREATE OR REPLACE FUNCTION record_table_status() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO table_status_history (business_id, table_id, from_status, to_status, party_size, changed_by)
+        VALUES (NEW.business_id, NEW.id, CASE WHEN TG_OP = 'UPDATE' THEN OLD.status END,
+                NEW.status, NEW.party_size, NEW.status_changed_by);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tables_status_history ON tables;
+CREATE TRIGGER tables_status_history
+    AFTER INSERT OR UPDATE OF status ON tables
+    FOR EACH ROW EXECUTE FUNCTION record_table_status();
+
+-- Start the history of existing tables from their current status
+INSERT INTO table_status_history (business_id, table_id, to_status, changed_at)
+SELECT business_id, id, status, COALESCE(occupied_at, reserved_at, NOW())
+FROM tables t
+WHERE NOT EXISTS (SELECT 1 FROM table_status_history h WHERE h.table_id = t.id);
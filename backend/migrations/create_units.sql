@@ -0,0 +1,36 @@
+-- Units catalogue. Every unit converts to the base unit of its dimension
+-- (g, ml, pcs) by factor; aliases are other spellings accepted on input.
+CREATE TABLE IF NOT EXISTS units (
+    code VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    dimension VARCHAR(10) NOT NULL CHECK (dimension IN ('mass', 'volume', 'count')),
+    factor NUMERIC(14,6) NOT NULL CHECK (factor > 0),
+    aliases TEXT[] NOT NULL DEFAULT '{}'
+);
+
+INSERT INTO units (code, name, dimension, factor, aliases) VALUES
+    ('mg', 'Milligram', 'mass', 0.001, '{"мг"}'),
+    ('g', 'Gram', 'mass', 1, '{"г", "гр", "гр."}'),
+    ('kg', 'Kilogram', 'mass', 1000, '{"кг"}'),
+    ('ml', 'Millilitre', 'volume', 1, '{"мл"}'),
+    ('cl', 'Centilitre', 'volume', 10, '{"сл"}'),
+    ('l', 'Litre', 'volume', 1000, '{"л"}'),
+    ('pcs', 'Piece', 'count', 1, '{"шт", "шт.", "pc"}'),
+    ('dozen', 'Dozen', 'count', 12, '{"дюжина"}')
+ON CONFLICT (code) DO NOTHING;
+
+-- Item-specific units: one from_unit equals factor to_unit (1 case = 12 bottles)
+CREATE TABLE IF NOT EXISTS inventory_unit_conversions (
+    id SERIAL PRIMARY KEY,
+    inventory_id INTEGER NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    from_unit VARCHAR(20) NOT NULL,
+    to_unit VARCHAR(20) NOT NULL,
+    factor NUMERIC(14,6) NOT NULL CHECK (factor > 0),
+    UNIQUE (inventory_id, from_unit, to_unit)
+);
+
+-- Unit of the minimum stock level; NULL means the stock unit
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS min_unit VARCHAR(20);
+
+-- Stock unit of the item at the time of each movement
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS unit VARCHAR(20);
@@ -0,0 +1,16 @@
+-- Waiter sections: tables or whole floor zones a waiter covers during a shift
+CREATE TABLE IF NOT EXISTS waiter_assignments (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    shift_id INTEGER NOT NULL REFERENCES shifts(id) ON DELETE CASCADE,
+    waiter_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    table_id INTEGER REFERENCES tables(id) ON DELETE CASCADE,
+    zone_id INTEGER REFERENCES floor_zones(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((table_id IS NULL) <> (zone_id IS NULL)),
+    -- a table or zone belongs to one waiter per shift
+    UNIQUE (shift_id, table_id),
+    UNIQUE (shift_id, zone_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waiter_assignments_waiter ON waiter_assignments(business_id, waiter_id);
@@ -0,0 +1,18 @@
+-- Walk-in waitlist
+CREATE TABLE IF NOT EXISTS waitlist (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    guest_name VARCHAR(100) NOT NULL,
+    phone VARCHAR(30) NOT NULL DEFAULT '',
+    party_size INTEGER NOT NULL CHECK (party_size > 0),
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting'
+        CHECK (status IN ('waiting', 'notified', 'seated', 'no_show', 'left')),
+    table_ids INTEGER[] NOT NULL DEFAULT '{}', -- where the party was seated
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMPTZ,
+    seated_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_waiting ON waitlist(business_id, added_at) WHERE status IN ('waiting', 'notified');
@@ -0,0 +1,26 @@
+-- Waste log. Each entry is either an inventory item or portions of a dish; the stock
+-- it took out is recorded as waste movements pointing back at the entry.
+CREATE TABLE IF NOT EXISTS waste_entries (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    inventory_id INTEGER REFERENCES inventory(id) ON DELETE SET NULL,
+    dish_id INTEGER REFERENCES dishes(id) ON DELETE SET NULL,
+    quantity NUMERIC(12,3) NOT NULL CHECK (quantity > 0), -- stock unit of the item, or dish portions
+    unit VARCHAR(20),
+    reason VARCHAR(20) NOT NULL
+        CHECK (reason IN ('spoiled', 'dropped', 'overproduced', 'returned')),
+    note TEXT,
+    photo_url VARCHAR(255),
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    shift_id INTEGER REFERENCES shifts(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_entries_business ON waste_entries(business_id, created_at);
+
+-- Waste movements point at their entry, and every movement keeps the unit cost of
+-- the item when it was recorded so losses can be valued later
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS waste_id INTEGER REFERENCES waste_entries(id) ON DELETE SET NULL;
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(10,4);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_waste ON inventory_movements(waste_id) WHERE waste_id IS NOT NULL;
@@ -0,0 +1,294 @@
+// guest.js - Menu and ordering page opened from a table QR code
+
+const guestLabels = {
+    ru: {
+        table: 'Стол',
+        cart: 'Ваш заказ',
+        allergies: 'Аллергии (через запятую)',
+        comment: 'Комментарий',
+        back: 'Назад',
+        submit: 'Отправить заказ',
+        showCart: (count, total) => `Заказ: ${count} шт. · ${total}`,
+        loading: 'Загрузка меню...',
+        empty: 'Сейчас нет доступных блюд',
+        invalidCode: 'QR-код недействителен. Попросите официанта о помощи.',
+        sent: 'Заказ отправлен. Официант скоро подтвердит его.',
+        tooMany: 'Слишком много заказов. Попробуйте позже или позовите официанта.',
+        conflict: 'Заказ содержит аллергены:',
+        failed: 'Не удалось отправить заказ. Позовите официанта.',
+        contains: 'Содержит'
+    },
+    en: {
+        table: 'Table',
+        cart: 'Your order',
+        allergies: 'Allergies (comma separated)',
+        comment: 'Comment',
+        back: 'Back',
+        submit: 'Send order',
+        showCart: (count, total) => `Order: ${count} items · ${total}`,
+        loading: 'Loading menu...',
+        empty: 'No dishes are available right now',
+        invalidCode: 'This QR code is no longer valid. Please ask a waiter for help.',
+        sent: 'Your order has been sent. A waiter will confirm it shortly.',
+        tooMany: 'Too many orders. Please try again later or call a waiter.',
+        conflict: 'The order contains allergens:',
+        failed: 'Could not send the order. Please call a waiter.',
+        contains: 'Contains'
+    }
+};
+
+const guestState = {
+    qrToken: decodeURIComponent(window.location.pathname.replace(/^\/t\//, '')),
+    session: null,
+    menu: null,
+    catalogue: {},
+    language: '',
+    cart: new Map() // dish id -> quantity
+};
+
+function label(key) {
+    const labels = guestLabels[guestState.language] || guestLabels.ru;
+    return labels[key];
+}
+
+function escapeHTML(value) {
+    const div = document.createElement('div');
+    div.textContent = value == null ? '' : String(value);
+    return div.innerHTML;
+}
+
+function formatPrice(value) {
+    return `${Number(value).toLocaleString(guestState.language || 'ru')} KZT`;
+}
+
+// Sessions are kept per QR code so reopening the page does not start a new one
+async function startGuestSession() {
+    const storageKey = `guestSession:${guestState.qrToken}`;
+    const stored = JSON.parse(sessionStorage.getItem(storageKey) || 'null');
+    if (stored && new Date(stored.expires_at) > new Date()) {
+        guestState.session = stored;
+        return true;
+    }
+
+    const response = await fetch('/api/public/sessions', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ token: guestState.qrToken })
+    });
+    if (!response.ok) {
+        return false;
+    }
+    guestState.session = await response.json();
+    sessionStorage.setItem(storageKey, JSON.stringify(guestState.session));
+    return true;
+}
+
+async function loadCatalogue() {
+    const response = await fetch('/api/public/allergens');
+    if (!response.ok) {
+        return;
+    }
+    const data = await response.json();
+    for (const entry of data.allergens) {
+        guestState.catalogue[entry.code] = entry.names;
+    }
+}
+
+async function loadMenu(lang = '') {
+    const query = lang ? `?lang=${encodeURIComponent(lang)}` : '';
+    const response = await fetch(`/api/public/businesses/${guestState.session.business_id}/menu${query}`);
+    if (!response.ok) {
+        throw new Error(`menu request failed with ${response.status}`);
+    }
+    guestState.menu = await response.json();
+    guestState.language = guestState.menu.language;
+    document.documentElement.lang = guestState.language;
+    renderMenu();
+}
+
+function allergenName(code) {
+    const names = guestState.catalogue[code];
+    if (!names) {
+        return code;
+    }
+    return names[guestState.language] || names.en || code;
+}
+
+function renderMenu() {
+    const menu = guestState.menu;
+    document.title = menu.business_name;
+    document.getElementById('businessName').textContent = menu.business_name;
+    document.getElementById('tableNumber').textContent = `${label('table')} ${guestState.session.table_number}`;
+
+    const logo = document.getElementById('businessLogo');
+    if (menu.logo) {
+        logo.src = menu.logo;
+        logo.hidden = false;
+    }
+
+    const languageSelect = document.getElementById('languageSelect');
+    languageSelect.hidden = menu.languages.length < 2;
+    languageSelect.innerHTML = menu.languages.map(lang =>
+        `<option value="${escapeHTML(lang)}" ${lang === menu.language ? 'selected' : ''}>${escapeHTML(lang.toUpperCase())}</option>`
+    ).join('');
+
+    document.querySelectorAll('[data-label]').forEach(el => {
+        el.textContent = label(el.dataset.label);
+    });
+
+    document.getElementById('guestMessage').textContent = menu.categories.length === 0 ? label('empty') : '';
+    document.getElementById('categoryNav').innerHTML = menu.categories.map(category =>
+        `<a href="#category-${category.id}">${escapeHTML(category.name)}</a>`
+    ).join('');
+    document.getElementById('menuList').innerHTML = menu.categories.map(category => `
+        <section class="menu-category" id="category-${category.id}">
+            <h2>${escapeHTML(category.name)}</h2>
+            ${category.items.map(renderMenuItem).join('')}
+        </section>
+    `).join('');
+
+    renderCartBar();
+}
+
+function renderMenuItem(item) {
+    const image = (item.thumbnails && item.thumbnails.small) || item.image_url;
+    const quantity = guestState.cart.get(item.id) || 0;
+    return `
+        <div class="menu-item">
+            ${image ? `<img class="menu-item__image" src="${escapeHTML(image)}" alt="" loading="lazy">` : ''}
+            <div class="menu-item__body">
+                <div class="menu-item__name">${escapeHTML(item.name)}</div>
+                ${item.description ? `<div class="menu-item__description">${escapeHTML(item.description)}</div>` : ''}
+                ${item.allergens && item.allergens.length ? `<div class="menu-item__allergens">${label('contains')}: ${item.allergens.map(code => escapeHTML(allergenName(code))).join(', ')}</div>` : ''}
+                <div class="menu-item__footer">
+                    <div class="menu-item__price">${formatPrice(item.price)}</div>
+                    <div class="quantity">
+                        ${quantity > 0 ? `<button onclick="changeQuantity(${item.id}, -1)">−</button><span>${quantity}</span>` : ''}
+                        <button onclick="changeQuantity(${item.id}, 1)">+</button>
+                    </div>
+                </div>
+            </div>
+        </div>
+    `;
+}
+
+function menuItems() {
+    return guestState.menu.categories.flatMap(category => category.items);
+}
+
+function changeQuantity(dishId, delta) {
+    const quantity = (guestState.cart.get(dishId) || 0) + delta;
+    if (quantity > 0) {
+        guestState.cart.set(dishId, quantity);
+    } else {
+        guestState.cart.delete(dishId);
+    }
+    renderMenu();
+    if (!document.getElementById('cartDialog').hidden) {
+        renderCart();
+    }
+}
+
+function cartTotals() {
+    let count = 0;
+    let total = 0;
+    for (const item of menuItems()) {
+        const quantity = guestState.cart.get(item.id) || 0;
+        count += quantity;
+        total += quantity * item.price;
+    }
+    return { count, total };
+}
+
+function renderCartBar() {
+    const { count, total } = cartTotals();
+    document.getElementById('cartBar').hidden = count === 0;
+    document.getElementById('cartButton').textContent = label('showCart')(count, formatPrice(total));
+}
+
+function renderCart() {
+    document.getElementById('cartItems').innerHTML = menuItems()
+        .filter(item => guestState.cart.has(item.id))
+        .map(renderMenuItem)
+        .join('');
+}
+
+function showCartError(message) {
+    const error = document.getElementById('cartError');
+    error.innerHTML = message;
+    error.hidden = !message;
+}
+
+async function submitOrder() {
+    const submit = document.getElementById('cartSubmit');
+    submit.disabled = true;
+    showCartError('');
+
+    const order = {
+        items: Array.from(guestState.cart, ([dishId, quantity]) => ({ dishId, quantity })),
+        comment: document.getElementById('cartComment').value.trim(),
+        allergies: document.getElementById('cartAllergies').value.split(',').map(v => v.trim()).filter(Boolean)
+    };
+
+    try {
+        const response = await fetch('/api/public/orders', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+                'Authorization': `Bearer ${guestState.session.token}`
+            },
+            body: JSON.stringify(order)
+        });
+
+        if (response.status === 201) {
+            guestState.cart.clear();
+            document.getElementById('cartComment').value = '';
+            document.getElementById('cartDialog').hidden = true;
+            document.getElementById('guestMessage').textContent = label('sent');
+            renderMenu();
+            window.scrollTo(0, 0);
+        } else if (response.status === 409) {
+            const data = await response.json();
+            const conflicts = data.allergy_warnings.map(w => `${escapeHTML(w.dish_name)}: ${escapeHTML(allergenName(w.allergen))}`);
+            showCartError(`${label('conflict')}<br>${conflicts.join('<br>')}`);
+        } else if (response.status === 401) {
+            sessionStorage.removeItem(`guestSession:${guestState.qrToken}`);
+            showCartError(escapeHTML(label('invalidCode')));
+        } else if (response.status === 429) {
+            showCartError(escapeHTML(label('tooMany')));
+        } else {
+            showCartError(escapeHTML(await response.text() || label('failed')));
+        }
+    } catch (error) {
+        console.error('Error sending guest order:', error);
+        showCartError(escapeHTML(label('failed')));
+    } finally {
+        submit.disabled = false;
+    }
+}
+
+document.addEventListener('DOMContentLoaded', async () => {
+    document.getElementById('cartButton').addEventListener('click', () => {
+        renderCart();
+        document.getElementById('cartDialog').hidden = false;
+    });
+    document.getElementById('cartClose').addEventListener('click', () => {
+        document.getElementById('cartDialog').hidden = true;
+    });
+    document.getElementById('cartSubmit').addEventListener('click', submitOrder);
+    document.getElementById('languageSelect').addEventListener('change', event => {
+        loadMenu(event.target.value).catch(error => console.error('Error loading menu:', error));
+    });
+
+    try {
+        if (!await startGuestSession()) {
+            document.getElementById('guestMessage').textContent = label('invalidCode');
+            return;
+        }
+        await loadCatalogue();
+        await loadMenu();
+    } catch (error) {
+        console.error('Error loading menu:', error);
+        document.getElementById('guestMessage').textContent = label('failed');
+    }
+});
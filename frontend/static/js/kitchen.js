@@ -154,9 +154,10 @@ async function loadKitchenOrders() {
                         <div class="order-card__time">${formatOrderTime(order.created_at)}</div>
                     </div>
                 </div>
+                ${order.allergy_banner ? `<div class="order-card__allergy">⚠ ${order.allergy_banner}</div>` : ''}
                 <div class="order-card__items">
                     ${order.items.map(item => `
-                        <div>${item.quantity} × ${item.name}</div>
+                        <div class="${item.allergen_conflicts && item.allergen_conflicts.length ? 'order-card__item--allergen' : ''}">${item.quantity} × ${item.name}${item.seat ? ` (место ${item.seat})` : ''}${bundleLabel(order, item)}</div>
                     `).join('')}
                 </div>
                 <div class="order-card__footer">
@@ -172,6 +173,13 @@ async function loadKitchenOrders() {
     }
 }
 
+// bundleLabel names the combo a dish was ordered in, so the kitchen can plate it together
+function bundleLabel(order, item) {
+    if (!item.order_bundle_id || !order.bundles) return '';
+    const bundle = order.bundles.find(b => b.id === item.order_bundle_id);
+    return bundle ? ` — ${bundle.name}` : '';
+}
+
 async function loadKitchenHistory() {
     const historyListEl = document.getElementById('kitchenHistoryList');
     historyListEl.innerHTML = '<div class="loading">Загрузка истории...</div>';
@@ -362,7 +362,7 @@ function renderOrdersWithFilter() {
                 <div class="order-card__header">
                     <div class="order-card__id">#${order.id}</div>
                     <div class="order-card__info">
-                        <div class="order-card__table">Стол ${order.table_id}</div>
+                        <div class="order-card__table">Стол ${order.table_id}${order.source === 'guest' ? ' <span class="order-card__source">QR</span>' : ''}</div>
                         <div class="order-card__time">${formatOrderTime(order.created_at)}</div>
                     </div>
                 </div>
@@ -1575,7 +1575,9 @@ async function createOrder() {
         if (!createdOrder) return; // Request failed or redirect happened
         
         console.log('Order created:', createdOrder);
-        alert('Заказ успешно создан!');
+        alert(createdOrder.section_warning
+            ? `Заказ успешно создан!\nВнимание: ${createdOrder.section_warning}`
+            : 'Заказ успешно создан!');
         
         hideConfirmOrderModal(); // Assuming this is still relevant for a final confirmation step
                                // If not, it can be removed or repurposed.
@@ -2089,7 +2091,8 @@ function translateTableStatus(status) {
     const translations = {
         'free': 'Свободен',
         'occupied': 'Занят',
-        'reserved': 'Забронирован'
+        'reserved': 'Забронирован',
+        'needs_cleaning': 'Уборка'
     };
     return translations[status] || status;
 }